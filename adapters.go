@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"reflect"
+)
+
+/*************************************************************
+ * adapters for web frameworks
+ *************************************************************/
+
+// GinValidator implements the gin `binding.StructValidator` interface.
+// so the gin `c.ShouldBind()` will use the `validate` tags, messages
+// and translates of this package.
+//
+// Usage:
+// 	import "github.com/gin-gonic/gin/binding"
+//
+// 	binding.Validator = &validate.GinValidator{}
+type GinValidator struct {
+	// ConfigFunc custom config the Validation before validate. optional
+	ConfigFunc func(v *Validation)
+}
+
+// NewGinValidator create a gin struct validator
+func NewGinValidator(configFn ...func(v *Validation)) *GinValidator {
+	gv := &GinValidator{}
+	if len(configFn) > 0 {
+		gv.ConfigFunc = configFn[0]
+	}
+	return gv
+}
+
+// ValidateStruct validate the struct, slice or array data.
+// will return nil on the data is not an struct.
+func (gv *GinValidator) ValidateStruct(ptr interface{}) error {
+	return validateAny(ptr, gv.ConfigFunc)
+}
+
+// Engine returns the underlying validator engine
+func (gv *GinValidator) Engine() interface{} {
+	return gv
+}
+
+// EchoValidator implements the echo `Validator` interface.
+//
+// Usage:
+// 	e := echo.New()
+// 	e.Validator = validate.NewEchoValidator(func(es validate.Errors) error {
+// 		return echo.NewHTTPError(http.StatusUnprocessableEntity, es.All())
+// 	})
+type EchoValidator struct {
+	// ConfigFunc custom config the Validation before validate. optional
+	ConfigFunc func(v *Validation)
+	// ErrorFunc convert the Errors to the framework error model. optional
+	ErrorFunc func(es Errors) error
+}
+
+// NewEchoValidator create a echo validator
+func NewEchoValidator(errFn ...func(es Errors) error) *EchoValidator {
+	ev := &EchoValidator{}
+	if len(errFn) > 0 {
+		ev.ErrorFunc = errFn[0]
+	}
+	return ev
+}
+
+// Validate the struct data, implements the echo.Validator
+func (ev *EchoValidator) Validate(i interface{}) error {
+	return convertErrors(validateAny(i, ev.ConfigFunc), ev.ErrorFunc)
+}
+
+// FiberValidator implements the fiber `StructValidator` interface.
+//
+// Usage:
+// 	app := fiber.New(fiber.Config{
+// 		StructValidator: validate.NewFiberValidator(func(es validate.Errors) error {
+// 			return fiber.NewError(fiber.StatusUnprocessableEntity, es.One())
+// 		}),
+// 	})
+//
+// for fiber v2, call it in the handler:
+// 	err := fv.Validate(form)
+type FiberValidator struct {
+	// ConfigFunc custom config the Validation before validate. optional
+	ConfigFunc func(v *Validation)
+	// ErrorFunc convert the Errors to the framework error model. optional
+	ErrorFunc func(es Errors) error
+}
+
+// NewFiberValidator create a fiber validator
+func NewFiberValidator(errFn ...func(es Errors) error) *FiberValidator {
+	fv := &FiberValidator{}
+	if len(errFn) > 0 {
+		fv.ErrorFunc = errFn[0]
+	}
+	return fv
+}
+
+// Validate the struct data, implements the fiber.StructValidator
+func (fv *FiberValidator) Validate(out interface{}) error {
+	return convertErrors(validateAny(out, fv.ConfigFunc), fv.ErrorFunc)
+}
+
+// validate the struct, slice or array data.
+// will return nil on the data is not an struct.
+func validateAny(ptr interface{}, configFn func(v *Validation)) error {
+	if ptr == nil {
+		return nil
+	}
+
+	rv, isNil := indirect(reflect.ValueOf(ptr))
+	if isNil {
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return validateStructValue(ptr, configFn)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i)
+			// use pointer, allow update source value.
+			if elem.CanAddr() && elem.Kind() == reflect.Struct {
+				elem = elem.Addr()
+			}
+
+			if err := validateAny(elem.Interface(), configFn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// convert the Errors by the errFn
+func convertErrors(err error, errFn func(es Errors) error) error {
+	if err == nil || errFn == nil {
+		return err
+	}
+
+	if es, ok := err.(Errors); ok {
+		return errFn(es)
+	}
+	return err
+}
+
+// validate an struct and return Errors on fail
+func validateStructValue(ptr interface{}, configFn func(v *Validation)) error {
+	v := Struct(ptr)
+	if configFn != nil {
+		configFn(v)
+	}
+
+	if v.Validate() {
+		return nil
+	}
+	return v.Errors
+}
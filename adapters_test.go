@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type adapterForm struct {
+	Name string `validate:"required|minLen:3"`
+	Age  int    `validate:"required|min:1"`
+}
+
+func TestGinValidator(t *testing.T) {
+	is := assert.New(t)
+
+	// is an implements of the gin binding.StructValidator
+	var sv interface {
+		ValidateStruct(interface{}) error
+		Engine() interface{}
+	} = NewGinValidator()
+
+	is.NotNil(sv.Engine())
+	is.Nil(sv.ValidateStruct(nil))
+	is.Nil(sv.ValidateStruct("not struct"))
+	is.Nil(sv.ValidateStruct(&adapterForm{Name: "inhere", Age: 20}))
+
+	err := sv.ValidateStruct(&adapterForm{Name: "in", Age: 20})
+	is.Error(err)
+	es, ok := err.(Errors)
+	is.True(ok)
+	is.Equal("Name min length is 3", es.FieldOne("Name"))
+
+	// slice
+	is.Nil(sv.ValidateStruct([]adapterForm{{Name: "inhere", Age: 2}}))
+	err = sv.ValidateStruct(&[]adapterForm{{Name: "inhere", Age: 2}, {Name: "tom"}})
+	is.Error(err)
+	is.Contains(err.Error(), "Age is required")
+
+	// with config func
+	sv = NewGinValidator(func(v *Validation) {
+		v.WithMessages(MS{"Name.minLength": "{field} is too short"})
+	})
+	err = sv.ValidateStruct(&adapterForm{Name: "in", Age: 20})
+	is.Equal("Name is too short", err.(Errors).One())
+}
+
+type testHTTPError struct {
+	Code    int
+	Message interface{}
+}
+
+func (e *testHTTPError) Error() string {
+	return "http error"
+}
+
+func TestEchoValidator(t *testing.T) {
+	is := assert.New(t)
+
+	// is an implements of the echo.Validator
+	var ev interface {
+		Validate(i interface{}) error
+	} = NewEchoValidator()
+
+	is.Nil(ev.Validate(&adapterForm{Name: "inhere", Age: 20}))
+	err := ev.Validate(&adapterForm{Name: "inhere"})
+	is.Equal("Age is required and not empty", err.(Errors).One())
+
+	ev = NewEchoValidator(func(es Errors) error {
+		return &testHTTPError{Code: 422, Message: es.All()}
+	})
+	err = ev.Validate(&adapterForm{Name: "inhere"})
+	he, ok := err.(*testHTTPError)
+	is.True(ok)
+	is.Equal(422, he.Code)
+	is.Contains(he.Message, "Age")
+}
+
+func TestFiberValidator(t *testing.T) {
+	is := assert.New(t)
+
+	fv := NewFiberValidator(func(es Errors) error {
+		return &testHTTPError{Code: 400, Message: es.One()}
+	})
+	is.Nil(fv.Validate(&adapterForm{Name: "inhere", Age: 20}))
+
+	err := fv.Validate(&adapterForm{Name: "in", Age: 20})
+	is.Equal("Name min length is 3", err.(*testHTTPError).Message)
+}
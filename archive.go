@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"mime/multipart"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var errNotArchive = errors.New("the file is not a zip or tar archive")
+
+// inspect the entries of the uploaded zip, tar or tar.gz file.
+// only the headers are read for the zip. the fn return false will stop the inspection.
+func inspectArchive(fh *multipart.FileHeader, fn func(name string, size int64) bool) error {
+	file, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var head [512]byte
+	n, _ := io.ReadFull(file, head[:])
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	switch {
+	case bytes.HasPrefix(head[:n], []byte("PK\x03\x04")), bytes.HasPrefix(head[:n], []byte("PK\x05\x06")):
+		zr, err := zip.NewReader(file, fh.Size)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range zr.File {
+			if !fn(f.Name, int64(f.UncompressedSize64)) {
+				return nil
+			}
+		}
+		return nil
+	case bytes.HasPrefix(head[:n], []byte{0x1f, 0x8b}):
+		gr, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		return inspectTar(gr, fn)
+	case n > 262 && string(head[257:262]) == "ustar":
+		return inspectTar(file, fn)
+	}
+	return errNotArchive
+}
+
+func inspectTar(r io.Reader, fn func(name string, size int64) bool) error {
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if h.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		if !fn(h.Name, h.Size) {
+			return nil
+		}
+	}
+}
+
+// ArchiveMaxEntries check the uploaded archive(zip, tar, tar.gz) entries number is not greater than max.
+// Usage:
+// 	v.StringRule("backup", "archiveMaxEntries:100")
+func (v *Validation) ArchiveMaxEntries(fd *FormData, field string, max int64) bool {
+	fh := fd.GetFile(field)
+	if fh == nil {
+		return false
+	}
+
+	var count int64
+	err := inspectArchive(fh, func(_ string, _ int64) bool {
+		count++
+		return count <= max
+	})
+	return err == nil && count <= max
+}
+
+// ArchiveMaxSize check the total uncompressed size of the uploaded archive entries is not greater than max bytes.
+// it is useful for guard the zip bomb. allow the size unit: K, M, G.
+// Usage:
+// 	v.StringRule("backup", "archiveMaxSize:100M")
+func (v *Validation) ArchiveMaxSize(fd *FormData, field string, max int64) bool {
+	fh := fd.GetFile(field)
+	if fh == nil {
+		return false
+	}
+
+	var total int64
+	err := inspectArchive(fh, func(_ string, size int64) bool {
+		// the size from the header may be invalid
+		if size < 0 {
+			total = max + 1
+		} else {
+			total += size
+		}
+		return total <= max
+	})
+	return err == nil && total <= max
+}
+
+// ArchiveExts check the uploaded archive entries extensions are in the exts.
+// Usage:
+// 	v.StringRule("images", "archiveExts:jpg,png")
+func (v *Validation) ArchiveExts(fd *FormData, field string, exts ...string) bool {
+	fh := fd.GetFile(field)
+	if fh == nil {
+		return false
+	}
+
+	ok := true
+	err := inspectArchive(fh, func(name string, _ int64) bool {
+		// skip the directory entry of the zip
+		if strings.HasSuffix(name, "/") {
+			return true
+		}
+
+		ext := strings.TrimPrefix(strings.ToLower(path.Ext(name)), ".")
+		ok = EnumIgnoreCase(ext, exts)
+		return ok
+	})
+	return err == nil && ok
+}
+
+// parse the size string with unit to bytes. eg: "1024", "10K", "2MB", "1G"
+func parseByteSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimSuffix(s, "B")
+
+	var unit int64 = 1
+	if ln := len(s); ln > 0 {
+		switch s[ln-1] {
+		case 'K':
+			unit = 1 << 10
+		case 'M':
+			unit = 1 << 20
+		case 'G':
+			unit = 1 << 30
+		}
+
+		if unit > 1 {
+			s = s[:ln-1]
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * unit, nil
+}
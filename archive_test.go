@@ -0,0 +1,113 @@
+package validate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeZip(files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, body := range files {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(body))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func makeTarGz(files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, body := range files {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body))})
+		_, _ = tw.Write([]byte(body))
+	}
+	_ = tw.Close()
+	_ = gw.Close()
+	return buf.Bytes()
+}
+
+func archiveFormData(t *testing.T, files map[string][]byte) *FormData {
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
+	for field, body := range files {
+		w, err := mw.CreateFormFile(field, field)
+		assert.NoError(t, err)
+		_, _ = w.Write(body)
+	}
+	_ = mw.Close()
+
+	r, _ := http.NewRequest("POST", "/upload", buf)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	d, err := FromRequest(r)
+	assert.NoError(t, err)
+	return d.(*FormData)
+}
+
+func TestArchiveValidators(t *testing.T) {
+	is := assert.New(t)
+
+	files := map[string]string{
+		"a.jpg":     "a",
+		"dir/b.png": strings.Repeat("b", 2048),
+		"c.PNG":     "c",
+	}
+	fd := archiveFormData(t, map[string][]byte{
+		"zip":  makeZip(files),
+		"tgz":  makeTarGz(files),
+		"text": []byte("not an archive"),
+	})
+
+	v := fd.Create()
+	for _, field := range []string{"zip", "tgz"} {
+		is.True(v.ArchiveMaxEntries(fd, field, 3))
+		is.False(v.ArchiveMaxEntries(fd, field, 2))
+		is.True(v.ArchiveMaxSize(fd, field, 2050))
+		is.False(v.ArchiveMaxSize(fd, field, 2049))
+		is.True(v.ArchiveExts(fd, field, "jpg", "png"))
+		is.False(v.ArchiveExts(fd, field, "png"))
+	}
+
+	is.False(v.ArchiveMaxEntries(fd, "text", 3))
+	is.False(v.ArchiveMaxSize(fd, "not-exist", 3))
+	is.False(v.ArchiveExts(fd, "not-exist", "jpg"))
+
+	v.StopOnError = false
+	v.StringRules(MS{
+		"zip": "required|archiveMaxEntries:10|archiveMaxSize:1K",
+		"tgz": "required|archive_exts:jpg,png|archiveMaxSize:2KB",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("zip archive uncompressed size cannot exceed 1K", v.Errors.FieldOne("zip"))
+	is.Equal("tgz archive uncompressed size cannot exceed 2KB", v.Errors.FieldOne("tgz"))
+
+	v = fd.Create()
+	v.StringRule("zip", "archiveMaxSize:abc")
+	is.Panics(func() {
+		v.Validate()
+	})
+}
+
+func TestParseByteSize(t *testing.T) {
+	is := assert.New(t)
+
+	for s, want := range map[string]int64{"1024": 1024, "2K": 2048, "2kb": 2048, "1M": 1 << 20, "1GB": 1 << 30} {
+		size, err := parseByteSize(s)
+		is.NoError(err)
+		is.Equal(want, size)
+	}
+
+	_, err := parseByteSize("1T")
+	is.Error(err)
+}
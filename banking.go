@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"strings"
+)
+
+// the IBAN length of the countries, by the SWIFT IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// the BIC format: 4 letters bank code, 2 letters country code, 2 chars location code, optional 3 chars branch code
+var rxBIC = mustCompilePattern(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}(?:[A-Z0-9]{3})?$`)
+
+// remove the spaces and convert to upper case. eg: "de89 3704 ..." => "DE893704..."
+func normalizeBankCode(s string) string {
+	return strings.ToUpper(strings.Replace(s, " ", "", -1))
+}
+
+// check the country code is in the countries, the empty countries match all.
+func inCountries(country string, countries []string) bool {
+	if len(countries) == 0 {
+		return true
+	}
+
+	for _, c := range countries {
+		if strings.EqualFold(strings.TrimSpace(c), country) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIBAN check the value is the International Bank Account Number: the country length and the mod-97 checksum.
+// the spaces are ignored and the letters are case-insensitive. the countries is optional to restrict the countries.
+// Usage:
+// 	v.StringRule("iban", "required|iban:DE,AT,CH")
+func IsIBAN(s string, countries ...string) bool {
+	iban := normalizeBankCode(s)
+	if len(iban) < 4 {
+		return false
+	}
+
+	country := iban[:2]
+	if n, ok := ibanLengths[country]; !ok || n != len(iban) || !inCountries(country, countries) {
+		return false
+	}
+
+	// move the first 4 chars to the end, convert the letters to the numbers(A=10 ... Z=35) and mod 97
+	var mod int
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			mod = (mod*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			mod = (mod*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return mod == 1
+}
+
+// IsBIC check the value is the Business Identifier Code(SWIFT code). eg: "DEUTDEFF", "DEUTDEFF500"
+// the countries is optional to restrict the countries.
+// Usage:
+// 	v.StringRule("bic", "required|bic:DE,AT,CH")
+func IsBIC(s string, countries ...string) bool {
+	bic := normalizeBankCode(s)
+	if !rxBIC.MatchString(bic) {
+		return false
+	}
+	return inCountries(bic[4:6], countries)
+}
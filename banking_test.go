@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIBAN(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsIBAN("DE89370400440532013000"))
+	is.True(IsIBAN("de89 3704 0044 0532 0130 00"))
+	is.True(IsIBAN("GB82WEST12345698765432"))
+	is.True(IsIBAN("CH9300762011623852957"))
+	is.True(IsIBAN("NO9386011117947"))
+	is.True(IsIBAN("AT611904300234573201", "DE", "AT"))
+	// the checksum is invalid
+	is.False(IsIBAN("DE89370400440532013001"))
+	// the length is invalid
+	is.False(IsIBAN("DE8937040044053201300"))
+	// the country is not supported or not allowed
+	is.False(IsIBAN("US89370400440532013000"))
+	is.False(IsIBAN("GB82WEST12345698765432", "DE", "AT", "CH"))
+	is.False(IsIBAN("DE89-3704-0044-0532-0130-00"))
+	is.False(IsIBAN("DE"))
+
+	v := Map(M{"iban": "DE89 3704 0044 0532 0130 00", "iban2": "FR1420041010050500013M02606"})
+	v.StopOnError = false
+	v.StringRules(MS{"iban": "required|iban:DE,AT,CH", "iban2": "IBAN:DE,AT"})
+	is.False(v.Validate())
+	is.Empty(v.Errors.Field("iban"))
+	is.Equal("iban2 value must be a valid IBAN", v.Errors.FieldOne("iban2"))
+	is.True(IsIBAN("FR1420041010050500013M02606"))
+}
+
+func TestIsBIC(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsBIC("DEUTDEFF"))
+	is.True(IsBIC("DEUTDEFF500"))
+	is.True(IsBIC("deutdeff"))
+	is.True(IsBIC("NEDSZAJJXXX", "ZA"))
+	is.False(IsBIC("DEUTDEFF", "AT", "CH"))
+	is.False(IsBIC("DEUTDEF"))
+	is.False(IsBIC("DEUTDEFF50"))
+	is.False(IsBIC("DEU1DEFF"))
+	is.False(IsBIC("DEUT1EFF"))
+
+	v := Map(M{"bic": "DEUTDEFF500", "swift": "BNPAFRPP"})
+	v.StopOnError = false
+	v.StringRules(MS{"bic": "required|bic:DE", "swift": "swift:DE"})
+	is.False(v.Validate())
+	is.Empty(v.Errors.Field("bic"))
+	is.Equal("swift value must be a valid BIC(SWIFT code)", v.Errors.FieldOne("swift"))
+}
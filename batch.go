@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BatchProgress the progress of validate the slice data. see Validation.OnProgress()
+type BatchProgress struct {
+	// Total the number of the records
+	Total int
+	// Processed the number of the records all rules are applied
+	Processed int
+	// Failed the number of the processed records has errors
+	Failed int
+}
+
+// OnProgress set the progress callback for validate the slice data(the batch mode), it is called after each record is processed.
+// the validation can be aborted by the context, see WithContext()
+// Usage:
+// 	v := validate.Slice(records, rules)
+// 	v.StopOnError = false
+// 	v.WithContext(jobCtx).OnProgress(func(p validate.BatchProgress) {
+// 		job.Report(p.Processed, p.Failed, p.Total)
+// 	})
+func (v *Validation) OnProgress(fn func(p BatchProgress)) *Validation {
+	v.progressFn = fn
+	return v
+}
+
+// track the records progress on apply the rules of the slice data
+type batchTracker struct {
+	progress BatchProgress
+	// the records of the rule index are processed after the rule is applied
+	doneAt map[int][]int
+	// the indexes of the records has errors, it is updated on add the error
+	failed map[int]bool
+}
+
+func (v *Validation) newBatchTracker() *batchTracker {
+	sd, ok := v.data.(*SliceData)
+	if !ok || v.progressFn == nil && v.ctx == nil {
+		return nil
+	}
+
+	// the record is processed after the last rule of it
+	lastRule := make(map[int]int, sd.Len())
+	for i, r := range v.rules {
+		for _, field := range r.fields {
+			// the wildcard field apply to all records. eg: "*.name"
+			if strings.HasPrefix(field, "*.") {
+				for idx := 0; idx < sd.Len(); idx++ {
+					lastRule[idx] = i
+				}
+			} else if idx, ok := recordIndex(field); ok {
+				lastRule[idx] = i
+			}
+		}
+	}
+
+	t := &batchTracker{doneAt: make(map[int][]int, len(lastRule)), failed: make(map[int]bool)}
+	t.progress.Total = sd.Len()
+	for idx, i := range lastRule {
+		t.doneAt[i] = append(t.doneAt[i], idx)
+	}
+
+	// the errors added before apply the rules. eg: the unknown fields on strict mode
+	for field := range v.Errors {
+		t.addFailed(field)
+	}
+
+	// the records without rules
+	t.progress.Processed = t.progress.Total - len(lastRule)
+	return t
+}
+
+// get the record index of the field. eg: "2.name" => 2
+func recordIndex(field string) (int, bool) {
+	if pos := strings.IndexByte(field, '.'); pos > 0 {
+		field = field[:pos]
+	}
+
+	idx, err := strconv.Atoi(field)
+	return idx, err == nil
+}
+
+// check the context is canceled, and add the error on it is canceled.
+func (t *batchTracker) canceled(v *Validation) bool {
+	if t == nil || v.ctx == nil {
+		return false
+	}
+
+	if err := v.ctx.Err(); err != nil {
+		v.AddError(validateError, validateError, "validation is canceled: "+err.Error())
+		return true
+	}
+	return false
+}
+
+// update the progress after the rule is applied
+func (t *batchTracker) ruleDone(v *Validation, ruleIdx int) {
+	if t == nil || len(t.doneAt[ruleIdx]) == 0 {
+		return
+	}
+
+	for _, idx := range t.doneAt[ruleIdx] {
+		t.progress.Processed++
+		if t.failed[idx] {
+			t.progress.Failed++
+		}
+	}
+
+	if v.progressFn != nil {
+		v.progressFn(t.progress)
+	}
+}
+
+// record the index of the failed record by the error field. eg: "2.name" => 2
+func (t *batchTracker) addFailed(field string) {
+	if t == nil {
+		return
+	}
+
+	if idx, ok := recordIndex(field); ok {
+		t.failed[idx] = true
+	}
+}
@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_OnProgress(t *testing.T) {
+	is := assert.New(t)
+
+	records := []M{
+		{"name": "inhere", "age": 20},
+		{"name": "in", "age": 20},
+		{"name": "tom", "age": 0},
+		{"name": "lee", "age": 30},
+	}
+	rules := MS{
+		"name": "required|minLen:3",
+		"age":  "required|min:1",
+	}
+
+	var reports []BatchProgress
+	v := Slice(records, rules)
+	v.StopOnError = false
+	v.OnProgress(func(p BatchProgress) {
+		reports = append(reports, p)
+	})
+	is.False(v.Validate())
+	is.Equal([]BatchProgress{
+		{Total: 4, Processed: 1, Failed: 0},
+		{Total: 4, Processed: 2, Failed: 1},
+		{Total: 4, Processed: 3, Failed: 2},
+		{Total: 4, Processed: 4, Failed: 2},
+	}, reports)
+
+	// the struct slice
+	type user struct {
+		Name string `validate:"required|minLen:3"`
+	}
+	reports = reports[:0]
+	v = Struct([]user{{"in"}, {"inhere"}})
+	v.StopOnError = false
+	v.OnProgress(func(p BatchProgress) {
+		reports = append(reports, p)
+	})
+	is.False(v.Validate())
+	is.Equal(BatchProgress{Total: 2, Processed: 2, Failed: 1}, reports[len(reports)-1])
+
+	// abort by the context
+	ctx, cancel := context.WithCancel(context.Background())
+	reports = reports[:0]
+	v = Slice(records, rules)
+	v.StopOnError = false
+	v.WithContext(ctx).OnProgress(func(p BatchProgress) {
+		reports = append(reports, p)
+		if p.Processed == 2 {
+			cancel()
+		}
+	})
+	is.False(v.Validate())
+	is.Len(reports, 2)
+	is.Equal("validation is canceled: context canceled", v.Errors.FieldOne(validateError))
+	is.NotContains(v.Errors, "2.age")
+
+	// not the slice data
+	v = Map(M{"name": "inhere"})
+	v.OnProgress(func(p BatchProgress) {
+		reports = append(reports, p)
+	})
+	v.StringRule("name", "required")
+	is.True(v.Validate())
+	is.Len(reports, 2)
+
+	// the wildcard rules
+	reports = reports[:0]
+	v = Slice(records, nil)
+	v.StopOnError = false
+	v.StringRule("*.name", "required|minLen:3")
+	v.OnProgress(func(p BatchProgress) {
+		reports = append(reports, p)
+	})
+	is.False(v.Validate())
+	is.Equal([]BatchProgress{{Total: 4, Processed: 4, Failed: 1}}, reports)
+	is.Equal("1.name min length is 3", v.Errors.One())
+}
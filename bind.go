@@ -0,0 +1,358 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gookit/goutil/strutil"
+)
+
+// the struct tag name for custom the time layout on binding. eg: `layout:"2006-01-02"`
+const layoutTag = "layout"
+
+var errBindPtr = errors.New("bind: the target must be an non-nil pointer")
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// bind the data to the struct pointer.
+// the dot path keys(eg: "addr.city") will be bind to the nested struct.
+//
+// on the Unmarshal is custom or the target is a json.Unmarshaler, will bind by the Marshal and Unmarshal.
+func bindData(data map[string]interface{}, ptr interface{}) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errBindPtr
+	}
+
+	if isCustomUnmarshal() || rv.Type().Implements(unmarshalerType) {
+		bs, err := Marshal(data)
+		if err != nil {
+			return err
+		}
+		return Unmarshal(bs, ptr)
+	}
+
+	return bindValue(rv.Elem(), nestedMap(data), "", "")
+}
+
+// bind the value by the UnmarshalJSON method of the field. eg: the custom enum type
+func bindUnmarshaler(u json.Unmarshaler, src interface{}, path string, dt reflect.Type) error {
+	bs, err := Marshal(src)
+	if err == nil {
+		err = u.UnmarshalJSON(bs)
+	}
+
+	if err != nil {
+		return fmt.Errorf("bind: cannot convert %T to %s for the field '%s': %s", src, dt.String(), path, err.Error())
+	}
+	return nil
+}
+
+// convert the dot path keys to the nested map.
+// eg: {"addr.city": "chengdu"} => {"addr": {"city": "chengdu"}}
+func nestedMap(data map[string]interface{}) map[string]interface{} {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	// the short path first, allow the sub path override the value.
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Count(keys[i], ".") < strings.Count(keys[j], ".")
+	})
+
+	mp := make(map[string]interface{}, len(data))
+	for _, key := range keys {
+		nodes := strings.Split(key, ".")
+		last := len(nodes) - 1
+		parent := mp
+		for i, node := range nodes {
+			if i == last {
+				parent[node] = data[key]
+				break
+			}
+
+			sub, ok := parent[node].(map[string]interface{})
+			if !ok {
+				// the value is not a map, cannot set the sub path.
+				if _, has := parent[node]; has {
+					break
+				}
+
+				sub = make(map[string]interface{})
+				parent[node] = sub
+			}
+			parent = sub
+		}
+	}
+	return mp
+}
+
+func bindValue(dst reflect.Value, src interface{}, path, layout string) error {
+	if src == nil {
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	dt := dst.Type()
+	if sv.Type().AssignableTo(dt) && dst.Kind() != reflect.Map && dst.Kind() != reflect.Slice {
+		dst.Set(sv)
+		return nil
+	}
+
+	// the time.Time allow more formats, see bindTime()
+	if dt != timeType && dst.CanAddr() {
+		if u, ok := dst.Addr().Interface().(json.Unmarshaler); ok {
+			return bindUnmarshaler(u, src, path, dt)
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dt.Elem()))
+		}
+		return bindValue(dst.Elem(), src, path, layout)
+	case reflect.Interface:
+		if sv.Type().Implements(dt) {
+			dst.Set(sv)
+			return nil
+		}
+	case reflect.Struct:
+		if dt == timeType {
+			return bindTime(dst, src, path, layout)
+		}
+
+		if mp, ok := src.(map[string]interface{}); ok {
+			return bindStruct(dst, mp, path)
+		}
+	case reflect.Map:
+		if sv.Kind() != reflect.Map {
+			break
+		}
+
+		mv := reflect.MakeMapWithSize(dt, sv.Len())
+		for _, key := range sv.MapKeys() {
+			kv := reflect.New(dt.Key()).Elem()
+			if err := bindValue(kv, key.Interface(), path, ""); err != nil {
+				return err
+			}
+
+			ev := reflect.New(dt.Elem()).Elem()
+			subPath := joinPath(path, fmt.Sprint(key.Interface()))
+			if err := bindValue(ev, sv.MapIndex(key).Interface(), subPath, layout); err != nil {
+				return err
+			}
+			mv.SetMapIndex(kv, ev)
+		}
+		dst.Set(mv)
+		return nil
+	case reflect.Slice, reflect.Array:
+		return bindList(dst, sv, path, layout)
+	case reflect.String:
+		if s, err := strutil.ToString(src); err == nil {
+			dst.SetString(s)
+			return nil
+		}
+	case reflect.Bool:
+		if sv.Kind() == reflect.String {
+			b, err := strutil.ToBool(sv.String())
+			if err != nil {
+				return bindError(path, src, dt)
+			}
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i64, err := bindToInt64(sv)
+		if err != nil || dst.OverflowInt(i64) {
+			return bindError(path, src, dt)
+		}
+		dst.SetInt(i64)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i64, err := bindToInt64(sv)
+		if err != nil || i64 < 0 || dst.OverflowUint(uint64(i64)) {
+			return bindError(path, src, dt)
+		}
+		dst.SetUint(uint64(i64))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f64, err := bindToFloat64(sv)
+		if err != nil || dst.OverflowFloat(f64) {
+			return bindError(path, src, dt)
+		}
+		dst.SetFloat(f64)
+		return nil
+	}
+
+	if sv.Type().ConvertibleTo(dt) && sv.Kind() == dst.Kind() {
+		dst.Set(sv.Convert(dt))
+		return nil
+	}
+	return bindError(path, src, dt)
+}
+
+func bindStruct(dst reflect.Value, mp map[string]interface{}, path string) error {
+	dt := dst.Type()
+	for i := 0; i < dt.NumField(); i++ {
+		sf := dt.Field(i)
+		// skip the unexported field
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+
+		name := sf.Name
+		if tag := sf.Tag.Get("json"); tag != "" {
+			if tag = strings.Split(tag, ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+		} else if sf.Anonymous {
+			// the embedded struct, bind with the same data.
+			fv := dst.Field(i)
+			if fv.Kind() == reflect.Struct {
+				if err := bindStruct(fv, mp, path); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		val, ok := mp[name]
+		if !ok { // like the encoding/json, allow case-insensitive match
+			for key, item := range mp {
+				if strings.EqualFold(key, name) {
+					val, ok = item, true
+					break
+				}
+			}
+		}
+
+		if ok {
+			if err := bindValue(dst.Field(i), val, joinPath(path, name), sf.Tag.Get(layoutTag)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func bindList(dst reflect.Value, sv reflect.Value, path, layout string) error {
+	dt := dst.Type()
+	// string to []byte
+	if sv.Kind() == reflect.String && dt.Elem().Kind() == reflect.Uint8 && dst.Kind() == reflect.Slice {
+		dst.SetBytes([]byte(sv.String()))
+		return nil
+	}
+
+	// the map with the continuous index keys. eg: {"0": "a", "1": "b"}
+	if sv.Kind() == reflect.Map {
+		list := make([]interface{}, sv.Len())
+		for _, key := range sv.MapKeys() {
+			skey := fmt.Sprint(key.Interface())
+			idx, err := strconv.Atoi(skey)
+			if err != nil || idx < 0 || idx >= len(list) || strconv.Itoa(idx) != skey {
+				return bindError(path, sv.Interface(), dt)
+			}
+			list[idx] = sv.MapIndex(key).Interface()
+		}
+		sv = reflect.ValueOf(list)
+	}
+
+	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+		return bindError(path, sv.Interface(), dt)
+	}
+
+	ln := sv.Len()
+	if dst.Kind() == reflect.Slice {
+		dst.Set(reflect.MakeSlice(dt, ln, ln))
+	} else if ln > dst.Len() {
+		ln = dst.Len()
+	}
+
+	for i := 0; i < ln; i++ {
+		if err := bindValue(dst.Index(i), sv.Index(i).Interface(), joinPath(path, strconv.Itoa(i)), layout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindTime(dst reflect.Value, src interface{}, path, layout string) (err error) {
+	var t time.Time
+	switch tv := src.(type) {
+	case *time.Time:
+		t = *tv
+	case string:
+		if layout != "" {
+			t, err = time.Parse(layout, tv)
+		} else {
+			t, err = strutil.ToTime(tv)
+		}
+	default: // unix timestamp
+		var i64 int64
+		if i64, err = bindToInt64(reflect.ValueOf(src)); err == nil {
+			t = time.Unix(i64, 0)
+		}
+	}
+
+	if err != nil {
+		return bindError(path, src, dst.Type())
+	}
+
+	dst.Set(reflect.ValueOf(t))
+	return nil
+}
+
+func bindToInt64(sv reflect.Value) (int64, error) {
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u64 := sv.Uint(); u64 <= math.MaxInt64 {
+			return int64(u64), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		// dont allow lose the decimal part
+		if f64 := sv.Float(); f64 == math.Trunc(f64) {
+			return int64(f64), nil
+		}
+	case reflect.String:
+		return strconv.ParseInt(strings.TrimSpace(sv.String()), 10, 64)
+	}
+	return 0, errConvertFail
+}
+
+func bindToFloat64(sv reflect.Value) (float64, error) {
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(sv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(sv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return sv.Float(), nil
+	case reflect.String:
+		return strconv.ParseFloat(strings.TrimSpace(sv.String()), 64)
+	}
+	return 0, errConvertFail
+}
+
+func bindError(path string, src interface{}, dt reflect.Type) error {
+	return fmt.Errorf("bind: cannot convert %T to %s for the field '%s'", src, dt.String(), path)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
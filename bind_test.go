@@ -0,0 +1,247 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindAddr struct {
+	City string `json:"city"`
+	Zip  *int   `json:"zip"`
+}
+
+type bindBase struct {
+	ID int64 `json:"id"`
+}
+
+type bindUserDTO struct {
+	bindBase
+	Name     string            `json:"name"`
+	Age      uint8             `json:"age"`
+	Score    float64           `json:"score"`
+	Active   bool              `json:"active"`
+	Nickname *string           `json:"nickname"`
+	Birthday time.Time         `json:"birthday" layout:"2006-01-02"`
+	JoinedAt *time.Time        `json:"joined_at"`
+	Tags     []string          `json:"tags"`
+	Addr     bindAddr          `json:"addr"`
+	Extra    map[string]int    `json:"extra"`
+	Ignore   string            `json:"-"`
+	Meta     interface{}       `json:"meta"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Remark   string
+}
+
+func TestValidation_BindSafeData(t *testing.T) {
+	is := assert.New(t)
+
+	v := FromURLValues(url.Values{
+		"id":       {"12"},
+		"name":     {" inhere "},
+		"age":      {"20"},
+		"score":    {"9.5"},
+		"active":   {"on"},
+		"nickname": {"tom"},
+		"birthday": {"2000-01-02"},
+		"ignore":   {"val"},
+		"remark":   {"some"},
+	}).Create()
+	v.FilterRule("name", "trim|upper")
+	v.StringRules(MS{
+		"id":       "required",
+		"name":     "required",
+		"age":      "required",
+		"score":    "required",
+		"active":   "required",
+		"nickname": "required",
+		"birthday": "required|date",
+		"ignore":   "required",
+		"remark":   "required",
+	})
+	is.True(v.Validate(), v.Errors.String())
+
+	dto := &bindUserDTO{}
+	err := v.BindSafeData(dto)
+	is.NoError(err)
+	is.Equal(int64(12), dto.ID)
+	is.Equal("INHERE", dto.Name)
+	is.Equal(uint8(20), dto.Age)
+	is.Equal(9.5, dto.Score)
+	is.True(dto.Active)
+	is.Equal("tom", *dto.Nickname)
+	is.Equal("2000-01-02", dto.Birthday.Format("2006-01-02"))
+	is.Equal("", dto.Ignore)
+	is.Equal("some", dto.Remark)
+
+	// JSON data with nested fields
+	d, err := FromJSON(`{
+	"name": "inhere",
+	"joined_at": "2019-06-01 10:20:30",
+	"tags": ["go", "php"],
+	"addr": {"city": "chengdu", "zip": 610000},
+	"extra": {"a": 1, "b": 2.0},
+	"meta": {"k": "v"},
+	"labels": {"env": "prod"}
+}`)
+	is.NoError(err)
+	v = d.Create()
+	v.StringRules(MS{
+		"name":      "required",
+		"joined_at": "required",
+		"tags":      "required",
+		"addr.city": "required",
+		"addr.zip":  "required",
+		"extra":     "required",
+		"meta":      "required",
+		"labels":    "required",
+	})
+	v.FilterRule("addr.city", "upper")
+	is.True(v.Validate(), v.Errors.String())
+
+	dto = &bindUserDTO{}
+	err = v.BindSafeData(dto)
+	is.NoError(err)
+	is.Equal("2019-06-01 10:20:30", dto.JoinedAt.Format("2006-01-02 15:04:05"))
+	is.Equal([]string{"go", "php"}, dto.Tags)
+	is.Equal("CHENGDU", dto.Addr.City)
+	is.Equal(610000, *dto.Addr.Zip)
+	is.Equal(map[string]int{"a": 1, "b": 2}, dto.Extra)
+	is.Equal(map[string]interface{}{"k": "v"}, dto.Meta)
+	is.Equal(map[string]string{"env": "prod"}, dto.Labels)
+
+	// convert error
+	v = Map(M{"age": "abc"})
+	v.StringRule("age", "required")
+	is.True(v.Validate())
+	err = v.BindSafeData(dto)
+	is.Error(err)
+	is.Contains(err.Error(), "'age'")
+
+	v = Map(M{"age": 1.5})
+	v.StringRule("age", "required")
+	is.True(v.Validate())
+	is.Error(v.BindSafeData(dto))
+
+	// the sparse index keys
+	v = Map(M{"tags": M{"1099511627776": "x"}})
+	v.StringRule("tags", "required")
+	is.True(v.Validate())
+	err = v.BindSafeData(dto)
+	is.Error(err)
+	is.Contains(err.Error(), "'tags'")
+
+	// invalid target
+	is.Equal(errBindPtr, v.BindSafeData(*dto))
+}
+
+type bindLevel int
+
+func (l *bindLevel) UnmarshalJSON(bs []byte) error {
+	switch string(bs) {
+	case `"low"`:
+		*l = 1
+	case `"high"`:
+		*l = 2
+	default:
+		return errors.New("invalid level")
+	}
+	return nil
+}
+
+type bindLevelDTO struct {
+	Name  string    `json:"name"`
+	Level bindLevel `json:"level"`
+}
+
+type bindRawDTO struct {
+	raw string
+}
+
+func (d *bindRawDTO) UnmarshalJSON(bs []byte) error {
+	d.raw = string(bs)
+	return nil
+}
+
+func TestValidation_BindSafeData_unmarshal(t *testing.T) {
+	is := assert.New(t)
+
+	newV := func(level string) *Validation {
+		v := Map(M{"name": "inhere", "level": level})
+		v.StringRules(MS{"name": "required", "level": "in:low,high,mid"})
+		is.True(v.Validate())
+		return v
+	}
+
+	// the field is json.Unmarshaler
+	dto := &bindLevelDTO{}
+	is.NoError(newV("high").BindSafeData(dto))
+	is.Equal(bindLevel(2), dto.Level)
+
+	err := newV("mid").BindSafeData(&bindLevelDTO{})
+	is.Error(err)
+	is.Contains(err.Error(), "'level': invalid level")
+
+	// the target is json.Unmarshaler
+	raw := &bindRawDTO{}
+	is.NoError(newV("low").BindSafeData(raw))
+	is.Equal(`{"level":"low","name":"inhere"}`, raw.raw)
+
+	// the custom Unmarshal func
+	var called bool
+	Unmarshal = func(data []byte, v interface{}) error {
+		called = true
+		return json.Unmarshal(data, v)
+	}
+	defer func() {
+		Unmarshal = json.Unmarshal
+	}()
+
+	dto = &bindLevelDTO{}
+	is.NoError(newV("low").BindSafeData(dto))
+	is.True(called)
+	is.Equal(bindLevel(1), dto.Level)
+}
+
+func TestNestedMap(t *testing.T) {
+	is := assert.New(t)
+
+	mp := nestedMap(map[string]interface{}{
+		"name":          "inhere",
+		"addr.city":     "chengdu",
+		"addr":          map[string]interface{}{"zip": 610000},
+		"items.0.sku":   "abc",
+		"name.sub":      "ignored",
+		"items.1.price": 12,
+	})
+
+	is.Equal(map[string]interface{}{
+		"name": "inhere",
+		"addr": map[string]interface{}{"city": "chengdu", "zip": 610000},
+		"items": map[string]interface{}{
+			"0": map[string]interface{}{"sku": "abc"},
+			"1": map[string]interface{}{"price": 12},
+		},
+	}, mp)
+
+	// the map with index keys to slice
+	type item struct {
+		Sku   string
+		Price int
+	}
+	s := &struct{ Items []item }{}
+	is.NoError(bindData(map[string]interface{}{"items.0.sku": "abc", "items.1.price": "12"}, s))
+	is.Equal([]item{{Sku: "abc"}, {Price: 12}}, s.Items)
+
+	// only the continuous index keys
+	t2 := &struct{ Tags []string }{}
+	is.Error(bindData(map[string]interface{}{"tags": map[string]interface{}{"1099511627776": "x"}}, t2))
+	is.Error(bindData(map[string]interface{}{"tags": map[string]interface{}{"0": "a", "2": "c"}}, t2))
+	is.Error(bindData(map[string]interface{}{"tags": map[string]interface{}{"00": "a"}}, t2))
+	is.NoError(bindData(map[string]interface{}{"tags": map[string]interface{}{"1": "b", "0": "a"}}, t2))
+	is.Equal([]string{"a", "b"}, t2.Tags)
+}
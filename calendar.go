@@ -0,0 +1,176 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gookit/goutil/strutil"
+)
+
+// DateRange definition. the Start and End are inclusive.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains check the time is in the range
+func (r DateRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && !t.After(r.End)
+}
+
+// registry of the named date ranges. use for the validator "notInDateRanges"
+var (
+	calendarMux sync.RWMutex
+	calendars   = make(map[string][]DateRange)
+)
+
+// AddCalendar add date ranges to the named calendar. eg: holidays, blackout dates
+// Usage:
+// 	validate.AddCalendar("holidays", validate.DateRange{
+// 		Start: time.Date(2019, 12, 24, 0, 0, 0, 0, time.UTC),
+// 		End:   time.Date(2019, 12, 26, 23, 59, 59, 0, time.UTC),
+// 	})
+func AddCalendar(name string, ranges ...DateRange) {
+	calendarMux.Lock()
+	calendars[name] = append(calendars[name], ranges...)
+	calendarMux.Unlock()
+}
+
+// Calendar get the date ranges of the named calendar
+func Calendar(name string) ([]DateRange, bool) {
+	calendarMux.RLock()
+	defer calendarMux.RUnlock()
+
+	ranges, ok := calendars[name]
+	return ranges, ok
+}
+
+// RemoveCalendar remove the named calendar
+func RemoveCalendar(name string) {
+	calendarMux.Lock()
+	delete(calendars, name)
+	calendarMux.Unlock()
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parse the weekdays string. eg: "Mon-Fri", "Sat", "Mon-Wed+Fri"
+func parseWeekdays(s string) (days [7]bool, ok bool) {
+	for _, part := range strings.Split(s, "+") {
+		nodes := strings.SplitN(part, "-", 2)
+		start, ok1 := weekdayNames[strings.ToLower(strings.TrimSpace(nodes[0]))]
+		if !ok1 {
+			return days, false
+		}
+
+		end := start
+		if len(nodes) == 2 {
+			if end, ok1 = weekdayNames[strings.ToLower(strings.TrimSpace(nodes[1]))]; !ok1 {
+				return days, false
+			}
+		}
+
+		// allow wrap the week. eg: "Sat-Mon"
+		for d := start; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == end {
+				break
+			}
+		}
+	}
+	return days, true
+}
+
+// parse the hours range string to minutes of the day. eg: "09:00-17:00"
+func parseHourRange(s string) (start, end int, ok bool) {
+	nodes := strings.SplitN(s, "-", 2)
+	if len(nodes) != 2 {
+		return
+	}
+
+	st, err := time.Parse("15:04", strings.TrimSpace(nodes[0]))
+	if err != nil {
+		return
+	}
+
+	et, err := time.Parse("15:04", strings.TrimSpace(nodes[1]))
+	if err != nil {
+		return
+	}
+
+	return st.Hour()*60 + st.Minute(), et.Hour()*60 + et.Minute(), true
+}
+
+// convert the value to time.Time. allow: time.Time, date string, unix timestamp.
+// the date string without zone will be parsed in the loc.
+func valueToTime(val interface{}, loc *time.Location) (t time.Time, ok bool) {
+	switch tv := val.(type) {
+	case time.Time:
+		return tv, true
+	case *time.Time:
+		if tv != nil {
+			return *tv, true
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339, tv); err == nil {
+			return t, true
+		}
+
+		t, err := strutil.ToTime(tv)
+		if err != nil {
+			return t, false
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), true
+	default:
+		if i64, err := valueToInt64(val, false); err == nil {
+			return time.Unix(i64, 0), true
+		}
+	}
+	return
+}
+
+// the filter "toTime", convert the date string to time.Time by the layouts.
+// if no layouts, use the GlobalOption.TimeLayouts or auto detect the common date formats.
+// the integer value is as the unix timestamp.
+// Usage:
+// 	v.FilterRule("birthday", "toTime:2006-01-02")
+func filterToTime(val interface{}, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = globalOpt.TimeLayouts
+	}
+
+	str, ok := val.(string)
+	if !ok || len(layouts) == 0 {
+		if t, ok := valueToTime(val, time.UTC); ok {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("cannot convert %v to time.Time", val)
+	}
+
+	str = strings.TrimSpace(str)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("the time %q does not match the layouts %v", str, layouts)
+}
+
+// the filter "toDate", like the filter "toTime", but the clock is removed.
+func filterToDate(val interface{}, layouts ...string) (time.Time, error) {
+	t, err := filterToTime(val, layouts...)
+	if err != nil {
+		return t, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+}
@@ -0,0 +1,134 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithinBusinessHours(t *testing.T) {
+	is := assert.New(t)
+
+	// 2019-06-03 is Monday
+	is.True(WithinBusinessHours("2019-06-03 09:00", "Mon-Fri", "09:00-17:00"))
+	is.True(WithinBusinessHours("2019-06-07 16:59", "Mon-Fri", "09:00-17:00"))
+	is.False(WithinBusinessHours("2019-06-03 17:00", "Mon-Fri", "09:00-17:00"))
+	is.False(WithinBusinessHours("2019-06-08 10:00", "Mon-Fri", "09:00-17:00"))
+	is.True(WithinBusinessHours("2019-06-08 10:00", "Mon-Wed+Sat", "09:00-17:00"))
+	is.True(WithinBusinessHours("2019-06-09 10:00", "Sat-Mon", "09:00-17:00"))
+
+	// with location
+	is.True(WithinBusinessHours("2019-06-03 16:30", "Mon-Fri", "09:00-17:00", "Europe/Berlin"))
+	// 15:30 UTC is 17:30 in Berlin(CEST)
+	is.False(WithinBusinessHours("2019-06-03T15:30:00Z", "Mon-Fri", "09:00-17:00", "Europe/Berlin"))
+	is.True(WithinBusinessHours(time.Date(2019, 6, 3, 14, 0, 0, 0, time.UTC), "Mon", "09:00-17:00", "Europe/Berlin"))
+	is.True(WithinBusinessHours(int64(1559563200), "Mon", "09:00-17:00")) // 2019-06-03 12:00 UTC
+
+	// overnight
+	is.True(WithinBusinessHours("2019-06-07 23:00", "Fri", "22:00-06:00"))
+	is.True(WithinBusinessHours("2019-06-08 05:00", "Fri", "22:00-06:00"))
+	is.False(WithinBusinessHours("2019-06-08 23:00", "Fri", "22:00-06:00"))
+
+	// invalid
+	is.False(WithinBusinessHours("invalid", "Mon-Fri", "09:00-17:00"))
+	is.False(WithinBusinessHours("2019-06-03 10:00", "Mon-Xyz", "09:00-17:00"))
+	is.False(WithinBusinessHours("2019-06-03 10:00", "Mon-Fri", "09:00"))
+	is.False(WithinBusinessHours("2019-06-03 10:00", "Mon-Fri", "09:00-17:00", "Invalid/Zone"))
+
+	v := Map(M{"bookAt": "2019-06-03 16:30"})
+	v.StringRule("bookAt", "required|withinBusinessHours:Mon-Fri,09:00-17:00,Europe/Berlin")
+	is.True(v.Validate())
+
+	v = Map(M{"bookAt": "2019-06-08 10:30"})
+	v.StringRule("bookAt", "within_business_hours:Mon-Fri,09:00-17:00")
+	is.False(v.Validate())
+	is.Equal("bookAt value must be within the business hours [Mon-Fri 09:00-17:00]", v.Errors.One())
+}
+
+func TestNotInDateRanges(t *testing.T) {
+	is := assert.New(t)
+	defer RemoveCalendar("holidays")
+
+	AddCalendar("holidays", DateRange{
+		Start: time.Date(2019, 12, 24, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2019, 12, 26, 23, 59, 59, 0, time.UTC),
+	})
+	AddCalendar("holidays", DateRange{
+		Start: time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2020, 1, 1, 23, 59, 59, 0, time.UTC),
+	})
+	ranges, ok := Calendar("holidays")
+	is.True(ok)
+	is.Len(ranges, 2)
+
+	is.True(NotInDateRanges("2019-12-23", "holidays"))
+	is.False(NotInDateRanges("2019-12-24", "holidays"))
+	is.False(NotInDateRanges("2019-12-26 18:00", "holidays"))
+	is.False(NotInDateRanges("2020-01-01", "holidays"))
+	is.True(NotInDateRanges("2020-01-02", "holidays"))
+	is.False(NotInDateRanges("invalid", "holidays"))
+	is.False(NotInDateRanges("2019-12-23", "not-exist"))
+
+	v := Map(M{"date": "2019-12-25"})
+	v.StringRule("date", "required|notInDateRanges:holidays")
+	is.False(v.Validate())
+	is.Equal("date value cannot be in the date ranges of holidays", v.Errors.One())
+
+	// unknown calendar: fail the rule, TryStringRule report it
+	v = Map(M{"date": "2019-12-23"})
+	v.StringRule("date", "notInDateRanges:not-exist")
+	is.False(v.Validate())
+	is.Equal("date value cannot be in the date ranges of not-exist", v.Errors.One())
+
+	err := Map(M{}).TryStringRule("date", "notInDateRanges:not-exist")
+	is.Error(err)
+	is.Contains(err.Error(), "the calendar 'not-exist' is not exists")
+
+	RemoveCalendar("holidays")
+	_, ok = Calendar("holidays")
+	is.False(ok)
+}
+
+func TestFilter_toTime(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{
+		"birthday": "1990-02-03",
+		"meetAt":   "03/04/2021 15:04",
+		"dueDate":  "2021-05-06T07:08:09Z",
+		"stamp":    1600000000,
+	})
+	v.FilterRules(MS{
+		"birthday": "toTime:2006-01-02",
+		"meetAt":   "toTime:01/02/2006 15:04,02.01.2006 15:04",
+		"dueDate":  "toDate",
+		"stamp":    "toTime",
+	})
+	v.StringRule("birthday,meetAt,dueDate,stamp", "required")
+	is.True(v.Validate(), v.Errors.One())
+
+	is.Equal(time.Date(1990, 2, 3, 0, 0, 0, 0, time.UTC), v.SafeVal("birthday"))
+	is.Equal(time.Date(2021, 3, 4, 15, 4, 0, 0, time.UTC), v.SafeVal("meetAt"))
+	is.Equal(time.Date(2021, 5, 6, 0, 0, 0, 0, time.UTC), v.SafeVal("dueDate"))
+	is.Equal(int64(1600000000), v.SafeVal("stamp").(time.Time).Unix())
+
+	v = Map(M{"birthday": "03.02.1990"})
+	v.FilterRule("birthday", "toTime:2006-01-02")
+	is.False(v.Validate())
+	is.Contains(v.Errors.One(), `the time "03.02.1990" does not match the layouts [2006-01-02]`)
+
+	// use the global layouts
+	Config(func(opt *GlobalOption) {
+		opt.TimeLayouts = []string{"02.01.2006"}
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.TimeLayouts = nil
+	})
+
+	v = Map(M{"birthday": "03.02.1990"})
+	v.FilterRule("birthday", "toDate")
+	v.StringRule("birthday", "required")
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(time.Date(1990, 2, 3, 0, 0, 0, 0, time.UTC), v.SafeVal("birthday"))
+}
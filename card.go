@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"strings"
+)
+
+// the credit card brands detected by the CardBrand()
+const (
+	CardVisa       = "visa"
+	CardMastercard = "mastercard"
+	CardAmex       = "amex"
+	CardDiscover   = "discover"
+	CardDiners     = "diners"
+	CardJCB        = "jcb"
+	CardUnionPay   = "unionpay"
+	CardMaestro    = "maestro"
+)
+
+type cardRange struct {
+	brand string
+	// the prefix range, lo and hi have the same length. eg: "2221" - "2720"
+	lo, hi string
+	// the min and max length of the card number
+	minLen, maxLen int
+}
+
+// the card number ranges, the first match is the brand.
+var cardRanges = []cardRange{
+	{CardAmex, "34", "34", 15, 15},
+	{CardAmex, "37", "37", 15, 15},
+	{CardDiners, "300", "305", 14, 19},
+	{CardDiners, "36", "36", 14, 19},
+	{CardDiners, "38", "39", 14, 19},
+	{CardJCB, "3528", "3589", 16, 19},
+	{CardVisa, "4", "4", 13, 19},
+	{CardMastercard, "51", "55", 16, 16},
+	{CardMastercard, "2221", "2720", 16, 16},
+	{CardDiscover, "6011", "6011", 16, 19},
+	{CardDiscover, "622126", "622925", 16, 19},
+	{CardDiscover, "644", "649", 16, 19},
+	{CardDiscover, "65", "65", 16, 19},
+	{CardMaestro, "5018", "5018", 12, 19},
+	{CardMaestro, "5020", "5020", 12, 19},
+	{CardMaestro, "5038", "5038", 12, 19},
+	{CardMaestro, "5893", "5893", 12, 19},
+	{CardMaestro, "6304", "6304", 12, 19},
+	{CardMaestro, "6759", "6759", 12, 19},
+	{CardMaestro, "6761", "6763", 12, 19},
+	{CardUnionPay, "62", "62", 16, 19},
+}
+
+// remove the spaces and dashes in the card number. eg: "4111 1111-1111 1111" => "4111111111111111"
+func cleanCardNumber(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// Luhn check the digits string passes the Luhn(mod 10) checksum
+func Luhn(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+
+	var sum int
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+
+		n := int(c - '0')
+		if double {
+			if n *= 2; n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// CardBrand detect the brand of the card number by the prefix and length. eg: CardVisa
+// the spaces and dashes are ignored, returns empty string on the brand is unknown.
+func CardBrand(number string) string {
+	number = cleanCardNumber(number)
+	for _, c := range number {
+		if c < '0' || c > '9' {
+			return ""
+		}
+	}
+
+	for _, r := range cardRanges {
+		if len(number) < r.minLen || len(number) > r.maxLen {
+			continue
+		}
+
+		prefix := number[:len(r.lo)]
+		if prefix >= r.lo && prefix <= r.hi {
+			return r.brand
+		}
+	}
+	return ""
+}
+
+// CreditCard check the value is a credit card number: the known brand and pass the Luhn checksum.
+// the spaces and dashes are ignored. the brands is optional to restrict the accepted brands.
+// Usage:
+// 	v.StringRule("card", "required|creditCard:visa,mastercard")
+func CreditCard(s string, brands ...string) bool {
+	number := cleanCardNumber(s)
+	if !Luhn(number) {
+		return false
+	}
+
+	brand := CardBrand(number)
+	if brand == "" {
+		return false
+	}
+
+	if len(brands) == 0 {
+		return true
+	}
+
+	for _, b := range brands {
+		if strings.EqualFold(strings.TrimSpace(b), brand) {
+			return true
+		}
+	}
+	return false
+}
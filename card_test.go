@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuhn(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(Luhn("79927398713"))
+	is.True(Luhn("4111111111111111"))
+	is.False(Luhn("79927398710"))
+	is.False(Luhn("4111-1111"))
+	is.False(Luhn("0"))
+	is.False(Luhn(""))
+}
+
+func TestCardBrand(t *testing.T) {
+	is := assert.New(t)
+
+	tests := map[string]string{
+		"4111111111111111":    CardVisa,
+		"4222222222222":       CardVisa,
+		"5555 5555 5555 4444": CardMastercard,
+		"2223003122003222":    CardMastercard,
+		"378282246310005":     CardAmex,
+		"3714-496353-98431":   CardAmex,
+		"6011111111111117":    CardDiscover,
+		"6445644564456445":    CardDiscover,
+		"30569309025904":      CardDiners,
+		"36227206271667":      CardDiners,
+		"3566002020360505":    CardJCB,
+		"6200000000000005":    CardUnionPay,
+		"6759649826438453":    CardMaestro,
+		"1234567812345678":    "",
+		"37828224631000":      "",
+		"4111a11111111111":    "",
+		"":                    "",
+	}
+	for number, want := range tests {
+		is.Equal(want, CardBrand(number), number)
+	}
+}
+
+func TestCreditCard(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(CreditCard("4111 1111 1111 1111"))
+	is.True(CreditCard("378282246310005"))
+	is.True(CreditCard("5555555555554444", "visa", "MasterCard"))
+	is.False(CreditCard("4111111111111112"))
+	is.False(CreditCard("378282246310005", "visa", "mastercard"))
+	// pass the Luhn, but the brand is unknown
+	is.False(CreditCard("79927398713"))
+
+	v := Map(M{"card": "4111-1111-1111-1111", "amex": "378282246310005"})
+	v.StopOnError = false
+	v.StringRule("card", "required|credit_card:visa,mastercard")
+	v.StringRule("amex", "creditCard:visa, mastercard")
+	is.False(v.Validate())
+	is.Empty(v.Errors.Field("card"))
+	is.Equal("amex value must be a valid credit card number", v.Errors.FieldOne("amex"))
+}
@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGB the color value of the red, green and blue channels.
+type RGB struct {
+	R, G, B uint8
+}
+
+// ParseColor parse the hex color(eg: "#fff", "1a2b3c") or rgb color(eg: "rgb(255, 0, 0)") string.
+func ParseColor(s string) (c RGB, err error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case IsHexColor(s):
+		hex := strings.TrimPrefix(s, "#")
+		if len(hex) == 3 { // "fff" => "ffffff"
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+
+		n, _ := strconv.ParseUint(hex, 16, 32)
+		return RGB{uint8(n >> 16), uint8(n >> 8), uint8(n)}, nil
+	case IsRGBColor(s):
+		ms := rxRGBColor.FindStringSubmatch(s)
+		ns := make([]uint8, 3)
+		for i := range ns {
+			n, _ := strconv.ParseUint(ms[i+1], 10, 8)
+			ns[i] = uint8(n)
+		}
+		return RGB{ns[0], ns[1], ns[2]}, nil
+	}
+	return c, fmt.Errorf("invalid color string '%s'", s)
+}
+
+// Luminance the relative luminance of the color, defined by WCAG 2. the range is 0 - 1.
+func (c RGB) Luminance() float64 {
+	channel := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// ContrastRatio calc the WCAG contrast ratio of the two colors. the range is 1 - 21.
+// Usage:
+// 	ContrastRatio("#000", "#fff") // 21
+func ContrastRatio(color1, color2 string) (float64, error) {
+	c1, err := ParseColor(color1)
+	if err != nil {
+		return 0, err
+	}
+
+	c2, err := ParseColor(color2)
+	if err != nil {
+		return 0, err
+	}
+
+	l1, l2 := c1.Luminance(), c2.Luminance()
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05), nil
+}
+
+// ContrastWithField the contrast ratio of the color value and the dst field color must be >= the min ratio.
+// the WCAG AA requires 4.5 for normal text, 3 for large text. will pass on the dst field is not exists.
+// Usage:
+// 	v.StringRule("textColor", "contrastWithField:backgroundColor,4.5")
+func (v *Validation) ContrastWithField(val interface{}, dstField string, minRatio float64) bool {
+	str, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	dstVal, has := v.Get(dstField)
+	if !has {
+		return true
+	}
+
+	dst, ok := dstVal.(string)
+	if !ok {
+		return false
+	}
+
+	ratio, err := ContrastRatio(str, dst)
+	return err == nil && ratio >= minRatio
+}
@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColor(t *testing.T) {
+	is := assert.New(t)
+
+	c, err := ParseColor("#1a2B3c")
+	is.NoError(err)
+	is.Equal(RGB{0x1a, 0x2b, 0x3c}, c)
+	c, err = ParseColor("fff")
+	is.NoError(err)
+	is.Equal(RGB{255, 255, 255}, c)
+	c, err = ParseColor("rgb(255, 0, 10)")
+	is.NoError(err)
+	is.Equal(RGB{255, 0, 10}, c)
+
+	_, err = ParseColor("#ffff")
+	is.Error(err)
+	_, err = ParseColor("red")
+	is.Error(err)
+}
+
+func TestContrastRatio(t *testing.T) {
+	is := assert.New(t)
+
+	ratio, err := ContrastRatio("#000", "#fff")
+	is.NoError(err)
+	is.Equal(float64(21), ratio)
+	ratio, _ = ContrastRatio("#777", "#777")
+	is.Equal(float64(1), ratio)
+	ratio, _ = ContrastRatio("#ffffff", "#767676")
+	is.InDelta(4.54, ratio, 0.01)
+	_, err = ContrastRatio("#000", "invalid")
+	is.Error(err)
+
+	v := Map(M{"textColor": "#777777", "backgroundColor": "#ffffff", "linkColor": "rgb(0, 0, 238)"})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"textColor": "contrastWithField:backgroundColor,4.5",
+		"linkColor": "contrast_with_field:backgroundColor,4.5",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("textColor color contrast ratio with the field backgroundColor must be at least 4.5:1", v.Errors.One())
+
+	// the dst field not exists
+	v = Map(M{"textColor": "#777777"})
+	v.StringRule("textColor", "contrastWithField:backgroundColor,4.5")
+	is.True(v.Validate())
+}
@@ -1,7 +1,9 @@
 package validate
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -9,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -26,9 +29,16 @@ const (
 	sourceForm
 	// from user setting
 	sourceStruct
+	// from user setting, slice/array of the struct
+	sourceSlice
+	// merged from multi data sources
+	sourceMerged
 )
 
-var timeType = reflect.TypeOf(time.Time{})
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
 
 // data (Un)marshal func
 var (
@@ -42,6 +52,30 @@ type MarshalFunc func(v interface{}) ([]byte, error)
 // UnmarshalFunc define
 type UnmarshalFunc func(data []byte, v interface{}) error
 
+// check the Unmarshal func is replaced by the user. eg: jsoniter.Unmarshal
+func isCustomUnmarshal() bool {
+	return reflect.ValueOf(Unmarshal).Pointer() != reflect.ValueOf(json.Unmarshal).Pointer()
+}
+
+// decode the JSON data by the Unmarshal, the GlobalOption.JSONUseNumber is used on it is not replaced.
+func jsonUnmarshal(data []byte, v interface{}) error {
+	if !globalOpt.JSONUseNumber || isCustomUnmarshal() {
+		return Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	// same as the json.Unmarshal, not allow the data after the top-level value
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
 // DataFace interface definition
 type DataFace interface {
 	Type() uint8
@@ -90,7 +124,7 @@ func (d *MapData) Get(field string) (interface{}, bool) {
 	// 	return fv, true
 	// }
 
-	return filter.GetByPath(field, d.Map)
+	return getByPath(field, d.Map)
 }
 
 // Create a Validation from data
@@ -107,12 +141,12 @@ func (d *MapData) Validation(err ...error) *Validation {
 }
 
 // BindJSON binds v to the JSON data in the request body.
-// It calls json.Unmarshal and sets the value of v.
+// It calls the Unmarshal(default is json.Unmarshal) and sets the value of v.
 func (d *MapData) BindJSON(ptr interface{}) error {
 	if len(d.bodyJSON) == 0 {
 		return nil
 	}
-	return Unmarshal(d.bodyJSON, ptr)
+	return jsonUnmarshal(d.bodyJSON, ptr)
 }
 
 /*************************************************************
@@ -124,6 +158,24 @@ type ConfigValidationFace interface {
 	ConfigValidation(v *Validation)
 }
 
+// StructValidatorFace definition. the method is called after the field rules are applied,
+// use for validate the invariants spanning multiple fields.
+// Usage:
+// 	func (f *Form) Validate(v *validate.Validation) {
+// 		if f.Start > f.End {
+// 			v.AddError("End", "gtStart", "End must be greater than Start")
+// 		}
+// 	}
+type StructValidatorFace interface {
+	Validate(v *Validation)
+}
+
+// StructErrorFace definition. like the StructValidatorFace, the returned error will be added to the Errors.
+// if the error is an Errors, the field errors will be merged.
+type StructErrorFace interface {
+	ValidateStruct() error
+}
+
 // FieldTranslatorFace definition. you can custom field translates.
 // Usage:
 // 	type User struct {
@@ -172,6 +224,8 @@ type StructData struct {
 	FilterTag string
 	// ValidateTag name in the struct tags.
 	ValidateTag string
+	// DefaultTag name in the struct tags.
+	DefaultTag string
 }
 
 // StructOption definition
@@ -234,7 +288,13 @@ func (d *StructData) Validation(err ...error) *Validation {
 }
 
 // parse and collect rules from struct tags.
-func (d *StructData) parseRulesFromTag(v *Validation) {
+// if the prefix is not empty, will add it to the field names. eg: "0." + "Name"
+func (d *StructData) parseRulesFromTag(v *Validation, prefix ...string) {
+	var fieldPrefix string
+	if len(prefix) > 0 {
+		fieldPrefix = prefix[0]
+	}
+
 	if d.ValidateTag == "" {
 		d.ValidateTag = globalOpt.ValidateTag
 	}
@@ -243,6 +303,10 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 		d.FilterTag = globalOpt.FilterTag
 	}
 
+	if d.DefaultTag == "" {
+		d.DefaultTag = globalOpt.DefaultTag
+	}
+
 	vt := d.valueTpy
 	for i := 0; i < vt.NumField(); i++ {
 		name := vt.Field(i).Name
@@ -261,13 +325,37 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 		// validate rule
 		vRule := vt.Field(i).Tag.Get(d.ValidateTag)
 		if vRule != "" {
-			v.StringRule(name, vRule)
+			v.StringRule(fieldPrefix+name, vRule)
 		}
 
 		// filter rule
 		fRule := vt.Field(i).Tag.Get(d.FilterTag)
 		if fRule != "" {
-			v.FilterRule(name, fRule)
+			v.FilterRule(fieldPrefix+name, fRule)
+		}
+
+		// default value, convert to the field type. the tag is disabled on the DefaultTag is empty
+		var defVal string
+		if d.DefaultTag != "" {
+			defVal = vt.Field(i).Tag.Get(d.DefaultTag)
+		}
+
+		if defVal != "" {
+			val, err := parseStringAs(defVal, vt.Field(i).Type)
+			switch {
+			case err == errUnsupportedType:
+				// eg: slice, time.Time. the tag may be used by the other package, ignore it.
+				defVal = ""
+			case err != nil:
+				panicf("invalid default value %q of the field %s: %s", defVal, name, err.Error())
+			default:
+				v.SetDefValue(fieldPrefix+name, val)
+			}
+		}
+
+		// only has the filter or default value, mark it is safe. the value will be in the SafeData
+		if vRule == "" && (fRule != "" || defVal != "") {
+			v.StringRule(fieldPrefix+name, "safe")
 		}
 	}
 }
@@ -278,6 +366,32 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 
 // Get value by field name
 func (d *StructData) Get(field string) (interface{}, bool) {
+	fv, ok := d.fieldValue(field)
+	if !ok {
+		return nil, false
+	}
+
+	// the sql.Null* types, use the inner value. the invalid value as not exist.
+	if isSQLNullType(fv.Type()) {
+		if !fv.FieldByName("Valid").Bool() || !fv.Field(0).CanInterface() {
+			return nil, false
+		}
+		return fv.Field(0).Interface(), true
+	}
+
+	// check can interface
+	if fv.CanInterface() {
+		// up: if is zero value, as not exist.
+		if IsZero(fv) {
+			return nil, false
+		}
+		return fv.Interface(), true
+	}
+	return nil, false
+}
+
+// get the reflect value of the field, the sub struct field is allowed. eg: "Address.City"
+func (d *StructData) fieldValue(field string) (reflect.Value, bool) {
 	var fv reflect.Value
 	field = strutil.UpperFirst(field)
 
@@ -292,7 +406,7 @@ func (d *StructData) Get(field string) (interface{}, bool) {
 	} else {
 		// want get sub struct filed
 		if !strings.ContainsRune(field, '.') {
-			return nil, false
+			return fv, false
 		}
 
 		ss := strings.SplitN(field, ".", 2)
@@ -301,25 +415,17 @@ func (d *StructData) Get(field string) (interface{}, bool) {
 		// check top field is an struct
 		tft, ok := d.valueTpy.FieldByName(field)
 		if !ok || tft.Type.Kind() != reflect.Struct { // not found OR not a struct
-			return nil, false
+			return fv, false
 		}
 
 		// get field in sub-struct
 		fv = d.value.FieldByName(field).FieldByName(subField)
 		if !fv.IsValid() { // not found
-			return nil, false
+			return fv, false
 		}
 	}
 
-	// check can interface
-	if fv.CanInterface() {
-		// up: if is zero value, as not exist.
-		if IsZero(fv) {
-			return nil, false
-		}
-		return fv.Interface(), true
-	}
-	return nil, false
+	return fv, true
 }
 
 // Set value by field name.
@@ -337,6 +443,23 @@ func (d *StructData) Set(field string, val interface{}) (newVal interface{}, err
 		return nil, ErrSetValue
 	}
 
+	// the sql.Null* types, set the inner value and mark it is valid. nil as the invalid value.
+	if isSQLNullType(fv.Type()) {
+		if val == nil {
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil, nil
+		}
+
+		if newVal, err = setFieldValue(fv.Field(0), val); err == nil {
+			fv.FieldByName("Valid").SetBool(true)
+		}
+		return
+	}
+	return setFieldValue(fv, val)
+}
+
+// set the value to the field, will convert the value type.
+func setFieldValue(fv reflect.Value, val interface{}) (newVal interface{}, err error) {
 	// Notice: need convert value type
 	rftVal := reflect.ValueOf(val)
 
@@ -362,6 +485,16 @@ func (d *StructData) Set(field string, val interface{}) (newVal interface{}, err
 	return
 }
 
+// check the type is the database/sql Null* types. eg: sql.NullString, sql.NullInt64, sql.Null[T]
+func isSQLNullType(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct || typ.PkgPath() != "database/sql" || !strings.HasPrefix(typ.Name(), "Null") {
+		return false
+	}
+
+	valid, ok := typ.FieldByName("Valid")
+	return ok && valid.Type.Kind() == reflect.Bool && typ.NumField() == 2
+}
+
 // FuncValue get func value in the src struct
 func (d *StructData) FuncValue(name string) (reflect.Value, bool) {
 	fv := d.value.MethodByName(filter.UpperFirst(name))
@@ -383,6 +516,187 @@ func (d *StructData) HasField(field string) bool {
 	return false
 }
 
+/*************************************************************
+ * Slice Data
+ *************************************************************/
+
+// SliceData definition. the source data is an slice/array of the struct or map.
+// the field name is start with the element index. eg: "0.Name", "1.Age"
+type SliceData struct {
+	// source slice data, from user setting
+	src interface{}
+	// data for each element. *StructData or *MapData
+	items []DataFace
+}
+
+// Type get
+func (d *SliceData) Type() uint8 {
+	return uint8(sourceSlice)
+}
+
+// Len get the number of the elements
+func (d *SliceData) Len() int {
+	return len(d.items)
+}
+
+// Create a Validation from the SliceData
+func (d *SliceData) Create(err ...error) *Validation {
+	return d.Validation(err...)
+}
+
+// Validation create from the SliceData.
+// will collect rules, translates and messages of each element.
+func (d *SliceData) Validation(err ...error) *Validation {
+	v := NewValidation(d)
+	if len(err) > 0 && err[0] != nil {
+		return v.WithError(err[0])
+	}
+
+	for i, elem := range d.items {
+		item, ok := elem.(*StructData)
+		if !ok {
+			continue
+		}
+
+		prefix := strconv.Itoa(i) + "."
+		// collect field filter/validate rules from struct tags
+		item.parseRulesFromTag(v, prefix)
+
+		// collect custom field translates config
+		if item.valueTpy.Implements(ftFaceType) {
+			fv := item.value.MethodByName("Translates")
+			vs := fv.Call(nil)
+			v.WithTranslates(prefixFieldKeys(prefix, vs[0].Interface().(map[string]string), false))
+		}
+
+		// collect custom error messages config
+		if item.valueTpy.Implements(cmFaceType) {
+			fv := item.value.MethodByName("Messages")
+			vs := fv.Call(nil)
+			v.WithMessages(prefixFieldKeys(prefix, vs[0].Interface().(map[string]string), true))
+		}
+	}
+
+	// for struct, default update source value
+	v.UpdateSource = true
+	return v
+}
+
+// Get value by field path. eg: "0.Name"
+func (d *SliceData) Get(field string) (interface{}, bool) {
+	item, subField := d.itemOf(field)
+	if item == nil {
+		return nil, false
+	}
+
+	if subField == "" {
+		if md, ok := item.(*MapData); ok {
+			return md.Map, true
+		}
+		return item.(*StructData).src, true
+	}
+	return item.Get(subField)
+}
+
+// Set value by field path. eg: "0.Name"
+func (d *SliceData) Set(field string, val interface{}) (interface{}, error) {
+	item, subField := d.itemOf(field)
+	if item == nil || subField == "" {
+		return nil, ErrNoField
+	}
+	return item.Set(subField, val)
+}
+
+func (d *SliceData) itemOf(field string) (DataFace, string) {
+	var subField string
+	if pos := strings.IndexRune(field, '.'); pos > 0 {
+		field, subField = field[:pos], field[pos+1:]
+	}
+
+	idx, err := strconv.Atoi(field)
+	if err != nil || idx < 0 || idx >= len(d.items) {
+		return nil, ""
+	}
+	return d.items[idx], subField
+}
+
+// add prefix for the field keys.
+// if onlySub is true, only the key like "field.validator" will add prefix.
+func prefixFieldKeys(prefix string, mp map[string]string, onlySub bool) map[string]string {
+	newMp := make(map[string]string, len(mp))
+	for key, val := range mp {
+		if onlySub && !strings.ContainsRune(key, '.') {
+			newMp[key] = val
+		} else {
+			newMp[prefix+key] = val
+		}
+	}
+	return newMp
+}
+
+/*************************************************************
+ * Merged Data
+ *************************************************************/
+
+// MergedData layers multi data sources into one. eg: path params, body, query
+// the front source has higher priority when get value.
+type MergedData struct {
+	// Sources the data sources, sorted by priority
+	Sources []DataFace
+}
+
+// Type get
+func (d *MergedData) Type() uint8 {
+	return uint8(sourceMerged)
+}
+
+// Get value by key, will find from the sources by priority
+func (d *MergedData) Get(key string) (interface{}, bool) {
+	for _, src := range d.Sources {
+		if val, ok := src.Get(key); ok {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+// Set value by key. will set to the source that has the key, default is the first source.
+func (d *MergedData) Set(field string, val interface{}) (interface{}, error) {
+	if len(d.Sources) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	for _, src := range d.Sources {
+		if _, ok := src.Get(field); ok {
+			return src.Set(field, val)
+		}
+	}
+	return d.Sources[0].Set(field, val)
+}
+
+// Create a Validation from data
+func (d *MergedData) Create(err ...error) *Validation {
+	return d.Validation(err...)
+}
+
+// Validation create from data
+func (d *MergedData) Validation(err ...error) *Validation {
+	if len(err) > 0 && err[0] != nil {
+		return NewValidation(d).WithError(err[0])
+	}
+	return NewValidation(d)
+}
+
+// FormData get the first FormData in the sources. use for the uploaded file validate
+func (d *MergedData) FormData() (*FormData, bool) {
+	for _, src := range d.Sources {
+		if fd, ok := src.(*FormData); ok {
+			return fd, true
+		}
+	}
+	return nil, false
+}
+
 /*************************************************************
  * Form Data
  *************************************************************/
@@ -403,6 +717,8 @@ type FormData struct {
 	// jsonBodies holds the original body of the request.
 	// Only available for json requests.
 	jsonBodies []byte
+	// the parsed nested values of the bracketed form keys. see nestedValues()
+	nested map[string]interface{}
 }
 
 func newFormData() *FormData {
@@ -437,6 +753,7 @@ func (d *FormData) Validation(err ...error) *Validation {
 // Add adds the value to key. It appends to any existing values associated with key.
 func (d *FormData) Add(key string, value string) {
 	d.Form.Add(key, value)
+	d.nested = nil
 }
 
 // AddValues to Data.Form
@@ -446,6 +763,7 @@ func (d *FormData) AddValues(values url.Values) {
 			d.Form.Add(key, val)
 		}
 	}
+	d.nested = nil
 }
 
 // AddFiles adds the multipart form files to data
@@ -465,6 +783,7 @@ func (d *FormData) AddFile(key string, file *multipart.FileHeader) {
 // Del deletes the values associated with key.
 func (d *FormData) Del(key string) {
 	d.Form.Del(key)
+	d.nested = nil
 }
 
 // DelFile deletes the file associated with key (if any).
@@ -482,6 +801,7 @@ func (d *FormData) Encode() string {
 
 // Set sets the key to value. It replaces any existing values.
 func (d *FormData) Set(field string, val interface{}) (newVal interface{}, err error) {
+	d.nested = nil
 	newVal = val
 	switch val.(type) {
 	case string:
@@ -496,7 +816,7 @@ func (d *FormData) Set(field string, val interface{}) (newVal interface{}, err e
 }
 
 // Get value by key
-func (d FormData) Get(key string) (interface{}, bool) {
+func (d *FormData) Get(key string) (interface{}, bool) {
 	// get form value
 	if vs, ok := d.Form[key]; ok && len(vs) > 0 {
 		return vs[0], true
@@ -507,7 +827,107 @@ func (d FormData) Get(key string) (interface{}, bool) {
 		return fh, true
 	}
 
-	return nil, false
+	// get from the bracketed keys. eg: "user.name" => "user[name]"
+	return getByPath(key, d.nestedValues())
+}
+
+// parse the bracketed form keys to a nested structure. eg:
+// 	"user[name]" => {"user": {"name": "inhere"}}
+// 	"addresses[0][city]" => {"addresses": [{"city": "..."}]}
+// 	"tags[]" => {"tags": ["a", "b"]}
+//
+// the keys are parsed in the sorted order, the deeper key wins on they are overlapped. eg: "a[b]" and "a[b][c]".
+// the result is cached until the form is changed by the methods of the FormData.
+func (d *FormData) nestedValues() map[string]interface{} {
+	if d.nested != nil {
+		return d.nested
+	}
+
+	formKeys := make([]string, 0, len(d.Form))
+	for key := range d.Form {
+		formKeys = append(formKeys, key)
+	}
+	sort.Strings(formKeys)
+
+	mp := make(map[string]interface{})
+	for _, key := range formKeys {
+		vals := d.Form[key]
+		keys := parseBracketKey(key)
+		if len(keys) < 2 || len(vals) == 0 {
+			continue
+		}
+
+		// the "tags[]" collect all values to a list
+		if keys[len(keys)-1] == "" {
+			list := make([]interface{}, len(vals))
+			for i, val := range vals {
+				list[i] = val
+			}
+			setNestedValue(mp, keys[:len(keys)-1], list)
+		} else {
+			setNestedValue(mp, keys, vals[0])
+		}
+	}
+
+	for key, sub := range mp {
+		mp[key] = indexedToSlice(sub)
+	}
+
+	d.nested = mp
+	return mp
+}
+
+// split the bracketed key. eg: "addresses[0][city]" => ["addresses", "0", "city"].
+// return nil on the key is not bracketed.
+func parseBracketKey(key string) []string {
+	pos := strings.IndexByte(key, '[')
+	if pos < 1 || key[len(key)-1] != ']' {
+		return nil
+	}
+
+	keys := []string{key[:pos]}
+	for _, sub := range strings.Split(key[pos+1:len(key)-1], "][") {
+		if strings.ContainsAny(sub, "[]") {
+			return nil
+		}
+		keys = append(keys, sub)
+	}
+	return keys
+}
+
+func setNestedValue(mp map[string]interface{}, keys []string, val interface{}) {
+	last := len(keys) - 1
+	for _, key := range keys[:last] {
+		sub, ok := mp[key].(map[string]interface{})
+		if !ok {
+			sub = make(map[string]interface{})
+			mp[key] = sub
+		}
+		mp = sub
+	}
+	mp[keys[last]] = val
+}
+
+// convert the map that keys are the continuous indexes to slice. eg: {"0": a, "1": b} => [a, b]
+func indexedToSlice(val interface{}) interface{} {
+	mp, ok := val.(map[string]interface{})
+	if !ok {
+		return val
+	}
+
+	for key, sub := range mp {
+		mp[key] = indexedToSlice(sub)
+	}
+
+	list := make([]interface{}, len(mp))
+	for key, sub := range mp {
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 || idx >= len(mp) || strconv.Itoa(idx) != key {
+			return mp
+		}
+		list[idx] = sub
+	}
+	return list
 }
 
 // String value get by key
@@ -1,6 +1,9 @@
 package validate
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"net/url"
@@ -40,6 +43,96 @@ func TestCallByValue(t *testing.T) {
 	})
 }
 
+func TestFromJSON_customUnmarshal(t *testing.T) {
+	is := assert.New(t)
+
+	var calls int
+	// eg: a faster decoder. here decode the numbers as json.Number
+	Unmarshal = func(data []byte, v interface{}) error {
+		calls++
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		return dec.Decode(v)
+	}
+	defer func() {
+		Unmarshal = json.Unmarshal
+	}()
+
+	d, err := FromJSON(`{"name": "inhere", "age": 20}`)
+	is.NoError(err)
+	is.Equal(1, calls)
+	is.Equal(json.Number("20"), d.Map["age"])
+
+	user := &struct {
+		Name string `json:"name"`
+	}{}
+	is.NoError(d.BindJSON(user))
+	is.Equal(2, calls)
+	is.Equal("inhere", user.Name)
+
+	_, err = FromJSONBytes([]byte("{invalid"))
+	is.Error(err)
+	is.Equal(3, calls)
+
+	// the custom Unmarshal is not bypassed by the JSONUseNumber
+	Config(func(opt *GlobalOption) {
+		opt.JSONUseNumber = true
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.JSONUseNumber = false
+	})
+
+	_, err = FromJSON(`{"age": 20}`)
+	is.NoError(err)
+	is.Equal(4, calls)
+}
+
+func TestGlobalOption_JSONUseNumber(t *testing.T) {
+	is := assert.New(t)
+
+	Config(func(opt *GlobalOption) {
+		opt.JSONUseNumber = true
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.JSONUseNumber = false
+	})
+
+	// 2^53 + 1 cannot be represented by the float64
+	body := `{"id": 9007199254740993, "uid": 18446744073709551615, "price": "12.30", "amount": 12.34, "rate": 0.5}`
+	d, err := FromJSON(body)
+	is.NoError(err)
+	is.Equal(json.Number("9007199254740993"), d.Map["id"])
+
+	v := d.Create()
+	v.StringRules(MS{
+		"id":     "required|int|min:9007199254740993|max:9007199254740993",
+		"uid":    "required|uint",
+		"amount": "float|min:12|moneyString:USD,min=12.34",
+		"rate":   "float|between:0,1|multipleOf:0.25",
+	})
+	is.True(v.Validate(), v.Errors.One())
+	// the SafeData keep the json.Number
+	is.Equal(json.Number("9007199254740993"), v.SafeVal("id"))
+
+	v = d.Create()
+	v.StringRule("id", "max:9007199254740992")
+	is.False(v.Validate())
+
+	_, err = FromJSON(`{"id": 1} {"id": 2}`)
+	is.Error(err)
+	_, err = FromJSON(`{"id": 1}  `)
+	is.NoError(err)
+
+	is.Equal(int64(12), jsonNumberValue("12"))
+	is.Equal(uint64(18446744073709551615), jsonNumberValue("18446744073709551615"))
+	is.Equal(json.Number("1.5"), jsonNumberValue("1.5"))
+	i64, err := toInt64(json.Number("12.5"))
+	is.NoError(err)
+	is.Equal(int64(12), i64)
+	_, err = toInt64(json.Number("abc"))
+	is.Error(err)
+}
+
 func TestData(t *testing.T) {
 	is := assert.New(t)
 	// MapData
@@ -135,6 +228,72 @@ func TestFormData(t *testing.T) {
 	is.False(d.HasFile("file"))
 }
 
+func TestFormData_nested(t *testing.T) {
+	is := assert.New(t)
+
+	q, err := url.ParseQuery("user[name]=inhere&user[age]=20&addresses[0][city]=Paris&addresses[1][city]=&tags[]=go&tags[]=php&ids[2]=a")
+	is.NoError(err)
+	d := FromURLValues(q)
+
+	val, ok := d.Get("user.name")
+	is.True(ok)
+	is.Equal("inhere", val)
+	val, ok = d.Get("addresses.0.city")
+	is.True(ok)
+	is.Equal("Paris", val)
+	val, ok = d.Get("tags")
+	is.True(ok)
+	is.Equal([]interface{}{"go", "php"}, val)
+	val, ok = d.Get("ids.2")
+	is.True(ok)
+	is.Equal("a", val)
+	_, ok = d.Get("user.email")
+	is.False(ok)
+	val, ok = d.Get("user[name]")
+	is.True(ok)
+	is.Equal("inhere", val)
+
+	is.Nil(parseBracketKey("user"))
+	is.Nil(parseBracketKey("[name]"))
+	is.Nil(parseBracketKey("user[name"))
+	is.Nil(parseBracketKey("user[a]b]"))
+	is.Equal([]string{"tags", ""}, parseBracketKey("tags[]"))
+
+	v := d.Create()
+	v.StopOnError = false
+	v.StringRule("user.name", "required|minLen:3")
+	v.StringRule("user.age", "required|min:18")
+	v.StringRule("addresses.*.city", "required")
+	v.StringRule("tags", "required|each:minLen:3")
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Contains(v.Errors, "addresses.1.city")
+	is.Contains(v.Errors, "tags.0")
+
+	// the overlapped keys, the deeper key wins
+	for i := 0; i < 10; i++ {
+		d = FromURLValues(url.Values{"a[b]": {"1"}, "a[b][c]": {"2"}})
+		val, ok = d.Get("a.b.c")
+		is.True(ok)
+		is.Equal("2", val)
+	}
+
+	// the cache is reset on the form changed
+	_, ok = d.Get("a.x")
+	is.False(ok)
+	d.Add("a[x]", "3")
+	val, ok = d.Get("a.x")
+	is.True(ok)
+	is.Equal("3", val)
+	_, err = d.Set("a[x]", "4")
+	is.NoError(err)
+	val, _ = d.Get("a.x")
+	is.Equal("4", val)
+	d.Del("a[x]")
+	_, ok = d.Get("a.x")
+	is.False(ok)
+}
+
 func TestStructData_Create(t *testing.T) {
 	is := assert.New(t)
 	_, err := FromStruct(time.Now())
@@ -197,3 +356,141 @@ func TestStructData_Create(t *testing.T) {
 	is.True(ok)
 	is.Equal("inhere", str)
 }
+
+func TestMapData_GetByPath(t *testing.T) {
+	is := assert.New(t)
+
+	d := FromMap(M{
+		"items": []interface{}{M{"sku": "a1"}, map[string]string{"sku": "a2"}},
+		"ints":  [2]int{3, 4},
+		"sub":   map[interface{}]interface{}{"key": "val"},
+		"ptr":   &M{"key": "val1"},
+	})
+
+	tests := map[string]interface{}{
+		"items.0.sku": "a1",
+		"items.1.sku": "a2",
+		"ints.1":      4,
+		"sub.key":     "val",
+		"ptr.key":     "val1",
+	}
+	for path, want := range tests {
+		val, ok := d.Get(path)
+		is.True(ok)
+		is.Equal(want, val)
+	}
+
+	for _, path := range []string{"items.2.sku", "items.-1", "items.a", "ints.1.a", "not.exists"} {
+		_, ok := d.Get(path)
+		is.False(ok)
+	}
+}
+
+func TestMergeData(t *testing.T) {
+	is := assert.New(t)
+
+	path := FromMap(M{"id": "12"})
+	body := FromMap(M{"id": 23, "name": "inhere"})
+	query := FromQuery(url.Values{"page": {"2"}, "name": {"other"}})
+	query.AddFile("avatar", &multipart.FileHeader{Filename: "a.png"})
+
+	d := MergeData(path, nil, body, query)
+	is.Equal(uint8(sourceMerged), d.Type())
+	is.Len(d.Sources, 3)
+
+	// the front source has higher priority
+	val, ok := d.Get("id")
+	is.True(ok)
+	is.Equal("12", val)
+	val, _ = d.Get("name")
+	is.Equal("inhere", val)
+	val, _ = d.Get("page")
+	is.Equal("2", val)
+	_, ok = d.Get("not-exist")
+	is.False(ok)
+
+	// set to the source that has the key
+	_, err := d.Set("page", 3)
+	is.Nil(err)
+	is.Equal("3", query.String("page"))
+	_, err = d.Set("new", "val")
+	is.Nil(err)
+	is.Equal("val", path.Map["new"])
+	_, err = MergeData().Set("new", "val")
+	is.Equal(ErrEmptyData, err)
+
+	fd, ok := d.FormData()
+	is.True(ok)
+	is.Equal(query, fd)
+
+	v := d.Create()
+	v.StringRules(MS{
+		"id":     "required|numeric",
+		"name":   "required|minLen:4",
+		"page":   "required|numeric",
+		"avatar": "required",
+	})
+	is.True(v.Validate(), v.Errors.String())
+	is.Equal("12", v.SafeVal("id"))
+}
+
+func TestStructData_sqlNullTypes(t *testing.T) {
+	is := assert.New(t)
+
+	type user struct {
+		Name     sql.NullString  `validate:"required|minLen:3" filter:"trim|upper"`
+		Age      sql.NullInt64   `validate:"min:18"`
+		Score    sql.NullFloat64 `validate:"required"`
+		Birthday sql.NullTime
+	}
+
+	u := &user{
+		Name:  sql.NullString{String: "  inhere ", Valid: true},
+		Age:   sql.NullInt64{Int64: 20, Valid: true},
+		Score: sql.NullFloat64{Float64: 9.5, Valid: false},
+	}
+	v := Struct(u)
+	v.StopOnError = false
+	is.False(v.Validate())
+	// the invalid value is empty
+	is.Len(v.Errors, 1)
+	is.Equal("Score is required and not empty", v.Errors.One())
+	// the filter write back
+	is.Equal(sql.NullString{String: "INHERE", Valid: true}, u.Name)
+	is.Equal("INHERE", v.Filtered("Name"))
+
+	u.Score = sql.NullFloat64{Float64: 0.5, Valid: true}
+	v = Struct(u)
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(int64(20), v.SafeVal("Age"))
+	is.Equal(0.5, v.SafeVal("Score"))
+
+	// skip on empty
+	u.Age = sql.NullInt64{Int64: 1}
+	v = Struct(u)
+	is.True(v.Validate())
+
+	// set the value
+	d, err := FromStruct(u)
+	is.NoError(err)
+	_, err = d.Set("Birthday", time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC))
+	is.NoError(err)
+	is.True(u.Birthday.Valid)
+	val, ok := d.Get("Birthday")
+	is.True(ok)
+	is.Equal(2000, val.(time.Time).Year())
+
+	newVal, err := d.Set("Age", "30")
+	is.NoError(err)
+	is.Equal(int64(30), newVal)
+	is.Equal(sql.NullInt64{Int64: 30, Valid: true}, u.Age)
+
+	_, err = d.Set("Name", nil)
+	is.NoError(err)
+	is.False(u.Name.Valid)
+	_, ok = d.Get("Name")
+	is.False(ok)
+
+	is.True(IsEmpty(sql.NullString{String: "abc"}))
+	is.False(IsEmpty(sql.NullBool{Valid: true}))
+}
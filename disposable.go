@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DomainList the domain list for the validator "notDisposableEmail". see SetDisposableDomains()
+type DomainList interface {
+	// Contains check the domain is in the list. the domain is lower case
+	Contains(domain string) bool
+}
+
+// DomainSet the DomainList by the map. the keys are the lower case domains
+type DomainSet map[string]bool
+
+// NewDomainSet create the DomainSet from the domains
+func NewDomainSet(domains ...string) DomainSet {
+	ds := make(DomainSet, len(domains))
+	ds.Add(domains...)
+	return ds
+}
+
+// LoadDomainSet load the DomainSet from the reader. one domain per line, the empty lines and the "#" comments are ignored.
+func LoadDomainSet(r io.Reader) (DomainSet, error) {
+	ds := make(DomainSet)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if pos := strings.IndexByte(line, '#'); pos >= 0 {
+			line = line[:pos]
+		}
+		ds.Add(line)
+	}
+	return ds, sc.Err()
+}
+
+// LoadDomainSetFile load the DomainSet from the file. see LoadDomainSet()
+func LoadDomainSetFile(file string) (DomainSet, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadDomainSet(f)
+}
+
+// Add the domains to the set
+func (ds DomainSet) Add(domains ...string) {
+	for _, domain := range domains {
+		if domain = strings.ToLower(strings.Trim(strings.TrimSpace(domain), ".")); domain != "" {
+			ds[domain] = true
+		}
+	}
+}
+
+// Contains check the domain is in the set
+func (ds DomainSet) Contains(domain string) bool {
+	return ds[domain]
+}
+
+// the default disposable email domains, use SetDisposableDomains() to replace them by the full list
+var defaultDisposableDomains = NewDomainSet(
+	"10minutemail.com",
+	"discard.email",
+	"dispostable.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"maildrop.cc",
+	"mailinator.com",
+	"mintemail.com",
+	"sharklasers.com",
+	"temp-mail.org",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+)
+
+var (
+	disposableMux     sync.RWMutex
+	disposableDomains DomainList = defaultDisposableDomains
+)
+
+// SetDisposableDomains set the disposable domain list for the validator "notDisposableEmail".
+// the nil list will use the built-in list.
+// Usage:
+// 	ds, err := validate.LoadDomainSetFile("disposable_domains.txt")
+// 	if err != nil {
+// 		panic(err)
+// 	}
+// 	validate.SetDisposableDomains(ds)
+func SetDisposableDomains(list DomainList) {
+	if list == nil {
+		list = defaultDisposableDomains
+	}
+
+	disposableMux.Lock()
+	disposableDomains = list
+	disposableMux.Unlock()
+}
+
+// NotDisposableEmail check the value is an email address and the domain is not a disposable email domain.
+// the sub domains of the listed domains are also rejected. eg: "mailinator.com" rejects "x.mailinator.com"
+func NotDisposableEmail(s string) bool {
+	pos := strings.LastIndexByte(s, '@')
+	if !IsEmail(s) || pos < 0 {
+		return false
+	}
+
+	disposableMux.RLock()
+	list := disposableDomains
+	disposableMux.RUnlock()
+
+	domain := strings.ToLower(strings.TrimSuffix(s[pos+1:], "."))
+	for domain != "" {
+		if list.Contains(domain) {
+			return false
+		}
+
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return true
+}
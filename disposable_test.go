@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type suffixList string
+
+func (l suffixList) Contains(domain string) bool {
+	return strings.HasSuffix(domain, string(l))
+}
+
+func TestNotDisposableEmail(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(NotDisposableEmail("user@example.com"))
+	is.True(NotDisposableEmail("user@notmailinator.com"))
+	is.False(NotDisposableEmail("user@mailinator.com"))
+	is.False(NotDisposableEmail("user@MailInator.COM"))
+	is.False(NotDisposableEmail("user@x.y.mailinator.com"))
+	is.False(NotDisposableEmail("invalid"))
+
+	v := Map(M{"email": "user@yopmail.com"})
+	v.StringRule("email", "required|not_disposable_email")
+	is.False(v.Validate())
+	is.Equal("email value cannot be a disposable email address", v.Errors.One())
+
+	// the custom list
+	SetDisposableDomains(suffixList(".test"))
+	defer SetDisposableDomains(nil)
+
+	is.True(NotDisposableEmail("user@mailinator.com"))
+	is.False(NotDisposableEmail("user@spam.test"))
+
+	SetDisposableDomains(nil)
+	is.False(NotDisposableEmail("user@mailinator.com"))
+}
+
+func TestLoadDomainSet(t *testing.T) {
+	is := assert.New(t)
+
+	ds, err := LoadDomainSet(strings.NewReader("# the list\n\n Temp.Example \nspam.test # inline comment\n.dot.test.\n"))
+	is.NoError(err)
+	is.Len(ds, 3)
+	is.True(ds.Contains("temp.example"))
+	is.True(ds.Contains("spam.test"))
+	is.True(ds.Contains("dot.test"))
+
+	dir, err := ioutil.TempDir("", "validate")
+	is.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "domains.txt")
+	is.NoError(ioutil.WriteFile(file, []byte("throwaway.test\n"), 0644))
+
+	ds, err = LoadDomainSetFile(file)
+	is.NoError(err)
+	ds.Add("other.test")
+	is.Equal(NewDomainSet("throwaway.test", "OTHER.test"), ds)
+
+	SetDisposableDomains(ds)
+	defer SetDisposableDomains(nil)
+	is.False(NotDisposableEmail("user@mail.throwaway.test"))
+
+	_, err = LoadDomainSetFile(filepath.Join(dir, "not-exists.txt"))
+	is.Error(err)
+}
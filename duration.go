@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsDuration check the value is a time.Duration, or a duration string. eg: "30s", "2h45m"
+func IsDuration(val interface{}) bool {
+	_, ok := valueToDuration(val)
+	return ok
+}
+
+// MinDuration check the duration value is greater than or equal to the min. eg: "5s"
+// Usage:
+// 	v.StringRule("timeout", "minDuration:5s")
+func MinDuration(val interface{}, min string) bool {
+	d, ok := valueToDuration(val)
+	return ok && d >= mustParseDuration(min, "minDuration")
+}
+
+// MaxDuration check the duration value is less than or equal to the max. eg: "1h"
+// Usage:
+// 	v.StringRule("timeout", "maxDuration:1h")
+func MaxDuration(val interface{}, max string) bool {
+	d, ok := valueToDuration(val)
+	return ok && d <= mustParseDuration(max, "maxDuration")
+}
+
+// convert the time.Duration or the duration string to the time.Duration
+func valueToDuration(val interface{}) (time.Duration, bool) {
+	switch tv := val.(type) {
+	case time.Duration:
+		return tv, true
+	case string:
+		d, err := time.ParseDuration(tv)
+		return d, err == nil
+	}
+	return 0, false
+}
+
+func mustParseDuration(s, validator string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		panicf("invalid duration '%s' for the validator %s, eg: 30s, 2h45m", s, validator)
+	}
+	return d
+}
+
+// the filter "toDuration", convert the duration string to the time.Duration. eg: "2h45m"
+func filterToDuration(val interface{}) (time.Duration, error) {
+	switch tv := val.(type) {
+	case time.Duration:
+		return tv, nil
+	case string:
+		return time.ParseDuration(tv)
+	}
+	return 0, fmt.Errorf("cannot convert %T to the duration", val)
+}
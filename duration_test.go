@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationValidators(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsDuration("30s"))
+	is.True(IsDuration("2h45m"))
+	is.True(IsDuration(5 * time.Second))
+	is.False(IsDuration("30"))
+	is.False(IsDuration("abc"))
+	is.False(IsDuration(30))
+
+	is.True(MinDuration("5s", "5s"))
+	is.True(MinDuration(time.Minute, "5s"))
+	is.False(MinDuration("4s", "5s"))
+	is.False(MinDuration("abc", "5s"))
+	is.True(MaxDuration("1h", "1h"))
+	is.False(MaxDuration("1h1s", "1h"))
+	is.PanicsWithValue("validate: invalid duration 'abc' for the validator maxDuration, eg: 30s, 2h45m", func() {
+		MaxDuration("1s", "abc")
+	})
+
+	v := Map(M{"timeout": "30s", "retry": "2s", "idle": "2h"})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"timeout": "duration|minDuration:5s|maxDuration:1h",
+		"retry":   "min_duration:5s",
+		"idle":    "max_duration:1h",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("retry min duration is 5s", v.Errors.FieldOne("retry"))
+	is.Equal("idle max duration is 1h", v.Errors.FieldOne("idle"))
+
+	v = Map(M{"timeout": "xyz"})
+	v.StringRule("timeout", "duration")
+	is.False(v.Validate())
+	is.Equal("timeout value must be a valid duration. eg: 30s, 2h45m", v.Errors.One())
+}
+
+func TestFilter_toDuration(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"timeout": " 2h45m "})
+	v.StringRule("timeout", "required|maxDuration:3h", "trim|toDuration")
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(2*time.Hour+45*time.Minute, v.SafeVal("timeout"))
+
+	v = Map(M{"timeout": "abc"})
+	v.StringRule("timeout", "required", "toDuration")
+	is.False(v.Validate())
+	is.Contains(v.Errors.One(), "invalid duration")
+
+	d, err := filterToDuration(time.Second)
+	is.NoError(err)
+	is.Equal(time.Second, d)
+	_, err = filterToDuration(12)
+	is.Error(err)
+}
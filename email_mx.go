@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMXTimeout the default timeout of the DNS lookup for the validator "emailMX"
+const DefaultMXTimeout = 3 * time.Second
+
+// MXResolver lookup the DNS records for the validator "emailMX". the *net.Resolver is a MXResolver
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+var (
+	mxMux      sync.RWMutex
+	mxResolver MXResolver = net.DefaultResolver
+	mxTimeout             = DefaultMXTimeout
+)
+
+// SetMXResolver set the resolver and the timeout for the validator "emailMX".
+// the nil resolver will use the net.DefaultResolver, the timeout <= 0 will use the DefaultMXTimeout.
+func SetMXResolver(r MXResolver, timeout time.Duration) {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	if timeout <= 0 {
+		timeout = DefaultMXTimeout
+	}
+
+	mxMux.Lock()
+	mxResolver, mxTimeout = r, timeout
+	mxMux.Unlock()
+}
+
+// EmailMX check the value is an email address and the domain can receive the emails.
+// the domain must has the MX records, or the A/AAAA records on no MX records. the "null MX"(RFC 7505) is rejected.
+// it is marked as the I/O validator, see MarkIOValidators()
+// Usage:
+// 	v.StringRule("email", "required|emailMX")
+func EmailMX(s string) bool {
+	pos := strings.LastIndexByte(s, '@')
+	if !IsEmail(s) || pos < 0 {
+		return false
+	}
+
+	mxMux.RLock()
+	r, timeout := mxResolver, mxTimeout
+	mxMux.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	domain := s[pos+1:]
+	mxs, err := r.LookupMX(ctx, domain)
+	if err == nil && len(mxs) > 0 {
+		// the null MX: the domain does not accept the emails
+		return !(len(mxs) == 1 && strings.TrimSuffix(mxs[0].Host, ".") == "")
+	}
+
+	// the implicit MX(RFC 5321): the A/AAAA records of the domain. the lookup fails fast on the timeout
+	addrs, err := r.LookupHost(ctx, domain)
+	return err == nil && len(addrs) > 0
+}
@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMXResolver struct {
+	mxs   map[string][]*net.MX
+	hosts map[string][]string
+	calls int
+}
+
+func (r *fakeMXResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.calls++
+	if name == "slow.com" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if mxs, ok := r.mxs[name]; ok {
+		return mxs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name}
+}
+
+func (r *fakeMXResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("lookup failed")
+}
+
+func TestEmailMX(t *testing.T) {
+	is := assert.New(t)
+
+	r := &fakeMXResolver{
+		mxs: map[string][]*net.MX{
+			"example.com": {{Host: "mx1.example.com.", Pref: 10}},
+			"nomail.com":  {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{
+			"a-only.com": {"192.0.2.1"},
+			"slow.com":   {"192.0.2.2"},
+		},
+	}
+	SetMXResolver(r, 20*time.Millisecond)
+	defer SetMXResolver(nil, 0)
+
+	is.True(EmailMX("user@example.com"))
+	is.True(EmailMX("user@a-only.com"))
+	is.False(EmailMX("user@nomail.com"))
+	is.False(EmailMX("user@missing.com"))
+	is.False(EmailMX("user@slow.com"))
+
+	// not an email, no lookup
+	r.calls = 0
+	is.False(EmailMX("invalid"))
+	is.False(EmailMX("user@"))
+	is.Equal(0, r.calls)
+
+	is.True(isIOValidator("emailMX"))
+
+	v := Map(M{"email": "user@nomail.com"})
+	v.StringRule("email", "required|email_mx")
+	is.False(v.Validate())
+	is.Equal("email value must be an email address with a deliverable domain", v.Errors.One())
+
+	v = Map(M{"email": "user@example.com"})
+	v.StringRule("email", "emailMX")
+	is.True(v.Validate())
+}
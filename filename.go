@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxFilenameLen the max bytes length of the file name
+const MaxFilenameLen = 255
+
+// the chars are not allowed in the file name
+const unsafeFilenameChars = `/\<>:"|?*`
+
+// the reserved device names of Windows
+var reservedFilenames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// IsSafeFilename check the file name is safe to save on the common file systems.
+// reject the control chars, path separators, reserved Windows names and the name is too long.
+func IsSafeFilename(s string) bool {
+	if s == "" || s == "." || s == ".." || len(s) > MaxFilenameLen || !utf8.ValidString(s) {
+		return false
+	}
+
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(unsafeFilenameChars, r) {
+			return false
+		}
+	}
+
+	// Windows does not allow end with space or dot
+	if last := s[len(s)-1]; last == ' ' || last == '.' {
+		return false
+	}
+	return !isReservedFilename(s)
+}
+
+// SanitizeFilename convert the file name to safe. the unsafe chars will be replaced to "_".
+// Usage:
+// 	SanitizeFilename("../a<b>.txt") // ".._a_b_.txt"
+func SanitizeFilename(s string) string {
+	s = strings.Map(func(r rune) rune {
+		// the invalid UTF-8 bytes will be passed as utf8.RuneError
+		if r < 0x20 || r == 0x7f || r == utf8.RuneError || strings.ContainsRune(unsafeFilenameChars, r) {
+			return '_'
+		}
+		return r
+	}, s)
+
+	s = strings.TrimRight(s, ". ")
+	if isReservedFilename(s) {
+		s = "_" + s
+	}
+
+	// truncate the name, keep the extension
+	if len(s) > MaxFilenameLen {
+		ext := filepath.Ext(s)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		s = truncateUTF8(s[:len(s)-len(ext)], MaxFilenameLen-len(ext)) + ext
+	}
+
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// check the name(without extension) is reserved by Windows. eg: "con", "NUL.txt"
+func isReservedFilename(s string) bool {
+	name := s
+	if pos := strings.IndexByte(s, '.'); pos >= 0 {
+		name = s[:pos]
+	}
+	return reservedFilenames[strings.ToUpper(strings.TrimSpace(name))]
+}
+
+// truncate the string to max bytes, without break the multi bytes char.
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
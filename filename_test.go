@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSafeFilename(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsSafeFilename("report-2019.pdf"))
+	is.True(IsSafeFilename(".env"))
+	is.True(IsSafeFilename("文档 v1.docx"))
+	is.True(IsSafeFilename("console.txt"))
+	is.False(IsSafeFilename(""))
+	is.False(IsSafeFilename(".."))
+	is.False(IsSafeFilename("../etc/passwd"))
+	is.False(IsSafeFilename(`a\b.txt`))
+	is.False(IsSafeFilename("a<b>.txt"))
+	is.False(IsSafeFilename("a\x00b.txt"))
+	is.False(IsSafeFilename("a\nb.txt"))
+	is.False(IsSafeFilename("CON"))
+	is.False(IsSafeFilename("nul.txt"))
+	is.False(IsSafeFilename("lpt1.tar.gz"))
+	is.False(IsSafeFilename("name."))
+	is.False(IsSafeFilename("name "))
+	is.False(IsSafeFilename(strings.Repeat("a", 256)))
+	is.False(IsSafeFilename("a\xffb"))
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	is := assert.New(t)
+
+	is.Equal("report.pdf", SanitizeFilename("report.pdf"))
+	is.Equal(".._etc_passwd", SanitizeFilename("../etc/passwd"))
+	is.Equal("a_b_.txt", SanitizeFilename("a<b>.txt"))
+	is.Equal("a_b", SanitizeFilename("a\x00b"))
+	is.Equal("a_b", SanitizeFilename("a\xffb"))
+	is.Equal("_CON", SanitizeFilename("CON"))
+	is.Equal("_nul.txt", SanitizeFilename("nul.txt"))
+	is.Equal("name", SanitizeFilename("name. . "))
+	is.Equal("_", SanitizeFilename(".."))
+	is.Equal("_", SanitizeFilename(""))
+
+	long := SanitizeFilename(strings.Repeat("文", 100) + ".txt")
+	is.True(len(long) <= MaxFilenameLen)
+	is.True(strings.HasSuffix(long, ".txt"))
+	is.True(IsSafeFilename(long))
+
+	v := Map(M{"name": "../a<b>.txt"})
+	v.FilterRule("name", "sanitizeFilename")
+	v.StringRule("name", "required|safeFilename")
+	is.True(v.Validate())
+	is.Equal(".._a_b_.txt", v.SafeVal("name"))
+
+	v = Map(M{"name": "../a.txt"})
+	v.StringRule("name", "required|safe_filename")
+	is.False(v.Validate())
+	is.Equal("name value must be a safe file name", v.Errors.One())
+}
@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 
@@ -12,8 +13,26 @@ import (
  *************************************************************/
 
 var (
-	filterValues map[string]reflect.Value
-	emptyValue   = reflect.Value{}
+	// the custom filters. the built in filters are from the package gookit/filter
+	filterValues = map[string]reflect.Value{
+		"latLng":           reflect.ValueOf(filterLatLng),
+		"isoDuration":      reflect.ValueOf(filterISODuration),
+		"isoInterval":      reflect.ValueOf(filterISOInterval),
+		"toDuration":       reflect.ValueOf(filterToDuration),
+		"toTime":           reflect.ValueOf(filterToTime),
+		"toDate":           reflect.ValueOf(filterToDate),
+		"sanitizeFilename": reflect.ValueOf(SanitizeFilename),
+		// text content
+		"stripEmoji":        reflect.ValueOf(StripEmoji),
+		"stripControlChars": reflect.ValueOf(StripControlChars),
+		"stripNewlines":     reflect.ValueOf(StripNewlines),
+		// rich text
+		"sanitizeRichText": reflect.ValueOf(SanitizeRichText),
+		// sanitizer
+		"stripTags":          reflect.ValueOf(StripTags),
+		"collapseWhitespace": reflect.ValueOf(CollapseWhitespace),
+	}
+	emptyValue = reflect.Value{}
 )
 
 // AddFilters add global filters
@@ -72,7 +91,7 @@ func (v *Validation) FilterFuncValue(name string) reflect.Value {
 // 	v.FilterRule("age", "int")
 func (v *Validation) FilterRule(field string, rule string) *FilterRule {
 	rule = strings.TrimSpace(rule)
-	rules := stringSplit(strings.Trim(rule, "|:"), "|")
+	rules := stringSplit(strings.Trim(rule, v.RuleSep+v.ValidatorSep), v.RuleSep)
 	fields := stringSplit(field, ",")
 
 	if len(fields) == 0 || len(rules) == 0 {
@@ -80,6 +99,7 @@ func (v *Validation) FilterRule(field string, rule string) *FilterRule {
 	}
 
 	r := newFilterRule(fields)
+	r.nameSep, r.argSep = v.ValidatorSep, v.ArgSep
 	r.AddFilters(rules...)
 	v.filterRules = append(v.filterRules, r)
 
@@ -106,11 +126,17 @@ type FilterRule struct {
 	filters []string
 	// filter args. { index: "args" }
 	filterArgs map[int]string
+	// separator between the filter name and args.
+	nameSep string
+	// separator of the filter args.
+	argSep string
 }
 
 func newFilterRule(fields []string) *FilterRule {
 	return &FilterRule{
-		fields: fields,
+		fields:  fields,
+		nameSep: validatorSep,
+		argSep:  argSep,
 		// init map
 		filterArgs: make(map[int]string),
 	}
@@ -121,12 +147,12 @@ func newFilterRule(fields []string) *FilterRule {
 // 	r.AddFilters("int", "str2arr:,")
 func (r *FilterRule) AddFilters(filters ...string) *FilterRule {
 	for _, filterName := range filters {
-		pos := strings.IndexRune(filterName, ':')
+		pos := strings.Index(filterName, r.nameSep)
 		if pos > 0 { // has filter args
 			name := filterName[:pos]
 			index := len(r.filters)
 			r.filters = append(r.filters, name)
-			r.filterArgs[index] = filterName[pos+1:]
+			r.filterArgs[index] = filterName[pos+len(r.nameSep):]
 		} else {
 			r.filters = append(r.filters, filterName)
 		}
@@ -141,6 +167,7 @@ func (r *FilterRule) Apply(v *Validation) (err error) {
 	for _, field := range r.Fields() {
 		// get field value.
 		val, has := v.Get(field)
+		v.traceRaw(field, val, has)
 		if !has { // no field
 			defVal, ok := v.GetDefValue(field)
 			// there is also no custom default value
@@ -149,7 +176,7 @@ func (r *FilterRule) Apply(v *Validation) (err error) {
 			}
 
 			// update source data field value
-			newVal, err := v.updateValue(field, defVal)
+			newVal, err := v.fillDefValue(field, defVal)
 			if err != nil {
 				return err
 			}
@@ -171,7 +198,7 @@ func (r *FilterRule) Apply(v *Validation) (err error) {
 		// call filters
 		for i, name := range r.filters {
 			fv := v.FilterFuncValue(name)
-			args := parseArgString(r.filterArgs[i])
+			args := parseArgString(r.filterArgs[i], r.argSep)
 			if !fv.IsValid() { // is built int filters
 				val, err = filter.Apply(name, val, args)
 			} else {
@@ -180,6 +207,7 @@ func (r *FilterRule) Apply(v *Validation) (err error) {
 			if err != nil {
 				return err
 			}
+			v.trace(field, TraceFilter, name, val, true)
 		}
 
 		// update source data field value
@@ -202,7 +230,12 @@ func (r *FilterRule) Fields() []string {
 func callCustomFilter(fv reflect.Value, val interface{}, args []string) (interface{}, error) {
 	var rs []reflect.Value
 	if len(args) > 0 {
-		rs = CallByValue(fv, buildArgs(val, strings2Args(args))...)
+		fArgs, err := convertFilterArgs(fv.Type(), args)
+		if err != nil {
+			return nil, err
+		}
+
+		rs = CallByValue(fv, buildArgs(val, fArgs)...)
 	} else {
 		rs = CallByValue(fv, val)
 	}
@@ -222,3 +255,29 @@ func callCustomFilter(fv reflect.Value, val interface{}, args []string) (interfa
 
 	return val, nil
 }
+
+// convert the string args to the param types of the filter func. eg: 'truncate:50' => func(s string, n int)
+func convertFilterArgs(ft reflect.Type, args []string) ([]interface{}, error) {
+	lastIdx := ft.NumIn() - 1
+	fArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		// "+1" because first param is val
+		idx := i + 1
+		if idx > lastIdx && !ft.IsVariadic() {
+			fArgs[i] = arg // number of args mismatch, will panic on call.
+			continue
+		}
+
+		typ := ft.In(minInt(idx, lastIdx))
+		if ft.IsVariadic() && idx >= lastIdx {
+			typ = typ.Elem()
+		}
+
+		nVal, err := parseStringAs(arg, typ)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert the filter argument %q to %s", arg, typ.Kind())
+		}
+		fArgs[i] = nVal
+	}
+	return fArgs, nil
+}
@@ -32,6 +32,23 @@ func TestFilterOnStruct(t *testing.T) {
 	ris.Equal("INHERE", u.Name)
 }
 
+func TestFilterOnStruct_onlyFilterTag(t *testing.T) {
+	is := assert.New(t)
+	u := &struct {
+		Name  string `filter:"trim|lower" validate:"required"`
+		Nick  string `filter:"trim|upper"`
+		Email string `filter:"trim"`
+	}{" Inhere ", " tom ", ""}
+
+	v := Struct(u)
+	is.True(v.Validate())
+	is.Equal("inhere", v.SafeVal("Name"))
+	is.Equal("TOM", v.SafeVal("Nick"))
+	is.Equal("TOM", u.Nick)
+	_, ok := v.Safe("Email")
+	is.False(ok)
+}
+
 func TestAddFilter(t *testing.T) {
 	is := assert.New(t)
 	is.Panics(func() {
@@ -125,6 +142,40 @@ func TestAddFilter(t *testing.T) {
 	is.Equal("report a error", v.Errors.FieldOne("_filter"))
 }
 
+func TestAddFilter_withArgs(t *testing.T) {
+	is := assert.New(t)
+
+	AddFilter("truncate", func(s string, n int) string {
+		if len(s) > n {
+			return s[:n]
+		}
+		return s
+	})
+	AddFilter("scale", func(val interface{}, rate float64, nums ...uint8) string {
+		return fmt.Sprint(val, "*", rate, nums)
+	})
+
+	u := &struct {
+		Title string `filter:"trim|truncate:5" validate:"required"`
+	}{" hello world "}
+	v := Struct(u)
+	is.True(v.Validate())
+	is.Equal("hello", u.Title)
+	is.Equal("hello", v.SafeVal("Title"))
+
+	v = Map(M{"title": "hello world", "num": 2})
+	v.FilterRules(MS{"title": "truncate:2", "num": "scale:1.5,3,4"})
+	is.True(v.Validate())
+	is.Equal("he", v.Filtered("title"))
+	is.Equal("2*1.5 [3 4]", v.Filtered("num"))
+
+	// invalid argument
+	v = Map(M{"title": "hello world"})
+	v.FilterRule("title", "truncate:abc")
+	is.False(v.Validate())
+	is.Equal(`cannot convert the filter argument "abc" to int`, v.Errors.FieldOne("_filter"))
+}
+
 // check panic caused nil value with custom filter
 func TestFilterRuleNilValue(t *testing.T) {
 	AddFilter("X", func(in interface{}) interface{} {
@@ -0,0 +1,108 @@
+// Package fuzz provide the fuzz targets for the rule string parser, the data builders and the args coercion.
+//
+// the targets are compatible with the go-fuzz(https://github.com/dvyukov/go-fuzz):
+//
+// 	go-fuzz-build -func FuzzRule github.com/gookit/validate/fuzz
+// 	go-fuzz -bin fuzz-fuzz.zip -workdir testdata/rule
+//
+// each target returns 1 on the input is parsed, 0 on the input is rejected by the returned errors.
+// any panic is a bug, the malformed input must be returned as an error.
+package fuzz
+
+import (
+	"bytes"
+	"net/url"
+
+	"github.com/gookit/validate"
+)
+
+// the rules applied to the data built by the FuzzJSON and FuzzForm
+var dataRules = validate.MS{
+	"name":        "required|string|minLen:2|maxLen:20",
+	"age":         "int|min:1|max:120",
+	"email":       "email",
+	"tags":        "slice|each:alphaDash",
+	"items.*.sku": "required|regexp:^[A-Z]{3}-\\d+$",
+	"ratio":       "float|between:0,100",
+	"attrs":       "map|keys:alphaDash",
+}
+
+// the validators to check the args coercion by the FuzzArgs
+var argValidators = []string{
+	"min", "max", "gt", "lt", "between", "len", "minLen", "maxLen",
+	"in", "notIn", "regexp", "isInt", "startsWith", "date", "afterDate",
+	"minDuration", "maxDuration", "archiveMaxSize", "token", "keys",
+}
+
+// FuzzRule fuzz the rule string parser. the data is the rule string. eg: "required|minLen:3"
+func FuzzRule(data []byte) int {
+	v := validate.Map(validate.M{"field": "some value", "num": 23, "list": []string{"a", "b"}})
+	v.StopOnError = false
+	if err := v.TryStringRule("field", string(data)); err != nil {
+		return 0
+	}
+
+	if _, err := v.TryValidate(); err != nil {
+		return 0
+	}
+	return 1
+}
+
+// FuzzJSON fuzz the JSON data builder, and validate the built data.
+func FuzzJSON(data []byte) int {
+	d, err := validate.FromJSONBytes(data)
+	if err != nil {
+		return 0
+	}
+	return validateData(validate.NewValidation(d))
+}
+
+// FuzzForm fuzz the form data builder, the data is the URL encoded query. eg: "name=inhere&tags=a&tags=b"
+func FuzzForm(data []byte) int {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return 0
+	}
+	return validateData(validate.NewValidation(validate.FromURLValues(values)))
+}
+
+// FuzzArgs fuzz the args coercion of the validators. the data is the value and the args separated by "\x00",
+// the first byte choose the validator. eg: "\x00abc\x00min:3"
+func FuzzArgs(data []byte) int {
+	if len(data) == 0 {
+		return 0
+	}
+
+	name := argValidators[int(data[0])%len(argValidators)]
+	val, args := data[1:], []byte(nil)
+	if pos := bytes.IndexByte(val, 0); pos >= 0 {
+		val, args = val[:pos], val[pos+1:]
+	}
+
+	rule := name
+	if len(args) > 0 {
+		rule += ":" + string(args)
+	}
+
+	v := validate.Map(validate.M{"field": string(val)})
+	if err := v.TryStringRule("field", rule); err != nil {
+		return 0
+	}
+
+	if _, err := v.TryValidate(); err != nil {
+		return 0
+	}
+	return 1
+}
+
+func validateData(v *validate.Validation) int {
+	v.StopOnError = false
+	if err := v.TryStringRules(dataRules); err != nil {
+		panic(err) // the dataRules must be valid
+	}
+
+	if _, err := v.TryValidate(); err != nil {
+		return 0
+	}
+	return 1
+}
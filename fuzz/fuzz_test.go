@@ -0,0 +1,96 @@
+package fuzz
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var seeds = map[string]map[string]int{
+	"FuzzRule": {
+		"required|minLen:3":    1,
+		"regexp:^\\w+$":        1,
+		"each:minLen:1":        1,
+		"min:abc":              0,
+		"regexp:[a-":           0,
+		"between:,":            0,
+		"notExists":            0,
+		"minLen:":              0,
+		"\xff\xfe|\u202e:\x00": 0,
+		"|||::,,":              0,
+		"||required||":         1,
+	},
+	"FuzzJSON": {
+		`{"name": "inhere", "age": 20, "items": [{"sku": "ABC-12"}], "attrs": {"k": 1}}`: 1,
+		`{"name": [], "age": "abc", "tags": {"a": 1}, "items": "x", "ratio": "NaN"}`:     1,
+		`{"ratio": 1e400}`: 0,
+		`{"name": `:        0,
+		`[1, 2]`:           0,
+		`null`:             1,
+	},
+	"FuzzForm": {
+		"name=inhere&age=20&tags=a&tags=b": 1,
+		"items.0.sku=x&ratio=NaN&age=-1":   1,
+		"name=%zz":                         0,
+	},
+	"FuzzArgs": {
+		"\x00abc\x00abc": 0,
+		"\x00abc\x003":   1,
+		"\x0a12\x0099":   1,
+		"\x10abc\x00xx":  1,
+		"\x105m\x00xx":   0,
+		"\x11abc\x00xx":  0,
+		"\x12abc\x00":    0,
+		"":               0,
+	},
+}
+
+var targets = map[string]func([]byte) int{
+	"FuzzRule": FuzzRule,
+	"FuzzJSON": FuzzJSON,
+	"FuzzForm": FuzzForm,
+	"FuzzArgs": FuzzArgs,
+}
+
+func TestSeeds(t *testing.T) {
+	is := assert.New(t)
+
+	for name, fn := range targets {
+		for input, want := range seeds[name] {
+			is.Equal(want, fn([]byte(input)), "%s(%q)", name, input)
+		}
+	}
+}
+
+// run the targets with the randomly mutated seeds, no panic is expected.
+func TestMutatedSeeds(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	alphabet := []byte("|:,.*\\[](){}^$\"'-_ 0123456789azAZ\x00\xff\xe2\x80\xae")
+
+	for name, fn := range targets {
+		for input := range seeds[name] {
+			for i := 0; i < 200; i++ {
+				bs := []byte(input)
+				for n := rnd.Intn(4) + 1; n > 0; n-- {
+					c := alphabet[rnd.Intn(len(alphabet))]
+					if len(bs) > 0 && rnd.Intn(2) == 0 {
+						bs[rnd.Intn(len(bs))] = c
+					} else {
+						pos := rnd.Intn(len(bs) + 1)
+						bs = append(bs[:pos], append([]byte{c}, bs[pos:]...)...)
+					}
+				}
+
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							t.Fatalf("%s(%q) panic: %v", name, bs, r)
+						}
+					}()
+					fn(bs)
+				}()
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// the geohash base32 alphabet
+const geohashChars = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// LatLng the coordinate value. it is the value of the filter "latLng"
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Valid check the latitude and longitude are in the range
+func (ll LatLng) Valid() bool {
+	return ll.Lat >= -90 && ll.Lat <= 90 && ll.Lng >= -180 && ll.Lng <= 180
+}
+
+// ParseLatLng parse the combined coordinate string. eg: "30.66,104.06", "30.66, 104.06"
+func ParseLatLng(s string) (ll LatLng, err error) {
+	nodes := strings.Split(s, ",")
+	if len(nodes) != 2 {
+		return ll, fmt.Errorf("invalid coordinate string '%s'", s)
+	}
+
+	if ll.Lat, err = strconv.ParseFloat(strings.TrimSpace(nodes[0]), 64); err != nil {
+		return ll, fmt.Errorf("invalid latitude of the coordinate '%s'", s)
+	}
+
+	if ll.Lng, err = strconv.ParseFloat(strings.TrimSpace(nodes[1]), 64); err != nil {
+		return ll, fmt.Errorf("invalid longitude of the coordinate '%s'", s)
+	}
+
+	if !ll.Valid() {
+		return ll, fmt.Errorf("the coordinate '%s' is out of range", s)
+	}
+	return
+}
+
+// IsLatLngString check the value is combined coordinate string "lat,lng".
+// also allow the LatLng value, it is converted by the filter "latLng".
+func IsLatLngString(val interface{}) bool {
+	switch tv := val.(type) {
+	case string:
+		_, err := ParseLatLng(tv)
+		return err == nil
+	case LatLng:
+		return tv.Valid()
+	case *LatLng:
+		return tv != nil && tv.Valid()
+	}
+	return false
+}
+
+// IsGeohash check the string is geohash. the length is 1 - 12.
+func IsGeohash(s string) bool {
+	if s == "" || len(s) > 12 {
+		return false
+	}
+
+	s = strings.ToLower(s)
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(geohashChars, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsGeoJSON check the value is a valid GeoJSON geometry, Feature or FeatureCollection(RFC 7946).
+// the value can be the JSON string, bytes or the decoded map. check the coordinate arity,
+// the range of the longitude and latitude, the linear ring is closed ...
+func IsGeoJSON(val interface{}) bool {
+	return geoJSONType(val) != ""
+}
+
+// GeoJSONType check the value is a valid GeoJSON and the type is one of the given types.
+// Usage:
+// 	v.StringRule("area", "geoJSONType:Polygon,MultiPolygon")
+func GeoJSONType(val interface{}, types ...string) bool {
+	typ := geoJSONType(val)
+	return typ != "" && Enum(typ, types)
+}
+
+// get the type of the valid GeoJSON value, return empty string on invalid.
+func geoJSONType(val interface{}) string {
+	switch tv := val.(type) {
+	case string:
+		val = nil
+		if json.Unmarshal([]byte(tv), &val) != nil {
+			return ""
+		}
+	case []byte:
+		val = nil
+		if json.Unmarshal(tv, &val) != nil {
+			return ""
+		}
+	}
+
+	obj, ok := geoObject(val)
+	if !ok {
+		return ""
+	}
+
+	typ, _ := obj["type"].(string)
+	switch typ {
+	case "Feature":
+		ok = validGeoFeature(obj)
+	case "FeatureCollection":
+		var features []interface{}
+		if features, ok = geoArray(obj["features"]); ok {
+			for _, item := range features {
+				if feature, isObj := geoObject(item); !isObj || feature["type"] != "Feature" || !validGeoFeature(feature) {
+					return ""
+				}
+			}
+		}
+	default:
+		ok = validGeometry(obj)
+	}
+
+	if !ok {
+		return ""
+	}
+	return typ
+}
+
+func validGeoFeature(obj map[string]interface{}) bool {
+	if props, has := obj["properties"]; has && props != nil {
+		if _, ok := geoObject(props); !ok {
+			return false
+		}
+	}
+
+	// the unlocated feature's geometry is null
+	geometry := obj["geometry"]
+	if geometry == nil {
+		return true
+	}
+
+	gObj, ok := geoObject(geometry)
+	return ok && validGeometry(gObj)
+}
+
+func validGeometry(obj map[string]interface{}) bool {
+	typ, _ := obj["type"].(string)
+	if typ == "GeometryCollection" {
+		geometries, ok := geoArray(obj["geometries"])
+		if !ok {
+			return false
+		}
+
+		for _, item := range geometries {
+			if gObj, ok := geoObject(item); !ok || !validGeometry(gObj) {
+				return false
+			}
+		}
+		return true
+	}
+
+	coords := obj["coordinates"]
+	switch typ {
+	case "Point":
+		return validGeoPosition(coords)
+	case "MultiPoint":
+		return validGeoList(coords, 0, validGeoPosition)
+	case "LineString":
+		return validGeoLine(coords)
+	case "MultiLineString":
+		return validGeoList(coords, 0, validGeoLine)
+	case "Polygon":
+		return validGeoPolygon(coords)
+	case "MultiPolygon":
+		return validGeoList(coords, 0, validGeoPolygon)
+	}
+	return false
+}
+
+// check each element of the list, and the list length must be >= minLen
+func validGeoList(val interface{}, minLen int, fn func(interface{}) bool) bool {
+	items, ok := geoArray(val)
+	if !ok || len(items) < minLen {
+		return false
+	}
+
+	for _, item := range items {
+		if !fn(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// the position is [lng, lat] or [lng, lat, altitude]
+func validGeoPosition(val interface{}) bool {
+	_, ok := geoPosition(val)
+	return ok
+}
+
+func geoPosition(val interface{}) ([]float64, bool) {
+	nums, ok := geoArray(val)
+	if !ok || len(nums) < 2 || len(nums) > 3 {
+		return nil, false
+	}
+
+	ns := make([]float64, len(nums))
+	for i, num := range nums {
+		if k, err := basicKind(reflect.ValueOf(num)); err != nil || k == stringKind {
+			return nil, false
+		}
+
+		f64, err := valueToFloat64(num)
+		if err != nil {
+			return nil, false
+		}
+		ns[i] = f64
+	}
+
+	if ns[0] < -180 || ns[0] > 180 || ns[1] < -90 || ns[1] > 90 {
+		return nil, false
+	}
+	return ns, true
+}
+
+func validGeoLine(val interface{}) bool {
+	return validGeoList(val, 2, validGeoPosition)
+}
+
+// each linear ring has 4 positions at least, and the first and last positions are same.
+func validGeoPolygon(val interface{}) bool {
+	return validGeoList(val, 1, func(ring interface{}) bool {
+		if !validGeoList(ring, 4, validGeoPosition) {
+			return false
+		}
+
+		positions, _ := geoArray(ring)
+		first, _ := geoPosition(positions[0])
+		last, _ := geoPosition(positions[len(positions)-1])
+		return reflect.DeepEqual(first, last)
+	})
+}
+
+func geoObject(val interface{}) (map[string]interface{}, bool) {
+	switch tv := val.(type) {
+	case map[string]interface{}:
+		return tv, true
+	case M:
+		return tv, true
+	}
+	return nil, false
+}
+
+// convert the slice/array value to []interface{}. eg: []float64{1, 2}
+func geoArray(val interface{}) ([]interface{}, bool) {
+	if items, ok := val.([]interface{}); ok {
+		return items, true
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// the filter "latLng", split the combined coordinate string to the LatLng.
+func filterLatLng(val interface{}) (interface{}, error) {
+	switch tv := val.(type) {
+	case string:
+		return ParseLatLng(tv)
+	case LatLng:
+		return tv, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to the coordinate", val)
+}
@@ -0,0 +1,121 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLatLng(t *testing.T) {
+	is := assert.New(t)
+
+	ll, err := ParseLatLng("30.66, 104.06")
+	is.NoError(err)
+	is.Equal(LatLng{Lat: 30.66, Lng: 104.06}, ll)
+
+	_, err = ParseLatLng("30.66")
+	is.Error(err)
+	_, err = ParseLatLng("abc,104.06")
+	is.Error(err)
+	_, err = ParseLatLng("30.66,abc")
+	is.Error(err)
+	_, err = ParseLatLng("91,104.06")
+	is.Error(err)
+}
+
+func TestIsLatLngString(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsLatLngString("30.66,104.06"))
+	is.True(IsLatLngString("-90,180"))
+	is.True(IsLatLngString(LatLng{Lat: 1, Lng: 2}))
+	is.True(IsLatLngString(&LatLng{Lat: 1, Lng: 2}))
+	is.False(IsLatLngString("30.66,181"))
+	is.False(IsLatLngString("30.66"))
+	is.False(IsLatLngString(LatLng{Lat: 91}))
+	is.False(IsLatLngString(23))
+}
+
+func TestIsGeohash(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsGeohash("wm3yr31"))
+	is.True(IsGeohash("U4PRUYDQQVJ"))
+	is.False(IsGeohash("wm3yra1"))
+	is.False(IsGeohash("wm3yr31wm3yr3"))
+	is.False(IsGeohash(""))
+}
+
+func TestFilter_latLng(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"loc": "30.66, 104.06", "hash": "wm3yr31"})
+	v.FilterRule("loc", "latLng")
+	v.StringRules(MS{
+		"loc":  "required|latLngString",
+		"hash": "required|geohash",
+	})
+	is.True(v.Validate())
+	is.Equal(LatLng{Lat: 30.66, Lng: 104.06}, v.SafeVal("loc"))
+
+	v = Map(M{"loc": "30.66,190"})
+	v.StringRule("loc", "required|lat_lng_string")
+	is.False(v.Validate())
+	is.Equal(`loc value must be a valid coordinate string "lat,lng"`, v.Errors.One())
+
+	v = Map(M{"loc": "30.66,190"})
+	v.FilterRule("loc", "latLng")
+	v.StringRule("loc", "required")
+	is.False(v.Validate())
+	is.Equal("the coordinate '30.66,190' is out of range", v.Errors.One())
+}
+
+func TestIsGeoJSON(t *testing.T) {
+	is := assert.New(t)
+
+	valid := []interface{}{
+		`{"type": "Point", "coordinates": [104.06, 30.66]}`,
+		[]byte(`{"type": "Point", "coordinates": [104.06, 30.66, 500]}`),
+		`{"type": "LineString", "coordinates": [[100, 0], [101, 1]]}`,
+		`{"type": "Polygon", "coordinates": [[[100, 0], [101, 0], [101, 1], [100, 1], [100, 0]]]}`,
+		`{"type": "MultiPoint", "coordinates": []}`,
+		`{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2]}]}`,
+		`{"type": "Feature", "geometry": null, "properties": {"name": "a"}}`,
+		`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": null}]}`,
+		M{"type": "MultiPolygon", "coordinates": [][][][]float64{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}},
+		map[string]interface{}{"type": "Point", "coordinates": []int{1, 2}},
+	}
+	for _, val := range valid {
+		is.True(IsGeoJSON(val), "%v", val)
+	}
+
+	invalid := []interface{}{
+		nil, 123, "", "not json",
+		`{"type": "Point", "coordinates": [104.06]}`,
+		`{"type": "Point", "coordinates": [200, 30]}`,
+		`{"type": "Point", "coordinates": ["104", "30"]}`,
+		`{"type": "LineString", "coordinates": [[100, 0]]}`,
+		`{"type": "Polygon", "coordinates": [[[100, 0], [101, 0], [101, 1], [100, 1]]]}`,
+		`{"type": "Polygon", "coordinates": [[[100, 0], [101, 0], [100, 0]]]}`,
+		`{"type": "Circle", "coordinates": [1, 2]}`,
+		`{"type": "Feature", "geometry": {"type": "Point"}}`,
+		`{"type": "Feature", "geometry": null, "properties": "abc"}`,
+		`{"type": "FeatureCollection", "features": [{"type": "Point", "coordinates": [1, 2]}]}`,
+	}
+	for _, val := range invalid {
+		is.False(IsGeoJSON(val), "%v", val)
+	}
+
+	is.True(GeoJSONType(`{"type": "Point", "coordinates": [1, 2]}`, "Point", "MultiPoint"))
+	is.False(GeoJSONType(`{"type": "Point", "coordinates": [1, 2]}`, "Polygon"))
+
+	v := Map(M{
+		"area":  M{"type": "Point", "coordinates": []float64{1, 2}},
+		"route": `{"type": "LineString", "coordinates": [[100, 0], [101, 1]]}`,
+	})
+	v.StopOnError = false
+	v.StringRules(MS{"area": "required|geoJSONType:Polygon,MultiPolygon", "route": "geo_json"})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("area value must be a valid GeoJSON of the types [Polygon MultiPolygon]", v.Errors.One())
+}
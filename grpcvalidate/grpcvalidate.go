@@ -0,0 +1,119 @@
+// Package grpcvalidate provide the gRPC server interceptors for validate the request messages.
+//
+// Usage:
+// 	s := grpc.NewServer(
+// 		grpc.UnaryInterceptor(grpcvalidate.UnaryServerInterceptor()),
+// 		grpc.StreamInterceptor(grpcvalidate.StreamServerInterceptor()),
+// 	)
+package grpcvalidate
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/gookit/validate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// registered rules for the message types
+var (
+	schemaLock sync.RWMutex
+	schemas    = make(map[reflect.Type]validate.MS)
+)
+
+// Register the validate rules for the message type.
+// the registered rules will be merged with the rules in the struct tags.
+//
+// Usage:
+// 	grpcvalidate.Register(&pb.CreateUserRequest{}, validate.MS{
+// 		"Name":  "required|minLen:3",
+// 		"Email": "required|email",
+// 	})
+func Register(msg interface{}, rules validate.MS) {
+	schemaLock.Lock()
+	schemas[reflect.TypeOf(msg)] = rules
+	schemaLock.Unlock()
+}
+
+func schemaOf(msg interface{}) (validate.MS, bool) {
+	schemaLock.RLock()
+	rules, ok := schemas[reflect.TypeOf(msg)]
+	schemaLock.RUnlock()
+	return rules, ok
+}
+
+// Validate the message, return an gRPC status error with code InvalidArgument on fail.
+// will return nil on the message is not an struct.
+func Validate(msg interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(msg))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	v := validate.Struct(msg)
+	if rules, ok := schemaOf(msg); ok {
+		v.StringRules(rules)
+	}
+
+	if v.Validate() {
+		return nil
+	}
+	return toStatusError(v.Errors)
+}
+
+func toStatusError(es validate.Errors) error {
+	fields := make([]string, 0, len(es))
+	for field := range es {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	br := &errdetails.BadRequest{}
+	for _, field := range fields {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: es.FieldOne(field),
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, es.One())
+	if dst, err := st.WithDetails(br); err == nil {
+		st = dst
+	}
+	return st.Err()
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that validates incoming messages.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := Validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a new streaming server interceptor that validates incoming messages.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &recvWrapper{ss})
+	}
+}
+
+// recvWrapper wrap the grpc.ServerStream, validate the message on receive.
+type recvWrapper struct {
+	grpc.ServerStream
+}
+
+// RecvMsg receive and validate the message
+func (s *recvWrapper) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return Validate(m)
+}
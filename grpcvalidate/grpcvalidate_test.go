@@ -0,0 +1,104 @@
+package grpcvalidate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// like an proto-generated message
+type createUserRequest struct {
+	state int
+
+	Name  string `validate:"required|minLen:3"`
+	Email string
+	Age   int
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	msg *createUserRequest
+}
+
+func (s *fakeStream) RecvMsg(m interface{}) error {
+	*m.(*createUserRequest) = *s.msg
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	is := assert.New(t)
+
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return "ok", nil
+	}
+
+	ic := UnaryServerInterceptor()
+	ret, err := ic(context.Background(), &createUserRequest{Name: "inhere"}, nil, handler)
+	is.NoError(err)
+	is.Equal("ok", ret)
+	is.True(called)
+
+	called = false
+	_, err = ic(context.Background(), &createUserRequest{Name: "in"}, nil, handler)
+	is.Error(err)
+	is.False(called)
+
+	st, _ := status.FromError(err)
+	is.Equal(codes.InvalidArgument, st.Code())
+	is.Equal("Name min length is 3", st.Message())
+	is.Len(st.Details(), 1)
+
+	br := st.Details()[0].(*errdetails.BadRequest)
+	is.Equal("Name", br.FieldViolations[0].Field)
+
+	// not struct
+	_, err = ic(context.Background(), "string", nil, handler)
+	is.NoError(err)
+}
+
+func TestRegister(t *testing.T) {
+	is := assert.New(t)
+
+	Register(&createUserRequest{}, validate.MS{
+		"Email": "required|email",
+		"Age":   "required|min:1",
+	})
+	defer func() {
+		schemaLock.Lock()
+		delete(schemas, reflect.TypeOf(&createUserRequest{}))
+		schemaLock.Unlock()
+	}()
+
+	err := Validate(&createUserRequest{Name: "inhere", Email: "some@e.com", Age: 2})
+	is.NoError(err)
+
+	err = Validate(&createUserRequest{Name: "inhere", Email: "invalid"})
+	st, _ := status.FromError(err)
+	is.Equal(codes.InvalidArgument, st.Code())
+	is.Len(st.Details()[0].(*errdetails.BadRequest).FieldViolations, 1)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	is := assert.New(t)
+
+	ic := StreamServerInterceptor()
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		m := &createUserRequest{}
+		return ss.RecvMsg(m)
+	}
+
+	err := ic(nil, &fakeStream{msg: &createUserRequest{Name: "inhere"}}, nil, handler)
+	is.NoError(err)
+
+	err = ic(nil, &fakeStream{msg: &createUserRequest{Name: "in"}}, nil, handler)
+	is.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -1,13 +1,16 @@
 package validate
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/gookit/filter"
 	"github.com/gookit/goutil/mathutil"
@@ -50,6 +53,79 @@ func stringSplit(str, sep string) (ss []string) {
 	return
 }
 
+// check the field has wildcard. eg: "items.*.sku"
+func isWildcardField(field string) bool {
+	return wildcardIndex(strings.Split(field, ".")) >= 0
+}
+
+// get the position of the wildcard key. return -1 on not found
+func wildcardIndex(keys []string) int {
+	for i, key := range keys {
+		if key == "*" {
+			return i
+		}
+	}
+	return -1
+}
+
+// get the matched keys of the wildcard in the field.
+// eg: pattern "items.*.sku", field "items.2.sku" => "2"
+func wildcardKeys(pattern, field string) string {
+	var ss []string
+	fKeys := strings.Split(field, ".")
+	for i, key := range strings.Split(pattern, ".") {
+		if key == "*" && i < len(fKeys) {
+			ss = append(ss, fKeys[i])
+		}
+	}
+	return strings.Join(ss, ".")
+}
+
+// getByPath get value by key path from the map, support get the slice element by index.
+// eg: "top.sub", "items.0.name"
+func getByPath(path string, mp map[string]interface{}) (interface{}, bool) {
+	if val, ok := mp[path]; ok {
+		return val, true
+	}
+
+	// has sub key? eg. "top.sub"
+	if !strings.ContainsRune(path, '.') {
+		return nil, false
+	}
+
+	var item interface{} = mp
+	for _, key := range strings.Split(path, ".") {
+		rv, isNil := indirect(reflect.ValueOf(item))
+		if isNil {
+			return nil, false
+		}
+
+		switch rv.Kind() {
+		case reflect.Map:
+			kt := rv.Type().Key()
+			kv := reflect.ValueOf(key)
+			if !kv.Type().ConvertibleTo(kt) {
+				return nil, false
+			}
+
+			mv := rv.MapIndex(kv.Convert(kt))
+			if !mv.IsValid() {
+				return nil, false
+			}
+			item = mv.Interface()
+		case reflect.Slice, reflect.Array:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= rv.Len() {
+				return nil, false
+			}
+			item = rv.Index(idx).Interface()
+		default:
+			return nil, false
+		}
+	}
+	return item, true
+}
+
 func strings2Args(strings []string) []interface{} {
 	args := make([]interface{}, len(strings))
 	for i, s := range strings {
@@ -94,6 +170,11 @@ func ValueIsEmpty(v reflect.Value) bool {
 		return v.Float() == 0
 	case reflect.Interface, reflect.Ptr:
 		return v.IsNil()
+	case reflect.Struct:
+		// the invalid sql.Null* value. eg: sql.NullString{Valid: false}
+		if isSQLNullType(v.Type()) {
+			return !v.FieldByName("Valid").Bool()
+		}
 	}
 
 	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
@@ -120,7 +201,8 @@ func ValueLen(v reflect.Value) int {
 }
 
 var (
-	errConvertFail = errors.New("convert value is failure")
+	errConvertFail     = errors.New("convert value is failure")
+	errUnsupportedType = errors.New("unsupported type")
 )
 
 func valueToInt64(v interface{}, strict bool) (i64 int64, err error) {
@@ -166,19 +248,67 @@ func valueToInt64(v interface{}, strict bool) (i64 int64, err error) {
 	return
 }
 
-func valueToInt64ByReflect(v interface{}) (i64 int64, err error) {
-	refVal := reflect.ValueOf(v)
-	switch refVal.Type().Kind() {
-	case reflect.String,
-		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-		reflect.Float32, reflect.Float64:
-		i64, err = strconv.ParseInt(filter.Trim(fmt.Sprintf("%v", v)), 10, 0)
-	default:
-		err = errConvertFail
+// convert int(X), uint(X), float(X), numeric string value to float64.
+func valueToFloat64(v interface{}) (float64, error) {
+	rv := reflect.ValueOf(v)
+	k, err := basicKind(rv)
+	if err != nil {
+		return 0, errConvertFail
 	}
 
-	return
+	_, f64, _, err := kindToNumber(rv, k)
+	return f64, err
+}
+
+// floatEpsilon the tolerance for compare float values.
+const floatEpsilon = 1e-9
+
+func floatEqual(a, b float64) bool {
+	return math.Abs(a-b) <= floatEpsilon*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// clean the number string by the global options. eg: "+1_000" => "1000", "007" => "7"
+// the options only relax the format, so the validators check the cleaned string as before.
+// return false on the underscores are not between the digits. see GlobalOption.NumberPlusSign ...
+func cleanNumberString(s string) (string, bool) {
+	var sign string
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		sign, s = s[:1], s[1:]
+		// remove the allowed plus sign. eg: "+12" => "12"
+		if sign == "+" && globalOpt.NumberPlusSign && s != "" && (isDigitByte(s[0]) || s[0] == '.') {
+			sign = ""
+		}
+	}
+
+	if globalOpt.NumberUnderscores && strings.IndexByte(s, '_') >= 0 {
+		// the underscore must be between the digits
+		for i := 0; i < len(s); i++ {
+			if s[i] == '_' && (i == 0 || i == len(s)-1 || !isDigitByte(s[i-1]) || !isDigitByte(s[i+1])) {
+				return "", false
+			}
+		}
+		s = strings.Replace(s, "_", "", -1)
+	}
+
+	if globalOpt.NumberLeadingZeros {
+		for len(s) > 1 && s[0] == '0' && isDigitByte(s[1]) {
+			s = s[1:]
+		}
+	}
+	return sign + s, true
+}
+
+// parse the int string by the number options. see GlobalOption.NumberIntString
+func parseIntString(s string) (int64, error) {
+	str, ok := cleanNumberString(s)
+	if !ok || str == "" || !rxInt.MatchString(str) {
+		return 0, errConvertFail
+	}
+	return strconv.ParseInt(str, 10, 64)
+}
+
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
 }
 
 // CalcLength for input value
@@ -194,6 +324,38 @@ func CalcLength(val interface{}) int {
 	return ValueLen(reflect.ValueOf(val))
 }
 
+// calc the length for the length validators. the string length is the runes count on the GlobalOption.CountRunes
+func validateLength(val interface{}) int {
+	if str, ok := val.(string); ok && globalOpt.CountRunes {
+		return utf8.RuneCountInString(str)
+	}
+	return CalcLength(val)
+}
+
+// convert the json.Number integer to the int64 or uint64, the decimal is returned as it is.
+func jsonNumberValue(n json.Number) interface{} {
+	if i64, err := n.Int64(); err == nil {
+		return i64
+	}
+	if u64, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u64
+	}
+	return n
+}
+
+// convert the value to int64 for the number compare validators. the decimal is truncated, same as the float64
+func toInt64(val interface{}) (int64, error) {
+	if n, ok := val.(json.Number); ok {
+		if i64, err := n.Int64(); err == nil {
+			return i64, nil
+		}
+
+		f64, err := n.Float64()
+		return int64(f64), err
+	}
+	return mathutil.Int64(val)
+}
+
 // value compare. use for compare int, string.
 func valueCompare(srcVal, dstVal interface{}, op string) (ok bool) {
 	var err error
@@ -237,7 +399,8 @@ func valueCompare(srcVal, dstVal interface{}, op string) (ok bool) {
 // 	return runtime.FuncForPC(fv.Pointer()).Name()
 // }
 
-func parseArgString(argStr string) (ss []string) {
+// parse the args string. the sep default is ","
+func parseArgString(argStr string, sep ...string) (ss []string) {
 	if argStr == "" { // no arg
 		return
 	}
@@ -246,26 +409,10 @@ func parseArgString(argStr string) (ss []string) {
 		return []string{argStr}
 	}
 
-	return stringSplit(argStr, ",")
-}
-
-func toInt64Slice(enum interface{}) (ret []int64, ok bool) {
-	rv := reflect.ValueOf(enum)
-	if rv.Kind() != reflect.Slice {
-		return
-	}
-
-	for i := 0; i < rv.Len(); i++ {
-		i64, err := filter.Int64(rv.Index(i).Interface())
-		if err != nil {
-			return []int64{}, false
-		}
-
-		ret = append(ret, i64)
+	if len(sep) > 0 && sep[0] != "" {
+		return stringSplit(argStr, sep[0])
 	}
-
-	ok = true
-	return
+	return stringSplit(argStr, argSep)
 }
 
 func getSliceItemKind(typString string) reflect.Kind {
@@ -292,10 +439,23 @@ func convertType(srcVal interface{}, srcKind kind, dstType reflect.Kind) (interf
 	switch srcKind {
 	case stringKind:
 		switch dstType {
+		// NOTICE: return nil on the invalid number string. eg: "abc", "99999999999999999999"
 		case reflect.Int:
-			return mathutil.Int(srcVal)
+			i, err := mathutil.Int(srcVal)
+			if err != nil {
+				return nil, err
+			}
+			return i, nil
 		case reflect.Int64:
-			return mathutil.Int64(srcVal)
+			i64, err := mathutil.Int64(srcVal)
+			if err != nil {
+				return nil, err
+			}
+			return i64, nil
+		case reflect.Float64:
+			if f64, err := valueToFloat64(srcVal); err == nil {
+				return f64, nil
+			}
 		}
 	case intKind, uintKind:
 		i64 := filter.MustInt64(srcVal)
@@ -315,6 +475,35 @@ func convertType(srcVal interface{}, srcKind kind, dstType reflect.Kind) (interf
 	return nil, nil
 }
 
+// parse the string to the value of the basic type. eg: "12" => int(12), "5m" => time.Duration
+func parseStringAs(str string, typ reflect.Type) (interface{}, error) {
+	var err error
+	var val interface{}
+	switch typ.Kind() {
+	case reflect.String, reflect.Interface:
+		val = str
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if typ == durationType {
+			val, err = time.ParseDuration(str)
+		} else {
+			val, err = strconv.ParseInt(str, 10, 64)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val, err = strconv.ParseUint(str, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		val, err = strconv.ParseFloat(str, 64)
+	case reflect.Bool:
+		val, err = strconv.ParseBool(str)
+	default:
+		err = errUnsupportedType
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(val).Convert(typ).Interface(), nil
+}
+
 func panicf(format string, args ...interface{}) {
 	panic("validate: " + fmt.Sprintf(format, args...))
 }
@@ -519,6 +708,102 @@ func eq(arg1 reflect.Value, arg2 reflect.Value) (bool, error) {
 	return truth, nil
 }
 
+// enumItemEqual compare the value and enum item with type awareness.
+func enumItemEqual(val, item interface{}, ignoreCase bool) bool {
+	vv := indirectInterface(reflect.ValueOf(val))
+	vk, err := basicKind(vv)
+	if err != nil {
+		return false
+	}
+
+	iv := indirectInterface(reflect.ValueOf(item))
+	ik, err := basicKind(iv)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case vk == stringKind && ik == stringKind:
+		if ignoreCase {
+			return strings.EqualFold(vv.String(), iv.String())
+		}
+		return vv.String() == iv.String()
+	case vk == boolKind || ik == boolKind:
+		vb, err := kindToBool(vv, vk)
+		if err != nil {
+			return false
+		}
+
+		ib, err := kindToBool(iv, ik)
+		return err == nil && vb == ib
+	case vk == complexKind || ik == complexKind:
+		ok, _ := eq(vv, iv)
+		return ok
+	}
+
+	// compare as number
+	vi, vf, vIsInt, err := kindToNumber(vv, vk)
+	if err != nil {
+		return false
+	}
+
+	ii, iF, iIsInt, err := kindToNumber(iv, ik)
+	if err != nil {
+		return false
+	}
+
+	if vIsInt && iIsInt {
+		return vi == ii
+	}
+	return vf == iF
+}
+
+// check the two values is same. the basic values will compare loosely. eg: "1" == 1
+func isSameValue(val, other interface{}) bool {
+	if val == nil || other == nil {
+		return val == other
+	}
+
+	return enumItemEqual(val, other, false) || IsEqual(val, other)
+}
+
+func kindToBool(rv reflect.Value, k kind) (bool, error) {
+	switch k {
+	case boolKind:
+		return rv.Bool(), nil
+	case stringKind:
+		return strutil.ToBool(rv.String())
+	}
+	return false, errConvertFail
+}
+
+// convert int(X), uint(X), float(X), string value to number.
+// isInt is true: use the i64, otherwise use the f64.
+func kindToNumber(rv reflect.Value, k kind) (i64 int64, f64 float64, isInt bool, err error) {
+	switch k {
+	case intKind:
+		i64 = rv.Int()
+		return i64, float64(i64), true, nil
+	case uintKind:
+		u64 := rv.Uint()
+		if u64 > math.MaxInt64 {
+			return 0, float64(u64), false, nil
+		}
+		return int64(u64), float64(u64), true, nil
+	case floatKind:
+		return 0, rv.Float(), false, nil
+	case stringKind:
+		str := strings.TrimSpace(rv.String())
+		if i64, err = strconv.ParseInt(str, 10, 64); err == nil {
+			return i64, float64(i64), true, nil
+		}
+
+		f64, err = strconv.ParseFloat(str, 64)
+		return
+	}
+	return 0, 0, false, errConvertFail
+}
+
 // from package: github.com/stretchr/testify/assert/assertions.go
 func includeElement(list, element interface{}) (ok, found bool) {
 	listValue := reflect.ValueOf(list)
@@ -0,0 +1,40 @@
+package validate
+
+import "strings"
+
+// the Crockford's base32 alphabet of the ULID, it excludes the letters I, L, O, U
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZabcdefghjkmnpqrstvwxyz"
+
+// the max KSUID string, the larger string is overflow the 20 bytes
+const maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+
+// IsULID check the value is an ULID string. eg: "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+// the letters are case-insensitive, the first char must be 0-7, the larger is overflow the 128 bits.
+func IsULID(s string) bool {
+	if len(s) != 26 || s[0] > '7' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(ulidAlphabet, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsKSUID check the value is a KSUID string. eg: "0ujtsYcgvSTl8PAuAdqWYSMnLOv"
+// it is 27 chars of the base62, and not larger than the max KSUID.
+func IsKSUID(s string) bool {
+	if len(s) != 27 || s > maxKSUID {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+	return true
+}
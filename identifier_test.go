@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsULID(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAV"))
+	is.True(IsULID("01arz3ndektsv4rrffq69g5fav"))
+	is.True(IsULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ"))
+	is.False(IsULID("8ZZZZZZZZZZZZZZZZZZZZZZZZZ"))
+	is.False(IsULID("01ARZ3NDEKTSV4RRFFQ69G5FA"))
+	is.False(IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAU"))
+	is.False(IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAI"))
+	is.False(IsULID(""))
+}
+
+func TestIsKSUID(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv"))
+	is.True(IsKSUID("000000000000000000000000000"))
+	is.True(IsKSUID(maxKSUID))
+	is.False(IsKSUID("aWgEPTl1tmebfsQzFP4bxwgy80W"))
+	is.False(IsKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLO"))
+	is.False(IsKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLO-"))
+	is.False(IsKSUID(""))
+}
+
+func TestValidation_identifiers(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{
+		"id":    "8098f6fb-1557-4633-b82b-40e1b26137bf",
+		"uid7":  "8098f6fb-1557-4633-b82b-40e1b26137bf",
+		"ulid":  "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"ksuid": "0ujtsYcgvSTl8PAuAdqWYSMnLOv",
+	})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"id":    "required|uuid:4",
+		"uid7":  "uuid:7",
+		"ulid":  "required|ulid",
+		"ksuid": "required|ksuid",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "uid7")
+}
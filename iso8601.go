@@ -0,0 +1,162 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the ISO 8601 duration. eg: "P1Y2M3DT4H5M6.5S", "P2W". only the seconds allow the fraction.
+var rxISODuration = mustCompilePattern(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// ISODuration the ISO 8601 duration value. the years and months are not fixed length,
+// so they are kept separately.
+type ISODuration struct {
+	Years, Months, Weeks, Days int
+	// Time the hours, minutes and seconds part
+	Time time.Duration
+}
+
+// ParseISODuration parse the ISO 8601 duration string. eg: "P1DT2H", "PT30M", "P1Y6M"
+func ParseISODuration(s string) (d ISODuration, err error) {
+	ms := rxISODuration.FindStringSubmatch(s)
+	// "P" and "P1DT" are invalid, must has one component at least.
+	if ms == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return d, fmt.Errorf("invalid ISO 8601 duration '%s'", s)
+	}
+
+	nums := make([]int, 6)
+	for i := range nums {
+		if ms[i+1] != "" {
+			if nums[i], err = strconv.Atoi(ms[i+1]); err != nil {
+				return d, fmt.Errorf("invalid ISO 8601 duration '%s'", s)
+			}
+		}
+	}
+
+	var secs float64
+	if ms[7] != "" {
+		secs, _ = strconv.ParseFloat(strings.Replace(ms[7], ",", ".", 1), 64)
+	}
+
+	d = ISODuration{Years: nums[0], Months: nums[1], Weeks: nums[2], Days: nums[3]}
+	d.Time = time.Duration(nums[4])*time.Hour + time.Duration(nums[5])*time.Minute + time.Duration(secs*float64(time.Second))
+	return
+}
+
+// Duration convert to the time.Duration, the day is 24 hours.
+// will return error on has the years or months, please use AddTo() instead.
+func (d ISODuration) Duration() (time.Duration, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, fmt.Errorf("the ISO 8601 duration has years or months cannot convert to time.Duration")
+	}
+	return time.Duration(d.Weeks*7+d.Days)*24*time.Hour + d.Time, nil
+}
+
+// AddTo add the duration to the time. eg: "P1M" is the same day of next month
+func (d ISODuration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Weeks*7+d.Days).Add(d.Time)
+}
+
+// Interval the time interval, it is the value of the filter "isoInterval"
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// ParseISOInterval parse the ISO 8601 time interval string. allow formats:
+// 	"<start>/<end>"      eg: "2020-01-01T00:00:00Z/2020-02-01T00:00:00Z"
+// 	"<start>/<duration>" eg: "2020-01-01/P1M"
+// 	"<duration>/<end>"   eg: "P1D/2020-01-02"
+// the time without zone is in UTC. the end must not be before the start.
+func ParseISOInterval(s string) (iv Interval, err error) {
+	nodes := strings.Split(s, "/")
+	if len(nodes) != 2 || nodes[0] == "" || nodes[1] == "" {
+		return iv, fmt.Errorf("invalid ISO 8601 interval '%s'", s)
+	}
+
+	startIsDur, endIsDur := nodes[0][0] == 'P', nodes[1][0] == 'P'
+	switch {
+	case startIsDur && endIsDur:
+		return iv, fmt.Errorf("invalid ISO 8601 interval '%s'", s)
+	case endIsDur:
+		iv.Start, err = parseISOTime(nodes[0])
+		if err == nil {
+			var d ISODuration
+			if d, err = ParseISODuration(nodes[1]); err == nil {
+				iv.End = d.AddTo(iv.Start)
+			}
+		}
+	case startIsDur:
+		iv.End, err = parseISOTime(nodes[1])
+		if err == nil {
+			var d ISODuration
+			if d, err = ParseISODuration(nodes[0]); err == nil {
+				iv.Start = d.negate().AddTo(iv.End)
+			}
+		}
+	default:
+		if iv.Start, err = parseISOTime(nodes[0]); err == nil {
+			iv.End, err = parseISOTime(nodes[1])
+		}
+	}
+
+	if err != nil {
+		return iv, err
+	}
+
+	if iv.End.Before(iv.Start) {
+		return iv, fmt.Errorf("the end is before the start of the ISO 8601 interval '%s'", s)
+	}
+	return
+}
+
+func (d ISODuration) negate() ISODuration {
+	return ISODuration{Years: -d.Years, Months: -d.Months, Weeks: -d.Weeks, Days: -d.Days, Time: -d.Time}
+}
+
+func parseISOTime(s string) (time.Time, error) {
+	if t, ok := valueToTime(s, time.UTC); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid ISO 8601 time '%s'", s)
+}
+
+// IsISO8601Duration check the string is ISO 8601 duration. eg: "P1DT2H", "PT30M", "P2W"
+func IsISO8601Duration(s string) bool {
+	_, err := ParseISODuration(s)
+	return err == nil
+}
+
+// IsISO8601Interval check the string is ISO 8601 time interval. see ParseISOInterval()
+func IsISO8601Interval(s string) bool {
+	_, err := ParseISOInterval(s)
+	return err == nil
+}
+
+// the filter "isoDuration", convert the ISO 8601 duration string to the time.Duration.
+func filterISODuration(val interface{}) (interface{}, error) {
+	switch tv := val.(type) {
+	case string:
+		d, err := ParseISODuration(tv)
+		if err != nil {
+			return nil, err
+		}
+		return d.Duration()
+	case time.Duration:
+		return tv, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to the duration", val)
+}
+
+// the filter "isoInterval", convert the ISO 8601 interval string to the Interval.
+func filterISOInterval(val interface{}) (interface{}, error) {
+	switch tv := val.(type) {
+	case string:
+		return ParseISOInterval(tv)
+	case Interval:
+		return tv, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to the interval", val)
+}
@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseISODuration(t *testing.T) {
+	is := assert.New(t)
+
+	d, err := ParseISODuration("P1Y2M3DT4H5M6.5S")
+	is.NoError(err)
+	is.Equal(ISODuration{Years: 1, Months: 2, Days: 3, Time: 4*time.Hour + 5*time.Minute + 6500*time.Millisecond}, d)
+
+	d, err = ParseISODuration("P1DT2H")
+	is.NoError(err)
+	td, err := d.Duration()
+	is.NoError(err)
+	is.Equal(26*time.Hour, td)
+
+	d, _ = ParseISODuration("P2W")
+	td, _ = d.Duration()
+	is.Equal(14*24*time.Hour, td)
+
+	d, _ = ParseISODuration("P1M")
+	_, err = d.Duration()
+	is.Error(err)
+	start := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+	is.Equal(time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), d.AddTo(start))
+
+	for _, s := range []string{"PT30M", "PT0,5S", "P0D", "P1Y"} {
+		is.True(IsISO8601Duration(s), s)
+	}
+	for _, s := range []string{"", "P", "PT", "P1DT", "1D", "P1H", "PT1D", "P1.5D", "-P1D", "P1D2H"} {
+		is.False(IsISO8601Duration(s), s)
+	}
+}
+
+func TestParseISOInterval(t *testing.T) {
+	is := assert.New(t)
+
+	iv, err := ParseISOInterval("2020-01-01T00:00:00Z/2020-02-01T12:00:00Z")
+	is.NoError(err)
+	is.Equal(time.Date(2020, 2, 1, 12, 0, 0, 0, time.UTC), iv.End)
+
+	iv, err = ParseISOInterval("2020-01-01/P1M")
+	is.NoError(err)
+	is.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), iv.Start)
+	is.Equal(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), iv.End)
+
+	iv, err = ParseISOInterval("P1DT12H/2020-01-03")
+	is.NoError(err)
+	is.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), iv.Start)
+
+	for _, s := range []string{"", "2020-01-01", "P1D/P2D", "2020-01-02/2020-01-01", "2020-01-01/P", "abc/2020-01-01", "/P1D"} {
+		is.False(IsISO8601Interval(s), s)
+	}
+}
+
+func TestISO8601_validateAndFilter(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"trial": "P14D", "timeout": "PT1H30M", "period": "2020-01-01/P1Y", "bad": "1 day"})
+	v.StopOnError = false
+	v.FilterRules(MS{"timeout": "isoDuration", "period": "isoInterval"})
+	v.StringRules(MS{"trial": "iso8601Duration", "bad": "iso8601_duration", "period": "required"})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("bad value must be a valid ISO 8601 duration. eg: P1DT2H", v.Errors.One())
+	is.Equal(90*time.Minute, v.Filtered("timeout"))
+	is.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), v.Filtered("period").(Interval).End)
+
+	v = Map(M{"timeout": "P1M"})
+	v.FilterRule("timeout", "isoDuration")
+	is.False(v.Validate())
+	is.Contains(v.Errors, "_filter")
+}
@@ -7,10 +7,11 @@ var Locales = map[string]validate.MS{
 	"zh-CN": zhCN,
 }
 
-// Register language data to Validation
+// Register language data to Validation, and set the locale for the field labels.
 func Register(v *validate.Validation, name string) bool {
 	if data, ok := Locales[name]; ok {
 		v.AddMessages(data)
+		v.SetLocale(name)
 		return true
 	}
 
@@ -2,10 +2,13 @@ package validate
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const defaultErrMsg = " field did not pass validation"
@@ -112,19 +115,118 @@ func (es Errors) FieldOne(field string) string {
 	return ""
 }
 
+// ErrorDetail the structured error of a field and validator.
+// useful for the APIs to output the machine-readable errors.
+type ErrorDetail struct {
+	Field     string        `json:"field"`
+	Validator string        `json:"validator"`
+	Args      []interface{} `json:"args,omitempty"`
+	Message   string        `json:"message"`
+	// Code the stable error code, is the real validator name. eg: "minLen" => "minLength"
+	Code string `json:"code"`
+	// Value the submitted value, only set by the Validation.RenderErrors()
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Detailed get the structured errors, sorted by field and validator.
+//
+// NOTICE: the Args is not record in the Errors, use Validation.ErrorDetails() to get them.
+func (es Errors) Detailed() []ErrorDetail {
+	details := make([]ErrorDetail, 0, len(es))
+	for field, fe := range es {
+		for validator, msg := range fe {
+			details = append(details, ErrorDetail{
+				Field:     field,
+				Validator: validator,
+				Message:   msg,
+				Code:      ValidatorName(validator),
+			})
+		}
+	}
+
+	sort.Slice(details, func(i, j int) bool {
+		if details[i].Field != details[j].Field {
+			return details[i].Field < details[j].Field
+		}
+		return details[i].Validator < details[j].Validator
+	})
+	return details
+}
+
+// ErrorsShape the JSON shape of the Errors
+type ErrorsShape uint8
+
+// the JSON shapes of the Errors. see GlobalOption.ErrorsJSONShape
+const (
+	// ShapeNested field to validator messages. eg: {"name": {"required": "message"}}
+	ShapeNested ErrorsShape = iota
+	// ShapeFirst field to the first message. eg: {"name": "message"}
+	ShapeFirst
+	// ShapeList field to the message list. eg: {"name": ["message", "message1"]}
+	ShapeList
+	// ShapeFlat flat array of the field messages. eg: [{"field": "name", "message": "message"}]
+	ShapeFlat
+)
+
+// FieldMessage the item of the ShapeFlat
+type FieldMessage struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Shape convert the errors to the data of the shape, the messages of a field are sorted by the validator name.
+func (es Errors) Shape(shape ErrorsShape) interface{} {
+	switch shape {
+	case ShapeFirst:
+		mp := make(map[string]string, len(es))
+		for _, d := range es.Detailed() {
+			if _, ok := mp[d.Field]; !ok {
+				mp[d.Field] = d.Message
+			}
+		}
+		return mp
+	case ShapeList:
+		mp := make(map[string][]string, len(es))
+		for _, d := range es.Detailed() {
+			mp[d.Field] = append(mp[d.Field], d.Message)
+		}
+		return mp
+	case ShapeFlat:
+		details := es.Detailed()
+		list := make([]FieldMessage, 0, len(details))
+		for _, d := range details {
+			list = append(list, FieldMessage{Field: d.Field, Message: d.Message})
+		}
+		return list
+	}
+	return map[string]fieldErrors(es)
+}
+
+// MarshalJSON marshal the errors by the GlobalOption.ErrorsJSONShape
+func (es Errors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(es.Shape(globalOpt.ErrorsJSONShape))
+}
+
 /*************************************************************
  * Validator error messages
  *************************************************************/
 
+// guard the builtinMessages and the fieldLabels, they can be changed on the runtime.
+var messagesMux sync.RWMutex
+
 // default internal error messages for all validators.
 var builtinMessages = map[string]string{
 	"_": "{field}" + defaultErrMsg, // default message
 	// builtin
 	"_validate": "{field} did not pass validate", // default validate message
 	"_filter":   "{field} data is invalid",       // data filter error
+	// aggregated errors of the wildcard field elements
+	"_aggregate": "%d elements failed %s (indexes %s)",
 	// int value
 	"min": "{field} min value is %d",
 	"max": "{field} max value is %d",
+	"gt":  "{field} value must be greater than %d",
+	"lt":  "{field} value must be less than %d",
 	// type check: int
 	"isInt":  "{field} value must be an integer",
 	"isInt1": "{field} value must be an integer and mix value is %d",      // has min check
@@ -141,15 +243,79 @@ var builtinMessages = map[string]string{
 	"stringLength":  "{field} length must be in the range %d - %d",
 	"stringLength1": "{field} min length is %d",
 	"stringLength2": "{field} length must be in the range %d - %d",
+	// string byte length
+	"fitsVarchar": "{field} value is too long, the max size is %d bytes",
+	// words count
+	"minWords": "{field} must contain at least %d words",
+	"maxWords": "{field} cannot contain more than %d words",
 
 	"isURL":     "{field} must be an valid URL address",
 	"isFullURL": "{field} must be an valid full URL address",
 
 	"isFile":  "{field} must be an uploaded file",
 	"isImage": "{field} must be an uploaded image file",
-
-	"enum":  "{field} value must be in the enum %v",
-	"range": "{field} value must be in the range %d - %d",
+	// archive file
+	"archiveMaxEntries": "{field} archive entries cannot exceed {args0}",
+	"archiveMaxSize":    "{field} archive uncompressed size cannot exceed {args0}",
+	"archiveExts":       "{field} archive entries must be the types {values}",
+
+	"enum":           "{field} value must be in the enum %v",
+	"enumIgnoreCase": "{field} value must be in the enum %v(ignore case)",
+	"range":          "{field} value must be in the range %d - %d",
+	"betweenX":       "{field} value must be greater than %d and less than %d",
+	// number range
+	"isPercent":     "{field} value must be a percentage between 0 and 100",
+	"isProbability": "{field} value must be a probability between 0 and 1",
+	"multipleOf":    "{field} value must be a multiple of %v",
+	// barcode
+	"isEAN8":   "{field} value must be a valid EAN-8 barcode",
+	"isEAN13":  "{field} value must be a valid EAN-13 barcode",
+	"isUPC":    "{field} value must be a valid UPC-A barcode",
+	"isGTIN14": "{field} value must be a valid GTIN-14 code",
+	// coordinate
+	"isLatLngString": "{field} value must be a valid coordinate string \"lat,lng\"",
+	"isGeohash":      "{field} value must be a valid geohash",
+	"isGeoJSON":      "{field} value must be a valid GeoJSON",
+	"geoJSONType":    "{field} value must be a valid GeoJSON of the types {values}",
+	// ISO 8601 duration and interval
+	"isISO8601Duration": "{field} value must be a valid ISO 8601 duration. eg: P1DT2H",
+	"isISO8601Interval": "{field} value must be a valid ISO 8601 time interval",
+	// duration
+	"isDuration":  "{field} value must be a valid duration. eg: 30s, 2h45m",
+	"minDuration": "{field} min duration is {args0}",
+	"maxDuration": "{field} max duration is {args0}",
+	// recurrence rule
+	"isRRule": "{field} value must be a valid recurrence rule(RRULE)",
+	// money amount
+	"moneyString": "{field} value must be a valid {args0} amount in the allowed range",
+	// file name
+	"isSafeFilename": "{field} value must be a safe file name",
+	// case format
+	"isCamelCase":  "{field} value must be in camelCase",
+	"isPascalCase": "{field} value must be in PascalCase",
+	"isSnakeCase":  "{field} value must be in snake_case",
+	"isKebabCase":  "{field} value must be in kebab-case",
+	// text content
+	"noEmoji":        "{field} value cannot contain emoji",
+	"noControlChars": "{field} value cannot contain control characters",
+	"singleLine":     "{field} value must be a single line",
+	// text encoding
+	"validUTF8":  "{field} value must be valid UTF-8 encoded",
+	"latin1Safe": "{field} value can only contain Latin-1 characters",
+	"isASCII":    "{field} value can only contain ASCII characters",
+	// token format
+	"token":                  "{field} value must be a valid token(prefix: %q, length: %v, charset: %v)",
+	"isCrockfordBase32":      "{field} value must be a valid Crockford's Base32 string",
+	"isCrockfordBase32Check": "{field} value must be a valid Crockford's Base32 string with the check symbol",
+	// rich text
+	"richText": "{field} value contains the HTML content not allowed by the policy {args0}",
+	// vehicle
+	"isVIN":   "{field} value must be a valid vehicle identification number",
+	"isPlate": "{field} value must be a valid license plate number of {args0}",
+	// the field value validate itself. see ValidatableFace
+	"isValid": "{field} value is invalid",
+	// strict mode. see Validation.StrictFields()
+	"unknownField": "{field} is an unknown field",
 	// required
 	"required":             "{field} is required and not empty",
 	"required_if":          "{field} is required when {args0} is {args1end}",
@@ -158,6 +324,38 @@ var builtinMessages = map[string]string{
 	"required_with_all":    "{field} field is required when {values} is present",
 	"required_without":     "{field} field is required when {values} is not present",
 	"required_without_all": "{field} field is required when none of {values} are present",
+	"requiredOneOf":        "one of the fields {field} is required",
+	"onlyOneOf":            "only one of the fields {field} can be present",
+	// compare with the original data
+	"changedRequires": "{field} can only be changed when {values} is present",
+	"immutable":       "{field} value cannot be changed",
+	"readOnlyInScene": "{field} is read-only and cannot be submitted",
+	"confirmed":       "{field} value does not match the confirmation",
+	// check the record in the storage
+	"unique": "{field} value has already been taken",
+	"exists": "{field} value does not exist",
+	// check the email domain by DNS
+	"emailMX": "{field} value must be an email address with a deliverable domain",
+	// check the password by the policy, the messages of the fail reasons
+	"password":          "{field} value is not a valid password",
+	"passwordMinLength": "{field} must be at least %d characters long",
+	"passwordMaxLength": "{field} cannot be longer than %d characters",
+	"passwordUpper":     "{field} must contain at least one uppercase letter",
+	"passwordLower":     "{field} must contain at least one lowercase letter",
+	"passwordDigit":     "{field} must contain at least one digit",
+	"passwordSymbol":    "{field} must contain at least one symbol",
+	"passwordRepeat":    "{field} cannot contain the same character more than %d times in a row",
+	"passwordBanned":    "{field} is too common, please choose another one",
+	"passwordScore":     "{field} is too weak, the strength score must be at least %d",
+	// payment
+	"creditCard": "{field} value must be a valid credit card number",
+	"isIBAN":     "{field} value must be a valid IBAN",
+	"isBIC":      "{field} value must be a valid BIC(SWIFT code)",
+	// check the email domain by the list
+	"notDisposableEmail": "{field} value cannot be a disposable email address",
+	// time window
+	"withinBusinessHours": "{field} value must be within the business hours {values}",
+	"notInDateRanges":     "{field} value cannot be in the date ranges of {args0}",
 	// field compare
 	"eqField":  "{field} value must be equal the field %s",
 	"neField":  "{field} value cannot be equal the field %s",
@@ -165,6 +363,58 @@ var builtinMessages = map[string]string{
 	"lteField": "{field} value should be less than or equal to field %s",
 	"gtField":  "{field} value must be greater the field %s",
 	"gteField": "{field} value should be greater or equal to field %s",
+	// compare with sibling fields
+	"differentFrom": "{field} value must be different from the field %s",
+	"allDifferent":  "{field} value must be different from the fields {values}",
+	"sumTo":         "{field} and the fields {args1end} must add up to {args0}",
+	"excludesWith":  "{field} cannot be present together with {values}",
+	// similarity with the field
+	"notSimilarToField": "{field} value is too similar to the field {args0}",
+	// color contrast with the field
+	"contrastWithField": "{field} color contrast ratio with the field %s must be at least %v:1",
+}
+
+// AddGlobalMessages add the default error messages for all Validation.
+// useful for the global custom validators.
+func AddGlobalMessages(data map[string]string) {
+	messagesMux.Lock()
+	for n, m := range data {
+		builtinMessages[n] = m
+	}
+	messagesMux.Unlock()
+}
+
+// copy the builtin messages for the new translator
+func copyBuiltinMessages() map[string]string {
+	messagesMux.RLock()
+	defer messagesMux.RUnlock()
+
+	newMessages := make(map[string]string, len(builtinMessages))
+	for k, v := range builtinMessages {
+		newMessages[k] = v
+	}
+	return newMessages
+}
+
+// the field label catalogs of the locales. {"locale": {"field name": "label"}}
+var fieldLabels = make(map[string]map[string]string)
+
+// AddFieldLabels add the field labels for the locale, they are used on interpolate the {field} in the messages.
+// the labels of the current locale take precedence over the field map of the Translator.
+// Usage:
+// 	validate.AddFieldLabels("fr", map[string]string{"Name": "Nom", "Email": "Courriel"})
+// 	v.SetLocale("fr")
+func AddFieldLabels(locale string, labels map[string]string) {
+	messagesMux.Lock()
+	defer messagesMux.Unlock()
+
+	if fieldLabels[locale] == nil {
+		fieldLabels[locale] = make(map[string]string, len(labels))
+	}
+
+	for field, label := range labels {
+		fieldLabels[locale][field] = label
+	}
 }
 
 /*************************************************************
@@ -173,6 +423,8 @@ var builtinMessages = map[string]string{
 
 // Translator definition
 type Translator struct {
+	// the locale for the field labels. see AddFieldLabels()
+	locale string
 	// field map {"field name": "display name"}
 	fieldMap map[string]string
 	// message data map
@@ -181,25 +433,15 @@ type Translator struct {
 
 // NewTranslator instance
 func NewTranslator() *Translator {
-	newMessages := make(map[string]string)
-	for k, v := range builtinMessages {
-		newMessages[k] = v
-	}
-
 	return &Translator{
 		fieldMap: make(map[string]string),
-		messages: newMessages,
+		messages: copyBuiltinMessages(),
 	}
 }
 
 // Reset translator to default
 func (t *Translator) Reset() {
-	newMessages := make(map[string]string)
-	for k, v := range builtinMessages {
-		newMessages[k] = v
-	}
-
-	t.messages = newMessages
+	t.messages = copyBuiltinMessages()
 	t.fieldMap = make(map[string]string)
 }
 
@@ -208,6 +450,31 @@ func (t *Translator) FieldMap() map[string]string {
 	return t.fieldMap
 }
 
+// SetLocale set the locale for the field labels
+func (t *Translator) SetLocale(locale string) {
+	t.locale = locale
+}
+
+// Locale get the current locale
+func (t *Translator) Locale() string {
+	return t.locale
+}
+
+// FieldLabel get the display name of the field. find from the labels of the current locale, then the field map.
+func (t *Translator) FieldLabel(field string) string {
+	messagesMux.RLock()
+	label, ok := fieldLabels[t.locale][field]
+	messagesMux.RUnlock()
+	if ok {
+		return label
+	}
+
+	if trName, ok := t.fieldMap[field]; ok {
+		return trName
+	}
+	return field
+}
+
 // AddMessages data to translator
 func (t *Translator) AddMessages(data map[string]string) {
 	for n, m := range data {
@@ -240,10 +507,26 @@ func (t *Translator) HasMessage(key string) bool {
 	return ok
 }
 
+// check the message of the validator is customized. eg: by the Validation.WithMessages()
+func (t *Translator) isCustomized(validator, field string) bool {
+	if _, ok := t.messages[field+"."+validator]; ok {
+		return true
+	}
+
+	msg, ok := t.messages[validator]
+	if !ok {
+		return false
+	}
+
+	messagesMux.RLock()
+	defer messagesMux.RUnlock()
+	return msg != builtinMessages[validator]
+}
+
 // Message get by validator name and field name.
 func (t *Translator) Message(validator, field string, args ...interface{}) (msg string) {
 	var ok bool
-	if rName, has := validatorAliases[validator]; has {
+	if rName := ValidatorName(validator); rName != validator {
 		msg, ok = t.format(rName, field, args...)
 		if ok {
 			return
@@ -255,10 +538,7 @@ func (t *Translator) Message(validator, field string, args ...interface{}) (msg
 	// not found, fallback - use default error message
 	if !ok {
 		// get field display name.
-		if trName, ok := t.fieldMap[field]; ok {
-			field = trName
-		}
-		return field + defaultErrMsg
+		return t.FieldLabel(field) + defaultErrMsg
 	}
 	return
 }
@@ -277,9 +557,7 @@ func (t *Translator) format(validator, field string, args ...interface{}) (strin
 	}
 
 	// get field display name.
-	if trName, ok := t.fieldMap[field]; ok {
-		field = trName
-	}
+	field = t.FieldLabel(field)
 
 	if argLen > 0 {
 		// if need call fmt.Sprintf
@@ -1,7 +1,9 @@
 package validate
 
 import (
+	"encoding/json"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,3 +45,147 @@ func TestTranslatorBasic(t *testing.T) {
 
 	tr.Reset()
 }
+
+func TestAddGlobalMessages(t *testing.T) {
+	defer delete(builtinMessages, "testGlobalMsg")
+
+	AddGlobalMessages(map[string]string{"testGlobalMsg": "{field} global message"})
+	tr := NewTranslator()
+	assert.True(t, tr.HasMessage("testGlobalMsg"))
+	assert.Equal(t, "name global message", tr.Message("testGlobalMsg", "name"))
+}
+
+func TestAddGlobalMessages_concurrent(t *testing.T) {
+	defer delete(builtinMessages, "testConcurrentMsg")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			AddGlobalMessages(map[string]string{"testConcurrentMsg": "{field} concurrent message"})
+		}()
+		go func() {
+			defer wg.Done()
+			v := Map(M{"name": ""})
+			v.StringRule("name", "required")
+			assert.False(t, v.Validate())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, "name concurrent message", NewTranslator().Message("testConcurrentMsg", "name"))
+}
+
+func TestErrors_Detailed(t *testing.T) {
+	is := assert.New(t)
+
+	es := Errors{}
+	is.Len(es.Detailed(), 0)
+
+	es.Add("name", "minLen", "name min length is 3")
+	es.Add("age", "required", "age is required")
+	details := es.Detailed()
+	is.Len(details, 2)
+	is.Equal("age", details[0].Field)
+	is.Equal("name", details[1].Field)
+	is.Equal("minLen", details[1].Validator)
+	is.Equal("minLength", details[1].Code)
+	is.Equal("name min length is 3", details[1].Message)
+	is.Nil(details[1].Args)
+
+	v := Map(M{"name": "ab", "age": 10})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"name": "minLen:3",
+		"age":  "between:18,60",
+	})
+	is.False(v.Validate())
+
+	details = v.ErrorDetails()
+	is.Len(details, 2)
+	is.Equal("between", details[0].Validator)
+	is.Equal("between", details[0].Code)
+	is.Len(details[0].Args, 2)
+	is.Equal("minLen", details[1].Validator)
+	is.Equal([]interface{}{3}, details[1].Args)
+
+	v.ResetResult()
+	is.Len(v.ErrorDetails(), 0)
+}
+
+func TestErrors_MarshalJSON(t *testing.T) {
+	is := assert.New(t)
+	defer func() { globalOpt.ErrorsJSONShape = ShapeNested }()
+
+	es := Errors{}
+	es.Add("name", "required", "name is required")
+	es.Add("name", "minLen", "name min length is 3")
+	es.Add("age", "min", "age min value is 1")
+
+	bs, err := json.Marshal(es)
+	is.NoError(err)
+	is.Equal(`{"age":{"min":"age min value is 1"},"name":{"minLen":"name min length is 3","required":"name is required"}}`, string(bs))
+
+	Config(func(opt *GlobalOption) {
+		opt.ErrorsJSONShape = ShapeFirst
+	})
+	bs, err = json.Marshal(es)
+	is.NoError(err)
+	is.Equal(`{"age":"age min value is 1","name":"name min length is 3"}`, string(bs))
+
+	globalOpt.ErrorsJSONShape = ShapeList
+	bs, err = json.Marshal(es)
+	is.NoError(err)
+	is.Equal(`{"age":["age min value is 1"],"name":["name min length is 3","name is required"]}`, string(bs))
+
+	globalOpt.ErrorsJSONShape = ShapeFlat
+	bs, err = json.Marshal(es)
+	is.NoError(err)
+	is.Equal(`[{"field":"age","message":"age min value is 1"},{"field":"name","message":"name min length is 3"},{"field":"name","message":"name is required"}]`, string(bs))
+
+	bs, err = json.Marshal(Errors{})
+	is.NoError(err)
+	is.Equal(`[]`, string(bs))
+}
+
+func TestAddFieldLabels(t *testing.T) {
+	is := assert.New(t)
+
+	AddFieldLabels("fr", map[string]string{"Name": "Nom", "Email": "Courriel"})
+	AddFieldLabels("de", map[string]string{"Name": "Name (de)"})
+	defer func() {
+		delete(fieldLabels, "fr")
+		delete(fieldLabels, "de")
+	}()
+
+	type user struct {
+		Name  string `validate:"required"`
+		Email string `validate:"minLen:10"`
+		Age   int    `validate:"required"`
+	}
+
+	newV := func(locale string) *Validation {
+		v := Struct(&user{Email: "invalid"})
+		v.StopOnError = false
+		v.AddTranslates(MS{"Age": "User Age"})
+		v.SetLocale(locale)
+		is.False(v.Validate())
+		return v
+	}
+
+	// the locale labels take precedence over the field map
+	v := newV("fr")
+	is.Equal("fr", v.Trans().Locale())
+	is.Equal("Nom is required and not empty", v.Errors.FieldOne("Name"))
+	is.Equal("Courriel min length is 10", v.Errors.FieldOne("Email"))
+	is.Equal("User Age is required and not empty", v.Errors.FieldOne("Age"))
+
+	v = newV("de")
+	is.Equal("Name (de) is required and not empty", v.Errors.FieldOne("Name"))
+	is.Equal("Email min length is 10", v.Errors.FieldOne("Email"))
+
+	// no locale
+	v = newV("")
+	is.Equal("User Age", v.Trans().FieldLabel("Age"))
+	is.Equal("Email", v.Trans().FieldLabel("Email"))
+}
@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"context"
+	"net/http"
+)
+
+// context key type for the middleware
+type ctxKey int
+
+// the SafeData key in the request context
+const safeDataCtxKey ctxKey = iota
+
+// Middleware create an net/http middleware handler. it will validate the request data by the rules,
+// on fail will response 422 with the JSON errors, on success will store the SafeData to the request context.
+// on the request data can not be parsed, will response 415(unsupported content type) or 400.
+//
+// Usage:
+// 	h := validate.Middleware(validate.MS{
+// 		"name": "required|minLen:3",
+// 		"age":  "required|int|min:1",
+// 	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+// 		data := validate.SafeDataFromContext(r.Context())
+// 		// do something ...
+// 	}))
+// 	http.Handle("/users", h)
+func Middleware(rules MS, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d, err := FromRequest(r)
+		if err != nil {
+			status := http.StatusBadRequest
+			if err == ErrEmptyData {
+				status = http.StatusUnsupportedMediaType
+			}
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+
+		v := d.Validation()
+		v.StringRules(rules)
+
+		if !v.Validate() {
+			writeErrorsJSON(w, http.StatusUnprocessableEntity, v.Errors)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), safeDataCtxKey, v.SafeData())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// SafeDataFromContext get the validated safe data, it is stored by the Middleware()
+func SafeDataFromContext(ctx context.Context) M {
+	if data, ok := ctx.Value(safeDataCtxKey).(M); ok {
+		return data
+	}
+	return nil
+}
+
+func writeErrorsJSON(w http.ResponseWriter, status int, es Errors) {
+	writeJSON(w, status, "application/json; charset=utf-8", es)
+}
+
+// write the data as JSON to the response, by the Marshal func
+func writeJSON(w http.ResponseWriter, status int, contentType string, data interface{}) {
+	bs, err := Marshal(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(bs)
+}
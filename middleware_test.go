@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	is := assert.New(t)
+
+	var safeData M
+	var body string
+	h := Middleware(MS{
+		"name": "required|minLen:3",
+		"age":  "required|intStr",
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		safeData = SafeDataFromContext(r.Context())
+		bs, _ := ioutil.ReadAll(r.Body)
+		body = string(bs)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// fail
+	r := httptest.NewRequest("GET", "/users?name=in&age=20", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	is.Equal(http.StatusUnprocessableEntity, w.Code)
+	is.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	is.Equal(`{"name":{"minLen":"name min length is 3"}}`, w.Body.String())
+	is.Nil(safeData)
+
+	// success
+	r = httptest.NewRequest("GET", "/users?name=inhere&age=20", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	is.Equal(http.StatusOK, w.Code)
+	is.Equal("inhere", safeData["name"])
+	is.Equal("20", safeData["age"])
+
+	// JSON body can read again
+	r = httptest.NewRequest("POST", "/users", strings.NewReader(`{"name": "inhere", "age": "20"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	is.Equal(http.StatusOK, w.Code)
+	is.Equal(`{"name": "inhere", "age": "20"}`, body)
+
+	// the request data can not be parsed
+	r = httptest.NewRequest("POST", "/users", strings.NewReader(`name=inhere`))
+	r.Header.Set("Content-Type", "text/plain")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	is.Equal(http.StatusUnsupportedMediaType, w.Code)
+	is.NotContains(w.Body.String(), validateError)
+
+	r = httptest.NewRequest("POST", "/users", strings.NewReader(""))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	is.Equal(http.StatusBadRequest, w.Code)
+	is.NotContains(w.Body.String(), validateError)
+
+	is.Nil(SafeDataFromContext(r.Context()))
+}
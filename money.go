@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// the minor unit digits of the ISO 4217 currencies, the currencies not in the map are 2 digits.
+var currencyDecimals = map[string]int{
+	// zero decimal
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	// three decimals
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	// four decimals
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyDecimals get the minor unit digits of the currency code. eg: "USD" => 2, "JPY" => 0
+func CurrencyDecimals(currency string) int {
+	if n, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return n
+	}
+	return 2
+}
+
+// MoneyString check the value is a money amount string of the currency, and in the bounds.
+// the value is allow the sign, but not allow the grouping, and the decimals cannot be more than the minor unit digits.
+// eg: USD "12.5", "-0.99"; JPY "1200"
+//
+// the bounds format is "min=0.01" and "max=10000", will save the amount in the minor unit(int64) to the SafeData.
+// Usage:
+// 	v.StringRule("price", "moneyString:USD,min=0.01,max=10000")
+// 	// SafeData: "12.5" => int64(1250)
+func MoneyString(val interface{}, currency string, bounds ...string) bool {
+	// the json.Number keep the decimals exactly. see GlobalOption.JSONUseNumber
+	if n, ok := val.(json.Number); ok {
+		val = string(n)
+	}
+
+	str, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	amount, ok := parseMoneyString(str, currency)
+	if !ok {
+		return false
+	}
+
+	for _, bound := range bounds {
+		kv := strings.SplitN(strings.TrimSpace(bound), "=", 2)
+		limit, ok := parseMoneyString(kv[len(kv)-1], currency)
+		if len(kv) != 2 || !ok {
+			panicf("invalid bound '%s' for the validator moneyString, eg: min=0.01", bound)
+		}
+
+		switch kv[0] {
+		case "min":
+			ok = amount >= limit
+		case "max":
+			ok = amount <= limit
+		default:
+			panicf("invalid bound '%s' for the validator moneyString, eg: min=0.01", bound)
+		}
+
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// convert the money amount to the minor unit for the SafeData. eg: USD "12.5" => int64(1250)
+func moneySafeValue(val interface{}, args []interface{}) interface{} {
+	if n, ok := val.(json.Number); ok {
+		val = string(n)
+	}
+
+	if str, ok := val.(string); ok && len(args) > 0 {
+		if amount, ok := parseMoneyString(str, fmt.Sprint(args[0])); ok {
+			return amount
+		}
+	}
+	return val
+}
+
+// parse the money amount string to the minor unit of the currency. eg: USD "12.34" => 1234
+func parseMoneyString(s, currency string) (int64, bool) {
+	var neg bool
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	decimals := CurrencyDecimals(currency)
+	intPart, frac := s, ""
+	if pos := strings.IndexByte(s, '.'); pos >= 0 {
+		intPart, frac = s[:pos], s[pos+1:]
+		if frac == "" || len(frac) > decimals {
+			return 0, false
+		}
+	}
+
+	// not allow the leading zeros. eg: "007"
+	if intPart == "" || len(intPart) > 1 && intPart[0] == '0' || !isDigitString(intPart) || !isDigitString(frac) {
+		return 0, false
+	}
+
+	frac += strings.Repeat("0", decimals-len(frac))
+	n, err := strconv.ParseInt(intPart+frac, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	if neg {
+		n = -n
+	}
+	return n, true
+}
+
+func isDigitString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigitByte(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyString(t *testing.T) {
+	is := assert.New(t)
+
+	for _, s := range []string{"0", "12", "12.5", "12.50", "-0.99", "+100", "9999999.99"} {
+		is.True(MoneyString(s, "USD"), s)
+	}
+	for _, s := range []string{"", "-", ".5", "12.", "12.345", "1,200.00", "1 200", "012", "1e3", "12.5 ", "$12", "--1"} {
+		is.False(MoneyString(s, "USD"), s)
+	}
+	is.False(MoneyString(12.5, "USD"))
+
+	is.True(MoneyString("1200", "JPY"))
+	is.False(MoneyString("1200.5", "jpy"))
+	is.True(MoneyString("1.125", "KWD"))
+	is.Equal(2, CurrencyDecimals("EUR"))
+
+	// bounds
+	is.True(MoneyString("0.01", "USD", "min=0.01", "max=10000"))
+	is.True(MoneyString("10000", "USD", "min=0.01", "max=10000"))
+	is.False(MoneyString("0", "USD", "min=0.01", "max=10000"))
+	is.False(MoneyString("10000.01", "USD", "min=0.01", "max=10000"))
+	is.True(MoneyString("-50", "USD", "min=-100"))
+	is.PanicsWithValue("validate: invalid bound 'avg=1' for the validator moneyString, eg: min=0.01", func() {
+		MoneyString("1", "USD", "avg=1")
+	})
+	is.Panics(func() {
+		MoneyString("1", "USD", "min=abc")
+	})
+
+	v := Map(M{"price": "12.5", "fee": "-3", "total": "0.001"})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"price": "required|moneyString:USD,min=0.01,max=10000",
+		"fee":   "money_string:JPY,min=0",
+		"total": "moneyString:BHD",
+	})
+	is.False(v.Validate())
+	is.Equal("fee value must be a valid JPY amount in the allowed range", v.Errors.FieldOne("fee"))
+	is.NotContains(v.Errors, "price")
+	is.NotContains(v.Errors, "total")
+
+	// save the minor unit to the SafeData
+	v = Map(M{"price": "12.5", "total": "0.001"})
+	v.StringRules(MS{
+		"price": "required|moneyString:USD,min=0.01,max=10000",
+		"total": "moneyString:BHD",
+	})
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(int64(1250), v.SafeVal("price"))
+	is.Equal(int64(1), v.SafeVal("total"))
+
+	// the json.Number
+	v = Map(M{"price": json.Number("12.5")})
+	v.StringRule("price", "required|moneyString:USD")
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(int64(1250), v.SafeVal("price"))
+}
+
+func TestAddSafeValueFunc(t *testing.T) {
+	is := assert.New(t)
+
+	AddValidator("csv", func(val interface{}) bool {
+		_, ok := val.(string)
+		return ok
+	})
+	AddSafeValueFunc("csv", func(val interface{}, args []interface{}) interface{} {
+		return strings.Split(val.(string), ",")
+	})
+	defer func() {
+		delete(validators, "csv")
+		delete(validatorValues, "csv")
+		delete(validatorMetas, "csv")
+		delete(safeValueFuncs, "csv")
+	}()
+
+	v := Map(M{"tags": "go,php", "name": "inhere"})
+	v.StringRules(MS{"tags": "required|csv", "name": "required|string"})
+	is.True(v.Validate())
+	is.Equal([]string{"go", "php"}, v.SafeVal("tags"))
+	is.Equal("inhere", v.SafeVal("name"))
+}
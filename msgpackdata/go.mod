@@ -0,0 +1,11 @@
+module github.com/gookit/validate/msgpackdata
+
+go 1.11
+
+require (
+	github.com/gookit/validate v0.0.0
+	github.com/stretchr/testify v1.6.1
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+)
+
+replace github.com/gookit/validate => ../
@@ -0,0 +1,25 @@
+// Package msgpackdata provide the msgpack data source, it is a sub-module
+// to avoid add the dependency "github.com/vmihailenco/msgpack/v5" to the validate.
+//
+// call Register() to let the validate.FromRequest() support the msgpack body.
+// the content type: application/msgpack, application/x-msgpack
+//
+// 	msgpackdata.Register()
+//
+// 	d, err := validate.FromRequest(r)
+package msgpackdata
+
+import (
+	"github.com/gookit/validate"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Register the msgpack body decoder to the validate.
+func Register() {
+	validate.RegisterBodyDecoder("msgpack", msgpack.Unmarshal)
+}
+
+// FromBytes build data instance from msgpack bytes.
+func FromBytes(bs []byte) (*validate.MapData, error) {
+	return validate.FromBytesWith(bs, msgpack.Unmarshal)
+}
@@ -0,0 +1,75 @@
+package msgpackdata
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestFromBytes(t *testing.T) {
+	is := assert.New(t)
+
+	_, err := FromBytes([]byte{0xc1})
+	is.Error(err)
+
+	bs, err := msgpack.Marshal(validate.M{"name": "inhere", "addr": validate.M{"city": "chengdu"}})
+	is.Nil(err)
+
+	d, err := FromBytes(bs)
+	is.Nil(err)
+	v := d.Create()
+	v.StringRules(validate.MS{
+		"name":      "required|minLen:4",
+		"addr.city": "required|string",
+	})
+	is.True(v.Validate(), v.Errors.String())
+	is.Equal("chengdu", v.SafeVal("addr.city"))
+}
+
+func TestRegister(t *testing.T) {
+	is := assert.New(t)
+	Register()
+
+	bs, err := msgpack.Marshal(validate.M{
+		"name": "inhere",
+		"age":  100,
+		"tags": []string{"go", "php"},
+		"addr": validate.M{"city": "chengdu"},
+	})
+	is.Nil(err)
+
+	r, _ := http.NewRequest("POST", "/users", bytes.NewReader(bs))
+	r.Header.Set("Content-Type", "application/msgpack")
+	d, err := validate.FromRequest(r)
+	is.Nil(err)
+	_, ok := d.(*validate.MapData)
+	is.True(ok)
+
+	v := d.Create()
+	v.StringRules(validate.MS{
+		"name":      "required|minLen:4",
+		"age":       "required|int|range:1,120",
+		"tags":      "required|slice",
+		"tags.*":    "minLen:2",
+		"addr.city": "required|string",
+	})
+	is.True(v.Validate(), v.Errors.String())
+	is.Equal("chengdu", v.SafeVal("addr.city"))
+
+	// body can be read again
+	again, err := ioutil.ReadAll(r.Body)
+	is.Nil(err)
+	is.Equal(bs, again)
+
+	// invalid body
+	r, _ = http.NewRequest("POST", "/users", strings.NewReader("invalid"))
+	r.Header.Set("Content-Type", "application/x-msgpack")
+	_, err = validate.FromRequest(r)
+	is.Error(err)
+}
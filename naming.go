@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldNaming the key naming strategy of the struct fields
+type FieldNaming uint8
+
+// the key naming strategies. see GlobalOption.FieldNaming
+const (
+	// NamingOriginal use the struct field name. eg: "CreateAt"
+	NamingOriginal FieldNaming = iota
+	// NamingJSON use the name in the json tag, fallback to the struct field name. eg: "createAt"
+	NamingJSON
+	// NamingSnake use the snake case of the struct field name. eg: "create_at"
+	NamingSnake
+)
+
+// the root type of the struct data source. return nil on the source is not struct.
+func (v *Validation) structType() reflect.Type {
+	switch td := v.data.(type) {
+	case *StructData:
+		return td.valueTpy
+	case *SliceData:
+		if isStructSlice(td.src) {
+			return reflect.TypeOf(td.src)
+		}
+	}
+	return nil
+}
+
+// get the named key of the field path. eg: "Items.0.CreateAt" => "items.0.create_at"
+func (v *Validation) namedKey(field string) string {
+	typ := v.structType()
+	if typ == nil || v.FieldNaming == NamingOriginal {
+		return field
+	}
+
+	nodes := strings.Split(field, ".")
+	for i, node := range nodes {
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ == nil {
+			nodes[i] = v.namedField(node, "")
+			continue
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			sf, ok := typ.FieldByName(node)
+			if !ok {
+				nodes[i] = v.namedField(node, "")
+				typ = nil
+				continue
+			}
+
+			nodes[i] = v.namedField(sf.Name, sf.Tag.Get("json"))
+			typ = sf.Type
+		case reflect.Slice, reflect.Array, reflect.Map:
+			// the node is the element index or map key
+			typ = typ.Elem()
+		default:
+			nodes[i] = v.namedField(node, "")
+			typ = nil
+		}
+	}
+	return strings.Join(nodes, ".")
+}
+
+func (v *Validation) namedField(name, jsonTag string) string {
+	switch v.FieldNaming {
+	case NamingJSON:
+		if tag := strings.Split(jsonTag, ",")[0]; tag != "" && tag != "-" {
+			return tag
+		}
+	case NamingSnake:
+		return snakeCase(name)
+	}
+	return name
+}
+
+// use the named keys as the display names, if the field has not translation.
+func (v *Validation) initFieldNaming() {
+	if v.FieldNaming == NamingOriginal || v.structType() == nil {
+		return
+	}
+
+	for _, r := range v.rules {
+		for _, field := range r.fields {
+			if !v.trans.HasField(field) {
+				v.trans.AddFieldMap(map[string]string{field: v.namedKey(field)})
+			}
+		}
+	}
+}
+
+// rename the keys of the Errors and SafeData to the named keys.
+func (v *Validation) applyFieldNaming() {
+	if v.FieldNaming == NamingOriginal || v.structType() == nil {
+		return
+	}
+
+	es := make(Errors, len(v.Errors))
+	for field, fe := range v.Errors {
+		key := v.namedKey(field)
+		for validator, msg := range fe {
+			es.Add(key, validator, msg)
+		}
+
+		for validator := range fe {
+			if args, ok := v.errArgs[field+"."+validator]; ok {
+				delete(v.errArgs, field+"."+validator)
+				v.errArgs[key+"."+validator] = args
+			}
+		}
+	}
+	v.Errors = es
+
+	safeData := make(map[string]interface{}, len(v.safeData))
+	for field, val := range v.safeData {
+		safeData[v.namedKey(field)] = val
+	}
+	v.safeData = safeData
+}
+
+// convert the name to snake case. eg: "CreateAt" => "create_at", "UserID" => "user_id"
+func snakeCase(s string) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			// the word boundary. eg: "aB", "ABc"
+			if i > 0 && s[i-1] != '_' && (isLowerOrDigit(s[i-1]) || i+1 < len(s) && s[i+1] >= 'a' && s[i+1] <= 'z' && isUpper(s[i-1])) {
+				buf.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String()
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+func isLowerOrDigit(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnakeCase(t *testing.T) {
+	is := assert.New(t)
+
+	is.Equal("create_at", snakeCase("CreateAt"))
+	is.Equal("user_id", snakeCase("UserID"))
+	is.Equal("http_server", snakeCase("HTTPServer"))
+	is.Equal("address2_line", snakeCase("Address2Line"))
+	is.Equal("name", snakeCase("name"))
+	is.Equal("_validate", snakeCase("_validate"))
+}
+
+type namingUser struct {
+	Name     string `json:"name" validate:"required|minLen:3"`
+	CreateAt string `json:"createdAt" validate:"required"`
+	UserID   int    `validate:"required"`
+}
+
+func TestValidation_FieldNaming(t *testing.T) {
+	is := assert.New(t)
+
+	// original
+	v := Struct(&namingUser{Name: "ab"})
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.Contains(v.Errors, "CreateAt")
+	is.Equal("Name min length is 3", v.Errors.FieldOne("Name"))
+
+	// json tag
+	v = Struct(&namingUser{Name: "ab"})
+	v.StopOnError = false
+	v.FieldNaming = NamingJSON
+	is.False(v.Validate())
+	is.Len(v.Errors, 3)
+	is.Equal("name min length is 3", v.Errors.FieldOne("name"))
+	is.Equal("createdAt is required and not empty", v.Errors.FieldOne("createdAt"))
+	is.Contains(v.Errors, "UserID")
+	details := v.ErrorDetails()
+	is.Equal("name", details[2].Field)
+	is.Equal([]interface{}{3}, details[2].Args)
+
+	// snake case
+	Config(func(opt *GlobalOption) {
+		opt.FieldNaming = NamingSnake
+	})
+	defer func() { globalOpt.FieldNaming = NamingOriginal }()
+
+	v = Struct(&namingUser{Name: "inhere", CreateAt: "2019-06-03", UserID: 23})
+	is.True(v.Validate())
+	is.Equal("inhere", v.SafeVal("name"))
+	is.Equal("2019-06-03", v.SafeVal("create_at"))
+	is.Equal(23, v.SafeVal("user_id"))
+	is.Equal(23, v.SafeVal("UserID"))
+
+	// custom translation first
+	v = Struct(&namingUser{Name: "inhere"})
+	v.WithTranslates(map[string]string{"CreateAt": "Create Time"})
+	is.False(v.Validate())
+	is.Equal("Create Time is required and not empty", v.Errors.FieldOne("create_at"))
+
+	// slice of struct
+	sd, err := FromStructSlice([]*namingUser{{Name: "inhere", CreateAt: "2019-06-03", UserID: 1}, {Name: "ab"}})
+	is.NoError(err)
+	v = sd.Create()
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.Contains(v.Errors, "1.name")
+	is.Contains(v.Errors, "1.user_id")
+	is.NotContains(v.Errors, "0.name")
+}
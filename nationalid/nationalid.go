@@ -0,0 +1,209 @@
+// Package nationalid provide the country-specific national identity number validators.
+//
+// it is an optional package, call Register() to add the validator "nationalID" to the validate.
+//
+// 	nationalid.Register()
+//
+// 	v := validate.Map(data)
+// 	v.StringRule("cpf", "required|nationalID:BR")
+package nationalid
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gookit/validate"
+)
+
+// ValidatorName the validator name registered by Register()
+const ValidatorName = "nationalID"
+
+// the checkers for each country. key is the ISO 3166-1 alpha-2 country code
+var checkerMux sync.RWMutex
+
+var checkers = map[string]func(id string) bool{
+	"US": IsSSN,
+	"GB": IsNINO,
+	"BR": IsCPF,
+	"IN": IsAadhaar,
+}
+
+// Register add the validator "nationalID" and the error message to the validate.
+func Register() {
+	validate.AddValidator(ValidatorName, func(val interface{}, country string) bool {
+		id, ok := val.(string)
+		return ok && Check(country, id)
+	})
+	validate.AddGlobalMessages(map[string]string{
+		ValidatorName: "{field} value must be a valid national ID of %s",
+	})
+}
+
+// AddCountry add or override the checker for the country.
+func AddCountry(country string, checker func(id string) bool) {
+	checkerMux.Lock()
+	checkers[strings.ToUpper(country)] = checker
+	checkerMux.Unlock()
+}
+
+// Countries get the supported country codes
+func Countries() []string {
+	checkerMux.RLock()
+	defer checkerMux.RUnlock()
+
+	codes := make([]string, 0, len(checkers))
+	for code := range checkers {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// Check the id is a valid national ID of the country. return false on the country is not supported.
+func Check(country, id string) bool {
+	checkerMux.RLock()
+	checker, ok := checkers[strings.ToUpper(country)]
+	checkerMux.RUnlock()
+
+	if ok {
+		return checker(id)
+	}
+	return false
+}
+
+// IsSSN check the US Social Security Number. eg: "123-45-6789", "123456789"
+func IsSSN(id string) bool {
+	s := id
+	if len(s) == 11 {
+		if s[3] != '-' || s[6] != '-' {
+			return false
+		}
+		s = s[:3] + s[4:6] + s[7:]
+	}
+
+	if len(s) != 9 || !isDigits(s) {
+		return false
+	}
+
+	area, group, serial := s[:3], s[3:5], s[5:]
+	if area == "000" || area == "666" || area[0] == '9' {
+		return false
+	}
+	return group != "00" && serial != "0000"
+}
+
+// invalid prefixes of the NINO
+var ninoBadPrefixes = "|BG|GB|NK|KN|TN|NT|ZZ|"
+
+// IsNINO check the UK National Insurance Number. eg: "AB123456C", "AB 12 34 56 C"
+func IsNINO(id string) bool {
+	s := strings.ToUpper(strings.Replace(id, " ", "", -1))
+	if len(s) != 9 || !isDigits(s[2:8]) {
+		return false
+	}
+
+	first, second, suffix := s[0], s[1], s[8]
+	if first < 'A' || first > 'Z' || strings.IndexByte("DFIQUV", first) >= 0 {
+		return false
+	}
+
+	if second < 'A' || second > 'Z' || strings.IndexByte("DFIOQUV", second) >= 0 {
+		return false
+	}
+
+	if strings.Contains(ninoBadPrefixes, "|"+s[:2]+"|") {
+		return false
+	}
+	return suffix >= 'A' && suffix <= 'D'
+}
+
+// IsCPF check the Brazil CPF number with the check digits. eg: "529.982.247-25", "52998224725"
+func IsCPF(id string) bool {
+	s := id
+	if len(s) == 14 {
+		if s[3] != '.' || s[7] != '.' || s[11] != '-' {
+			return false
+		}
+		s = s[:3] + s[4:7] + s[8:11] + s[12:]
+	}
+
+	if len(s) != 11 || !isDigits(s) {
+		return false
+	}
+
+	// all digits are same. eg: "111.111.111-11"
+	if strings.Count(s, s[:1]) == 11 {
+		return false
+	}
+
+	for _, n := range []int{9, 10} {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(s[i]-'0') * (n + 1 - i)
+		}
+
+		digit := sum * 10 % 11 % 10
+		if digit != int(s[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAadhaar check the India Aadhaar number with the Verhoeff check digit. eg: "2341 2341 2346"
+func IsAadhaar(id string) bool {
+	s := strings.Replace(id, " ", "", -1)
+	if len(s) != 12 || !isDigits(s) {
+		return false
+	}
+
+	// cannot start with 0 or 1
+	if s[0] == '0' || s[0] == '1' {
+		return false
+	}
+	return verhoeffCheck(s)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// the Verhoeff algorithm tables
+var (
+	verhoeffD = [10][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffP = [8][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+)
+
+// check the digits string with the Verhoeff check digit at the end.
+func verhoeffCheck(s string) bool {
+	c := 0
+	ln := len(s)
+	for i := 0; i < ln; i++ {
+		c = verhoeffD[c][verhoeffP[i%8][int(s[ln-1-i]-'0')]]
+	}
+	return c == 0
+}
@@ -0,0 +1,80 @@
+package nationalid
+
+import (
+	"testing"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSSN(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsSSN("123-45-6789"))
+	is.True(IsSSN("123456789"))
+	is.False(IsSSN("000-45-6789"))
+	is.False(IsSSN("666-45-6789"))
+	is.False(IsSSN("923-45-6789"))
+	is.False(IsSSN("123-00-6789"))
+	is.False(IsSSN("123-45-0000"))
+	is.False(IsSSN("123-456-789"))
+	is.False(IsSSN("12345678a"))
+}
+
+func TestIsNINO(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsNINO("AB123456C"))
+	is.True(IsNINO("ab 12 34 56 c"))
+	is.False(IsNINO("QQ123456C"))
+	is.False(IsNINO("AO123456C"))
+	is.False(IsNINO("GB123456A"))
+	is.False(IsNINO("AB123456E"))
+	is.False(IsNINO("AB12345C"))
+	is.False(IsNINO("1B123456C"))
+}
+
+func TestIsCPF(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsCPF("529.982.247-25"))
+	is.True(IsCPF("52998224725"))
+	is.False(IsCPF("529.982.247-26"))
+	is.False(IsCPF("529-982-247.25"))
+	is.False(IsCPF("111.111.111-11"))
+	is.False(IsCPF("5299822472"))
+}
+
+func TestIsAadhaar(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsAadhaar("234123412346"))
+	is.True(IsAadhaar("2341 2341 2346"))
+	is.False(IsAadhaar("234123412345"))
+	is.False(IsAadhaar("134123412346"))
+	is.False(IsAadhaar("23412341234"))
+	is.True(verhoeffCheck("2363"))
+}
+
+func TestRegister(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(Check("br", "529.982.247-25"))
+	is.False(Check("XX", "529.982.247-25"))
+	is.Len(Countries(), 4)
+
+	AddCountry("xx", func(id string) bool { return id == "ok" })
+	is.True(Check("XX", "ok"))
+	delete(checkers, "XX")
+
+	Register()
+	v := validate.Map(validate.M{"cpf": "529.982.247-25", "ssn": "000-45-6789"})
+	v.StopOnError = false
+	v.StringRules(validate.MS{
+		"cpf": "required|nationalID:BR",
+		"ssn": "required|nationalID:US",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("ssn value must be a valid national ID of US", v.Errors.One())
+}
@@ -0,0 +1,112 @@
+package validate
+
+import (
+	"sort"
+	"sync"
+)
+
+// registry of the rule overlays. {key: {field: rule}}
+var (
+	overlayMux sync.RWMutex
+	overlays   = make(map[string]MS)
+)
+
+// AddOverlay add the rule overlay for the key(eg: tenant ID).
+// the overlay rules will override the base rules of the same field, an empty rule will remove the field rules.
+// Usage:
+// 	validate.AddOverlay("tenant-42", validate.MS{
+// 		"name":  "required|maxLen:64",
+// 		"phone": "required",
+// 	})
+func AddOverlay(key string, rules MS) {
+	overlayMux.Lock()
+	defer overlayMux.Unlock()
+
+	if _, ok := overlays[key]; !ok {
+		overlays[key] = make(MS, len(rules))
+	}
+
+	for field, rule := range rules {
+		overlays[key][field] = rule
+	}
+}
+
+// Overlay get the overlay rules by key
+func Overlay(key string) MS {
+	overlayMux.RLock()
+	defer overlayMux.RUnlock()
+
+	mp := make(MS, len(overlays[key]))
+	for field, rule := range overlays[key] {
+		mp[field] = rule
+	}
+	return mp
+}
+
+// RemoveOverlay remove the overlay by key
+func RemoveOverlay(key string) {
+	overlayMux.Lock()
+	delete(overlays, key)
+	overlayMux.Unlock()
+}
+
+// WithOverlay set the overlay key(eg: tenant ID) for the validation.
+// the overlay rules will be resolved at validate time.
+// Usage:
+// 	v := validate.Map(data)
+// 	v.StringRules(baseRules)
+// 	v.WithOverlay("tenant-42")
+// 	ok := v.Validate()
+func (v *Validation) WithOverlay(key string) *Validation {
+	v.overlay = key
+	return v
+}
+
+// apply the overlay rules to the validation
+func (v *Validation) applyOverlay() {
+	if v.overlay == "" {
+		return
+	}
+
+	rules := Overlay(v.overlay)
+	// only apply once
+	v.overlay = ""
+	if len(rules) == 0 {
+		return
+	}
+
+	// remove the base rules of the overridden fields
+	kept := make([]*Rule, 0, len(v.rules))
+	for _, r := range v.rules {
+		fields := make([]string, 0, len(r.fields))
+		for _, field := range r.fields {
+			if _, ok := rules[field]; !ok {
+				fields = append(fields, field)
+			}
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		// the rule contains multi fields, copy it for the remaining fields
+		if len(fields) != len(r.fields) {
+			nr := *r
+			nr.fields = fields
+			r = &nr
+		}
+		kept = append(kept, r)
+	}
+	v.rules = kept
+
+	// add the overlay rules, sort fields for keep the order
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		v.StringRule(field, rules[field])
+	}
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_WithOverlay(t *testing.T) {
+	is := assert.New(t)
+	defer RemoveOverlay("tenant-42")
+
+	AddOverlay("tenant-42", MS{"name": "required|maxLen:4"})
+	AddOverlay("tenant-42", MS{"phone": "required", "age": ""})
+	is.Equal(MS{"name": "required|maxLen:4", "phone": "required", "age": ""}, Overlay("tenant-42"))
+	is.Empty(Overlay("not-exist"))
+
+	newV := func() *Validation {
+		v := Map(M{"name": "inhere", "age": 200, "email": "bad"})
+		v.StopOnError = false
+		v.StringRules(MS{
+			"name":  "required|maxLen:10",
+			"email": "required",
+		})
+		v.AddRule("age,email", "minLen", 1)
+		v.StringRule("age", "max:120")
+		return v
+	}
+
+	// base rules
+	v := newV()
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "age")
+
+	// with overlay
+	v = newV().WithOverlay("tenant-42")
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("name max length is 4", v.Errors.FieldOne("name"))
+	is.Equal("phone is required and not empty", v.Errors.FieldOne("phone"))
+	is.NotContains(v.Errors, "age")
+
+	// not exist overlay
+	v = newV().WithOverlay("tenant-99")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+
+	RemoveOverlay("tenant-42")
+	is.Empty(Overlay("tenant-42"))
+}
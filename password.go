@@ -0,0 +1,172 @@
+package validate
+
+import (
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// the fail reasons of the password check, they are also the message keys. see PasswordPolicy.Check()
+const (
+	PasswordTooShort    = "passwordMinLength"
+	PasswordTooLong     = "passwordMaxLength"
+	PasswordNoUpper     = "passwordUpper"
+	PasswordNoLower     = "passwordLower"
+	PasswordNoDigit     = "passwordDigit"
+	PasswordNoSymbol    = "passwordSymbol"
+	PasswordRepeated    = "passwordRepeat"
+	PasswordBanned      = "passwordBanned"
+	PasswordScoreTooLow = "passwordScore"
+)
+
+// PasswordPolicy the requirements of the password for the validator "password"
+type PasswordPolicy struct {
+	// MinLength the min number of the characters. default is 8
+	MinLength int
+	// MaxLength the max number of the characters. default is 0, no limit
+	MaxLength int
+	// the required character classes
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MaxRepeat the max number of the same character in a row. eg: 3 reject "aaaa". default is 0, no limit
+	MaxRepeat int
+	// Banned check the password is banned. eg: in the common passwords list, in the breached passwords
+	Banned func(password string) bool
+	// Score the strength score of the password. eg: the zxcvbn score 0-4. it works with the MinScore
+	Score func(password string) int
+	// MinScore the min strength score by the Score func
+	MinScore int
+}
+
+// the named password policies for the validator "password"
+var passwordPolicyMux sync.RWMutex
+
+var passwordPolicies = map[string]*PasswordPolicy{
+	"default": {MinLength: 8, RequireLower: true, RequireDigit: true},
+	"strong": {
+		MinLength:     12,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		MaxRepeat:     3,
+	},
+}
+
+// AddPasswordPolicy add or override the named policy for the validator "password"
+// Usage:
+// 	validate.AddPasswordPolicy("admin", &validate.PasswordPolicy{
+// 		MinLength:    16,
+// 		RequireUpper: true,
+// 		RequireDigit: true,
+// 		Banned:       commonPasswords.Contains,
+// 	})
+func AddPasswordPolicy(name string, policy *PasswordPolicy) {
+	passwordPolicyMux.Lock()
+	passwordPolicies[name] = policy
+	passwordPolicyMux.Unlock()
+}
+
+func passwordPolicy(name string) *PasswordPolicy {
+	passwordPolicyMux.RLock()
+	policy, ok := passwordPolicies[name]
+	passwordPolicyMux.RUnlock()
+	if !ok {
+		panicf("the password policy '%s' is not exists", name)
+	}
+	return policy
+}
+
+// Check the password by the policy, returns the fail reason. eg: PasswordTooShort
+// returns empty string on the password is valid.
+func (p *PasswordPolicy) Check(password string) string {
+	reason, _ := p.check(password)
+	return reason
+}
+
+// check the password, returns the fail reason and the arg of the reason message
+func (p *PasswordPolicy) check(password string) (string, interface{}) {
+	minLen := p.MinLength
+	if minLen <= 0 {
+		minLen = 8
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < minLen {
+		return PasswordTooShort, minLen
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return PasswordTooLong, p.MaxLength
+	}
+
+	var upper, lower, digit, symbol bool
+	var last rune
+	var repeat int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+
+		if r == last {
+			repeat++
+		} else {
+			last, repeat = r, 1
+		}
+		if p.MaxRepeat > 0 && repeat > p.MaxRepeat {
+			return PasswordRepeated, p.MaxRepeat
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !upper:
+		return PasswordNoUpper, nil
+	case p.RequireLower && !lower:
+		return PasswordNoLower, nil
+	case p.RequireDigit && !digit:
+		return PasswordNoDigit, nil
+	case p.RequireSymbol && !symbol:
+		return PasswordNoSymbol, nil
+	case p.Banned != nil && p.Banned(password):
+		return PasswordBanned, nil
+	case p.Score != nil && p.Score(password) < p.MinScore:
+		return PasswordScoreTooLow, p.MinScore
+	}
+	return "", nil
+}
+
+// Password check the value is a password that meets the named policy. default use the policy "default".
+// the error message explains which requirement failed. eg: "password must contain at least one digit"
+// Usage:
+// 	v.StringRule("password", "required|password:strong")
+func (v *Validation) Password(val interface{}, policy ...string) bool {
+	str, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	name := "default"
+	if len(policy) > 0 && policy[0] != "" {
+		name = policy[0]
+	}
+
+	reason, arg := passwordPolicy(name).check(str)
+	if reason == "" {
+		return true
+	}
+
+	var args []interface{}
+	if arg != nil {
+		args = []interface{}{arg}
+	}
+	v.failReason = &failReason{validator: "password", key: reason, args: args}
+	return false
+}
@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	is := assert.New(t)
+
+	p := passwordPolicy("strong")
+	tests := map[string]string{
+		"Abc-12":            PasswordTooShort,
+		"abcdef-123456":     PasswordNoUpper,
+		"ABCDEF-123456":     PasswordNoLower,
+		"Abcdef-ghijkl":     PasswordNoDigit,
+		"Abcdef1234567":     PasswordNoSymbol,
+		"Abbbbc-123456":     PasswordRepeated,
+		"Abbbc-123456":      "",
+		"Пароль-Надёжный-1": "",
+	}
+	for pwd, want := range tests {
+		is.Equal(want, p.Check(pwd), pwd)
+	}
+
+	p = &PasswordPolicy{
+		MaxLength: 10,
+		Banned: func(password string) bool {
+			return password == "password1"
+		},
+		Score: func(password string) int {
+			return len(password) / 3
+		},
+		MinScore: 3,
+	}
+	is.Equal(PasswordTooShort, p.Check("short"))
+	is.Equal(PasswordTooLong, p.Check("too-long-password"))
+	is.Equal(PasswordBanned, p.Check("password1"))
+	is.Equal(PasswordScoreTooLow, p.Check("abcdefgh"))
+	is.Equal("", p.Check("abcdefghi"))
+}
+
+func TestValidation_Password(t *testing.T) {
+	is := assert.New(t)
+
+	tests := map[string]string{
+		"abc":          "password must be at least 8 characters long",
+		"abcdefgh":     "password must contain at least one digit",
+		"abcdefgh1":    "",
+		"aaaaaaaa1":    "",
+		"ABCDEFGH1":    "password must contain at least one lowercase letter",
+		"password1234": "",
+	}
+	for pwd, want := range tests {
+		v := Map(M{"password": pwd})
+		v.StringRule("password", "required|password")
+		if want == "" {
+			is.True(v.Validate(), pwd)
+		} else {
+			is.False(v.Validate(), pwd)
+			is.Equal(want, v.Errors.FieldOne("password"), pwd)
+		}
+	}
+
+	// the named policy
+	AddPasswordPolicy("test", &PasswordPolicy{
+		MinLength: 6,
+		MaxRepeat: 2,
+		Banned: func(password string) bool {
+			return password == "qwerty"
+		},
+	})
+	defer delete(passwordPolicies, "test")
+
+	v := Map(M{"pwd1": "qwerty", "pwd2": "aaab12", "pwd3": "good-pwd"})
+	v.StopOnError = false
+	v.StringRules(MS{"pwd1": "password:test", "pwd2": "password:test", "pwd3": "password:test"})
+	is.False(v.Validate())
+	is.Equal("pwd1 is too common, please choose another one", v.Errors.FieldOne("pwd1"))
+	is.Equal("pwd2 cannot contain the same character more than 2 times in a row", v.Errors.FieldOne("pwd2"))
+	is.Empty(v.Errors.Field("pwd3"))
+
+	// the custom message is preferred
+	v = Map(M{"password": "abc", "name": "in"})
+	v.StopOnError = false
+	v.StringRule("password", "password")
+	v.StringRule("name", "minLen:3")
+	v.WithMessages(map[string]string{"password": "the password is too weak"})
+	is.False(v.Validate())
+	is.Equal("the password is too weak", v.Errors.FieldOne("password"))
+	is.Equal("name min length is 3", v.Errors.FieldOne("name"))
+	is.Nil(v.failReason)
+
+	is.PanicsWithValue("validate: the password policy 'notExists' is not exists", func() {
+		v := Map(M{"password": "abc"})
+		v.StringRule("password", "password:notExists")
+		v.Validate()
+	})
+}
+
+func TestValidation_Password_globalOverride(t *testing.T) {
+	is := assert.New(t)
+
+	// the global validator added by the user takes precedence
+	AddValidator("password", func(val interface{}) bool {
+		return len(val.(string)) >= 4
+	})
+	defer func() {
+		delete(validators, "password")
+		delete(validatorValues, "password")
+		delete(validatorMetas, "password")
+	}()
+
+	v := Map(M{"pwd": "abcd"})
+	v.StringRule("pwd", "password")
+	is.True(v.Validate())
+
+	v = Map(M{"pwd": "abc"})
+	v.StringRule("pwd", "password")
+	is.False(v.Validate())
+	is.Equal("pwd value is not a valid password", v.Errors.One())
+}
@@ -0,0 +1,154 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// the I/O bound validators. see MarkIOValidators()
+var (
+	ioMux        sync.RWMutex
+	ioValidators = map[string]bool{"unique": true, "exists": true, "emailMX": true}
+)
+
+// MarkIOValidators mark the validators are I/O bound. eg: the unique check in the database, the DNS lookup.
+//
+// if the Validation.PrefetchWorkers > 0, they will be called concurrently before apply the rules,
+// and the results are joined on apply the rules. so the marked validators must be safe for concurrent use.
+// Usage:
+// 	validate.AddValidator("uniqueEmail", func(val interface{}) bool {...})
+// 	validate.MarkIOValidators("uniqueEmail")
+func MarkIOValidators(names ...string) {
+	ioMux.Lock()
+	for _, name := range names {
+		ioValidators[name] = true
+	}
+	ioMux.Unlock()
+}
+
+func isIOValidator(name string) bool {
+	ioMux.RLock()
+	defer ioMux.RUnlock()
+	return ioValidators[name]
+}
+
+type prefetchKey struct {
+	rule  *Rule
+	field string
+}
+
+// the prefetched validator call
+type prefetchTask struct {
+	val  interface{}
+	done chan struct{}
+	ok   bool
+	// the panic value of the validator, will re-panic on wait.
+	panicVal interface{}
+}
+
+func (t *prefetchTask) wait() bool {
+	<-t.done
+	if t.panicVal != nil {
+		panic(t.panicVal)
+	}
+	return t.ok
+}
+
+// call the I/O validators of the rules concurrently, bounded by the PrefetchWorkers.
+// the rules with the beforeFunc or filterFunc, the wildcard fields and the not exists fields are not prefetched.
+func (v *Validation) prefetch() {
+	if v.PrefetchWorkers <= 0 {
+		return
+	}
+
+	sem := make(chan struct{}, v.PrefetchWorkers)
+	// collect the args convert errors, the errors will be reported on apply the rule.
+	scratch := &Validation{Errors: make(Errors)}
+
+	for _, r := range v.rules {
+		name := ValidatorName(r.validator)
+		if !isIOValidator(name) || strings.HasPrefix(name, "required") || isFieldValidator(name) {
+			continue
+		}
+		if r.scene != "" && r.scene != v.scene || r.beforeFunc != nil || r.filterFunc != nil {
+			continue
+		}
+
+		fm := r.checkFuncMeta
+		if fm == nil {
+			if fm = v.validatorMeta(name); fm == nil {
+				continue
+			}
+		}
+
+		args := append([]interface{}(nil), r.arguments...)
+		if !fm.matchArgNum(len(args)+1) || !convertArgsType(scratch, fm, args) {
+			continue
+		}
+
+		for _, field := range r.fields {
+			if isWildcardField(field) || v.isNotNeedToCheck(field) || v.isStoppedField(field) {
+				continue
+			}
+
+			val, ok := v.prefetchValue(r, fm, field)
+			if !ok {
+				continue
+			}
+
+			task := &prefetchTask{val: val, done: make(chan struct{})}
+			if v.prefetched == nil {
+				v.prefetched = make(map[prefetchKey]*prefetchTask)
+			}
+			v.prefetched[prefetchKey{r, field}] = task
+
+			go func(fm *funcMeta) {
+				sem <- struct{}{}
+				defer func() {
+					task.panicVal = recover()
+					<-sem
+					close(task.done)
+				}()
+
+				task.ok = v.callFuncMeta(fm, task.val, args)
+			}(fm)
+		}
+	}
+}
+
+// get the field value as same as the valueValidate() will pass to the validator.
+func (v *Validation) prefetchValue(r *Rule, fm *funcMeta, field string) (interface{}, bool) {
+	val, exist := v.Get(field)
+	if !exist {
+		return nil, false
+	}
+
+	val = v.checkValue(val)
+	if r.skipEmpty && IsEmpty(val) {
+		return nil, false
+	}
+
+	rftVal := reflect.ValueOf(val)
+	firstTyp := fm.fv.Type().In(0).Kind()
+	if firstTyp != rftVal.Kind() && firstTyp != reflect.Interface {
+		ak, err := basicKind(rftVal)
+		if err != nil {
+			return nil, false
+		}
+
+		if nVal, _ := convertType(val, ak, firstTyp); nVal != nil {
+			val = nVal
+		}
+	}
+	return val, true
+}
+
+// get the prefetched task of the rule field, the value must be same as the prefetched value.
+func (v *Validation) prefetchedTask(r *Rule, field string, val interface{}) *prefetchTask {
+	task, ok := v.prefetched[prefetchKey{r, field}]
+	if !ok || !reflect.DeepEqual(task.val, val) {
+		return nil
+	}
+	return task
+}
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_prefetch(t *testing.T) {
+	is := assert.New(t)
+
+	var calls, running, maxRunning int32
+	v := Map(M{
+		"email":    "taken@example.com",
+		"username": "inhere",
+		"nickname": "tom",
+		"domain":   "example.com",
+		"empty":    "",
+	})
+	v.AddValidator("notTaken", func(val string, table string) bool {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return val != "taken@example.com"
+	})
+	MarkIOValidators("notTaken")
+	defer func() {
+		delete(ioValidators, "notTaken")
+	}()
+
+	v.StopOnError = false
+	v.PrefetchWorkers = 2
+	v.StringRule("email,username,nickname,domain,empty", "notTaken:users")
+
+	start := time.Now()
+	is.False(v.Validate())
+	is.True(time.Since(start) < 180*time.Millisecond)
+	is.Equal(int32(4), atomic.LoadInt32(&calls))
+	is.Equal(int32(2), atomic.LoadInt32(&maxRunning))
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "email")
+
+	// the value must be same as the prefetched value
+	v = Map(M{"email": "taken@example.com"})
+	v.PrefetchWorkers = 2
+	v.AddValidator("notTaken", func(val string) bool {
+		return val != "taken@example.com"
+	})
+	v.StringRule("email", "notTaken")
+	v.prefetch()
+	is.Nil(v.prefetchedTask(v.rules[0], "email", "other"))
+	is.Nil(v.prefetchedTask(v.rules[0], "name", "taken@example.com"))
+	task := v.prefetchedTask(v.rules[0], "email", "taken@example.com")
+	is.NotNil(task)
+	is.False(task.wait())
+
+	// the panic is re-panic on apply the rule
+	v = Map(M{"email": "some@example.com"})
+	v.PrefetchWorkers = 1
+	v.AddValidator("notTaken", func(val string) bool {
+		panic("db is down")
+	})
+	v.StringRule("email", "notTaken")
+	is.PanicsWithValue("db is down", func() {
+		v.Validate()
+	})
+}
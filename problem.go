@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"net/http"
+)
+
+// ProblemContentType the content type of the RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// ProblemType the default type URI of the validation problem details
+var ProblemType = "about:blank"
+
+// InvalidParam the item of the "invalid-params" in the problem details
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	Code   string `json:"code,omitempty"`
+}
+
+// ProblemDetails the RFC 7807 problem details document.
+// see https://tools.ietf.org/html/rfc7807
+type ProblemDetails struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// ToProblemDetails convert the errors to the RFC 7807 problem details.
+// Usage:
+// 	pd := v.Errors.ToProblemDetails(http.StatusUnprocessableEntity)
+func (es Errors) ToProblemDetails(status int) *ProblemDetails {
+	details := es.Detailed()
+	params := make([]InvalidParam, 0, len(details))
+	for _, d := range details {
+		params = append(params, InvalidParam{Name: d.Field, Reason: d.Message, Code: d.Code})
+	}
+
+	return &ProblemDetails{
+		Type:          ProblemType,
+		Title:         "Your request parameters didn't validate.",
+		Status:        status,
+		InvalidParams: params,
+	}
+}
+
+// WriteProblem write the errors as the RFC 7807 problem details to the response.
+// Usage:
+// 	if !v.Validate() {
+// 		validate.WriteProblem(w, http.StatusBadRequest, v.Errors)
+// 		return
+// 	}
+func WriteProblem(w http.ResponseWriter, status int, es Errors) {
+	writeJSON(w, status, ProblemContentType, es.ToProblemDetails(status))
+}
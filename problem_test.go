@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_ToProblemDetails(t *testing.T) {
+	is := assert.New(t)
+
+	es := Errors{}
+	es.Add("name", "minLen", "name min length is 3")
+	es.Add("age", "required", "age is required")
+
+	pd := es.ToProblemDetails(http.StatusBadRequest)
+	is.Equal("about:blank", pd.Type)
+	is.Equal(http.StatusBadRequest, pd.Status)
+	is.Len(pd.InvalidParams, 2)
+	is.Equal(InvalidParam{Name: "age", Reason: "age is required", Code: "required"}, pd.InvalidParams[0])
+	is.Equal("minLength", pd.InvalidParams[1].Code)
+
+	// empty errors
+	pd = Errors{}.ToProblemDetails(http.StatusBadRequest)
+	is.NotNil(pd.InvalidParams)
+	is.Len(pd.InvalidParams, 0)
+}
+
+func TestWriteProblem(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "in"})
+	v.StringRule("name", "required|minLen:3")
+	is.False(v.Validate())
+
+	w := httptest.NewRecorder()
+	WriteProblem(w, http.StatusUnprocessableEntity, v.Errors)
+	is.Equal(http.StatusUnprocessableEntity, w.Code)
+	is.Equal(ProblemContentType, w.Header().Get("Content-Type"))
+	is.Equal(
+		`{"type":"about:blank","title":"Your request parameters didn't validate.","status":422,`+
+			`"invalid-params":[{"name":"name","reason":"name min length is 3","code":"minLength"}]}`,
+		w.Body.String(),
+	)
+}
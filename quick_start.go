@@ -1,12 +1,16 @@
 package validate
 
 import (
-	"encoding/json"
+	"bytes"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // New a Validation
@@ -24,8 +28,18 @@ func New(data interface{}, scene ...string) *Validation {
 		return FromURLValues(td).Create().SetScene(scene...)
 	case map[string][]string:
 		return FromURLValues(td).Create().SetScene(scene...)
+	case *multipart.Form:
+		return FromMultipartForm(td).Create().SetScene(scene...)
+	case map[string][]*multipart.FileHeader:
+		data := newFormData()
+		data.AddFiles(td)
+		return data.Create().SetScene(scene...)
 	}
 
+	// slice/array of the map. eg: []map[string]interface{}
+	if isMapSlice(data) {
+		return newWithError(FromSlice(data)).SetScene(scene...)
+	}
 	return Struct(data, scene...)
 }
 
@@ -38,13 +52,47 @@ func Map(m map[string]interface{}, scene ...string) *Validation {
 	return FromMap(m).Create().SetScene(scene...)
 }
 
+// Slice validation create. validate each element of the slice/array(the struct or map) by the rules,
+// the error field name is start with the index. eg: "0.name", "1.age"
+// Usage:
+// 	v := validate.Slice(items, validate.MS{
+// 		"name": "required|minLen:3",
+// 		"age":  "required|int|min:1",
+// 	})
+func Slice(s interface{}, rules MS, scene ...string) *Validation {
+	d, err := FromSlice(s)
+	v := newWithError(d, err).SetScene(scene...)
+	if err != nil {
+		return v
+	}
+
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for i := 0; i < d.Len(); i++ {
+		prefix := strconv.Itoa(i) + "."
+		for _, field := range fields {
+			v.StringRule(prefix+field, rules[field])
+		}
+	}
+	return v
+}
+
 // JSON create validation from JSON string.
 func JSON(s string, scene ...string) *Validation {
 	return newWithError(FromJSON(s)).SetScene(scene...)
 }
 
-// Struct validation create
+// Struct validation create. allow an struct or an slice/array of the struct.
+// for the slice/array, the error field name is start with the index. eg: "0.Name"
 func Struct(s interface{}, scene ...string) *Validation {
+	if isStructSlice(s) {
+		return newWithError(FromStructSlice(s)).SetScene(scene...)
+	}
+
 	return newWithError(FromStruct(s)).SetScene(scene...)
 }
 
@@ -80,7 +128,7 @@ func FromJSON(s string) (*MapData, error) {
 // FromJSONBytes string build data instance.
 func FromJSONBytes(bs []byte) (*MapData, error) {
 	mp := map[string]interface{}{}
-	if err := json.Unmarshal(bs, &mp); err != nil {
+	if err := jsonUnmarshal(bs, &mp); err != nil {
 		return nil, err
 	}
 
@@ -94,6 +142,18 @@ func FromJSONBytes(bs []byte) (*MapData, error) {
 	return data, nil
 }
 
+// FromBytesWith build data instance by decode the bytes to the map with the decode func.
+// Usage:
+// 	d, err := validate.FromBytesWith(bs, msgpack.Unmarshal)
+func FromBytesWith(bs []byte, decode UnmarshalFunc) (*MapData, error) {
+	mp := map[string]interface{}{}
+	if err := decode(bs, &mp); err != nil {
+		return nil, err
+	}
+
+	return FromMap(mp), nil
+}
+
 // FromStruct create a Data from struct
 func FromStruct(s interface{}) (*StructData, error) {
 	data := &StructData{
@@ -124,34 +184,257 @@ func FromStruct(s interface{}) (*StructData, error) {
 	return data, nil
 }
 
+// FromStructSlice create a Data from an slice/array of the struct
+func FromStructSlice(s interface{}) (*SliceData, error) {
+	data := &SliceData{src: s}
+	if !isStructSlice(s) {
+		return data, ErrInvalidData
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(s))
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i)
+		// use pointer, allow update source value.
+		if elem.Kind() == reflect.Struct && elem.CanAddr() {
+			elem = elem.Addr()
+		}
+
+		item, err := FromStruct(elem.Interface())
+		if err != nil {
+			return data, err
+		}
+		data.items = append(data.items, item)
+	}
+
+	return data, nil
+}
+
+// FromSlice create a Data from an slice/array of the struct or map
+func FromSlice(s interface{}) (*SliceData, error) {
+	if isStructSlice(s) {
+		return FromStructSlice(s)
+	}
+
+	data := &SliceData{src: s}
+	if !isMapSlice(s) {
+		return data, ErrInvalidData
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(s))
+	for i := 0; i < rv.Len(); i++ {
+		mp, _ := toStringMap(rv.Index(i).Interface())
+		data.items = append(data.items, FromMap(mp))
+	}
+
+	return data, nil
+}
+
+// check is an slice/array of the map. eg: []map[string]interface{}, []interface{}{M{}}
+func isMapSlice(s interface{}) bool {
+	if s == nil {
+		return false
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(s))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if _, ok := toStringMap(rv.Index(i).Interface()); !ok {
+			return false
+		}
+	}
+	return rv.Len() > 0 || rv.Type().Elem().Kind() == reflect.Map
+}
+
+func toStringMap(val interface{}) (map[string]interface{}, bool) {
+	switch tv := val.(type) {
+	case map[string]interface{}:
+		return tv, true
+	case M:
+		return tv, true
+	}
+	return nil, false
+}
+
+// check is an slice/array of the struct(or struct pointer)
+func isStructSlice(s interface{}) bool {
+	if s == nil {
+		return false
+	}
+
+	rt := reflect.TypeOf(s)
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	if rt.Kind() != reflect.Slice && rt.Kind() != reflect.Array {
+		return false
+	}
+
+	et := rt.Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	return et.Kind() == reflect.Struct && et != timeType
+}
+
+// the field name prefix for the merged request headers and cookies. eg: "header:X-Api-Key"
+const (
+	HeaderPrefix = "header:"
+	CookiePrefix = "cookie:"
+)
+
+// RequestOption for collect data from request. see FromRequestWith()
+type RequestOption struct {
+	// MaxMemory for parse the multipart form. default is 32 MB
+	MaxMemory int64
+	// Headers the header names to merge into the data, the field name is HeaderPrefix + name
+	Headers []string
+	// Cookies the cookie names to merge into the data, the field name is CookiePrefix + name
+	Cookies []string
+	// PathParams the router path params. they have higher priority than the query and body data.
+	PathParams map[string]string
+	// QueryFirst the URL query data has higher priority than the body data. default the body data is first.
+	QueryFirst bool
+}
+
 // FromRequest collect data from request instance
 func FromRequest(r *http.Request, maxMemoryLimit ...int64) (DataFace, error) {
+	var maxMemory int64
+	if len(maxMemoryLimit) > 0 {
+		maxMemory = maxMemoryLimit[0]
+	}
+
+	return fromRequest(r, maxMemory)
+}
+
+// FromRequestWith collect data from request instance, and merge the path params, selected headers and cookies.
+// the data sources are layered by priority: path params > headers and cookies > body > query.
+// the URL query is layered for all the content types, use RequestOption.QueryFirst to put it before the body.
+// Usage:
+// 	d, err := validate.FromRequestWith(r, validate.RequestOption{
+// 		Headers: []string{"X-Api-Key"},
+// 		Cookies: []string{"session"},
+// 	})
+// 	v := d.Create()
+// 	v.StringRule("header:X-Api-Key", "required|len:32")
+func FromRequestWith(r *http.Request, opt RequestOption) (DataFace, error) {
+	body, err := fromRequestBody(r, opt.MaxMemory)
+	if err != nil {
+		// allow only validate the query, headers, cookies and path params
+		if err != ErrEmptyData || len(opt.Headers)+len(opt.Cookies)+len(opt.PathParams) == 0 {
+			return nil, err
+		}
+	}
+
+	query := FromURLValues(r.URL.Query())
+	// the headers and cookies can not be faked by the body or query data
+	stripRequestMetaKeys(body)
+	stripRequestMetaKeys(query)
+	sources := make([]DataFace, 0, 4)
+
+	if len(opt.PathParams) > 0 {
+		params := make(map[string]interface{}, len(opt.PathParams))
+		for key, val := range opt.PathParams {
+			params[key] = val
+		}
+		sources = append(sources, FromMap(params))
+	}
+
+	meta := newFormData()
+	for _, name := range opt.Headers {
+		if vs := r.Header[http.CanonicalHeaderKey(name)]; len(vs) > 0 {
+			meta.Add(HeaderPrefix+name, vs[0])
+		}
+	}
+
+	for _, name := range opt.Cookies {
+		if c, err := r.Cookie(name); err == nil {
+			meta.Add(CookiePrefix+name, c.Value)
+		}
+	}
+
+	if len(meta.Form) > 0 {
+		sources = append(sources, meta)
+	}
+
+	if body == nil {
+		return MergeData(append(sources, query)...), nil
+	}
+	if opt.QueryFirst {
+		return MergeData(append(sources, query, body)...), nil
+	}
+	return MergeData(append(sources, body, query)...), nil
+}
+
+// remove the keys start with the HeaderPrefix or CookiePrefix from the body or query data,
+// they can only be collected from the request headers and cookies.
+func stripRequestMetaKeys(d DataFace) {
+	isMetaKey := func(key string) bool {
+		return strings.HasPrefix(key, HeaderPrefix) || strings.HasPrefix(key, CookiePrefix)
+	}
+
+	switch td := d.(type) {
+	case *FormData:
+		for key := range td.Form {
+			if isMetaKey(key) {
+				delete(td.Form, key)
+			}
+		}
+		for key := range td.Files {
+			if isMetaKey(key) {
+				delete(td.Files, key)
+			}
+		}
+	case *MapData:
+		for key := range td.Map {
+			if isMetaKey(key) {
+				delete(td.Map, key)
+			}
+		}
+	}
+}
+
+func fromRequest(r *http.Request, maxMemory int64) (DataFace, error) {
+	d, err := fromRequestBody(r, maxMemory)
+	if err != nil {
+		return nil, err
+	}
+
 	// no body. like GET DELETE ....
-	if r.Method != "POST" && r.Method != "PUT" && r.Method != "PATCH" {
+	if d == nil {
 		return FromURLValues(r.URL.Query()), nil
 	}
 
+	// add queries data to the form
+	if fd, ok := d.(*FormData); ok {
+		fd.AddValues(r.URL.Query())
+	}
+	return d, nil
+}
+
+// collect the data from the request body, returns nil on the request method has no body. like GET DELETE ....
+func fromRequestBody(r *http.Request, maxMemory int64) (DataFace, error) {
+	if r.Method != "POST" && r.Method != "PUT" && r.Method != "PATCH" {
+		return nil, nil
+	}
+
 	cType := r.Header.Get("Content-Type")
 
 	// contains file uploaded form
 	// strings.HasPrefix(mediaType, "multipart/")
 	if strings.Contains(cType, "multipart/form-data") {
-		maxMemory := defaultMaxMemory
-		if len(maxMemoryLimit) > 0 {
-			maxMemory = maxMemoryLimit[0]
+		if maxMemory <= 0 {
+			maxMemory = defaultMaxMemory
 		}
 
 		if err := r.ParseMultipartForm(maxMemory); err != nil {
 			return nil, err
 		}
 
-		// collect from values
-		data := FromURLValues(r.MultipartForm.Value)
-		// collect uploaded files
-		data.AddFiles(r.MultipartForm.File)
-		// add queries data
-		data.AddValues(r.URL.Query())
-		return data, nil
+		return FromMultipartForm(r.MultipartForm), nil
 	}
 
 	// basic POST form. content type: application/x-www-form-urlencoded
@@ -160,25 +443,82 @@ func FromRequest(r *http.Request, maxMemoryLimit ...int64) (DataFace, error) {
 			return nil, err
 		}
 
-		data := FromURLValues(r.PostForm)
-		// add queries data
-		data.AddValues(r.URL.Query())
-		return data, nil
+		return FromURLValues(r.PostForm), nil
 	}
 
 	// JSON body request
 	if strings.Contains(cType, "application/json") {
-		bs, err := ioutil.ReadAll(r.Body)
+		bs, err := readBody(r)
 		if err != nil {
 			return nil, err
 		}
-
 		return FromJSONBytes(bs)
 	}
 
+	// the registered body decoders. eg: msgpack
+	if decode := findBodyDecoder(cType); decode != nil {
+		bs, err := readBody(r)
+		if err != nil {
+			return nil, err
+		}
+		return FromBytesWith(bs, decode)
+	}
+
 	return nil, ErrEmptyData
 }
 
+// read all the request body, and restore it for allow read it again.
+func readBody(r *http.Request) ([]byte, error) {
+	bs, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(bs))
+	return bs, nil
+}
+
+type bodyDecoder struct {
+	keyword string
+	decode  UnmarshalFunc
+}
+
+var (
+	bodyDecodersMux sync.RWMutex
+	// the registered body decoders, matched in the order of registration
+	bodyDecoders []bodyDecoder
+)
+
+// RegisterBodyDecoder register the decoder for the request body, it is used on the content type contains the keyword.
+// it allows the FromRequest() support more body formats without add the dependencies to the validate.
+// Usage:
+// 	// content type: application/msgpack, application/x-msgpack
+// 	validate.RegisterBodyDecoder("msgpack", msgpack.Unmarshal)
+func RegisterBodyDecoder(keyword string, decode UnmarshalFunc) {
+	bodyDecodersMux.Lock()
+	defer bodyDecodersMux.Unlock()
+
+	for i, bd := range bodyDecoders {
+		if bd.keyword == keyword {
+			bodyDecoders[i].decode = decode
+			return
+		}
+	}
+	bodyDecoders = append(bodyDecoders, bodyDecoder{keyword: keyword, decode: decode})
+}
+
+func findBodyDecoder(cType string) UnmarshalFunc {
+	bodyDecodersMux.RLock()
+	defer bodyDecodersMux.RUnlock()
+
+	for _, bd := range bodyDecoders {
+		if strings.Contains(cType, bd.keyword) {
+			return bd.decode
+		}
+	}
+	return nil
+}
+
 // FromURLValues build data instance.
 func FromURLValues(values url.Values) *FormData {
 	data := newFormData()
@@ -191,6 +531,41 @@ func FromURLValues(values url.Values) *FormData {
 	return data
 }
 
+// FromMultipartForm build data instance from the parsed multipart form, contains the form values and uploaded files.
+// it can be used for the frameworks already parsed the multipart form, without re-parse the request.
+// Usage:
+// 	form, err := c.MultipartForm() // gin
+// 	v := validate.FromMultipartForm(form).Create()
+// 	v.StringRule("avatar", "required|image")
+func FromMultipartForm(form *multipart.Form) *FormData {
+	data := newFormData()
+	if form != nil {
+		data.AddValues(form.Value)
+		data.AddFiles(form.File)
+	}
+	return data
+}
+
+// MergeData layers multi data sources into one, the front source has higher priority.
+// Usage:
+// 	d := validate.MergeData(validate.FromMap(pathParams), bodyData, validate.FromQuery(r.URL.Query()))
+// 	v := d.Create()
+func MergeData(sources ...DataFace) *MergedData {
+	d := &MergedData{Sources: make([]DataFace, 0, len(sources))}
+	for _, src := range sources {
+		if src != nil {
+			d.Sources = append(d.Sources, src)
+		}
+	}
+	return d
+}
+
+// FromHeaders build data instance from the request headers.
+// the field name is the canonical header key. eg: "X-Api-Key"
+func FromHeaders(header http.Header) *FormData {
+	return FromURLValues(url.Values(header))
+}
+
 // FromQuery build data instance.
 // Usage:
 // 	validate.FromQuery(r.URL.Query()).Create()
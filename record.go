@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordChecker check the record exists in the storage. eg: the database
+// use for the validators "unique" and "exists", it must be safe for concurrent use.
+type RecordChecker interface {
+	Exists(ctx context.Context, table, column string, value interface{}) (bool, error)
+}
+
+var (
+	recordMux     sync.RWMutex
+	recordChecker RecordChecker
+)
+
+// SetRecordChecker set the global RecordChecker for the validators "unique" and "exists". set nil to remove it.
+// Usage:
+// 	validate.SetRecordChecker(checker)
+//
+// 	v := validate.Map(data)
+// 	v.StringRules(validate.MS{
+// 		"email":   "required|email|unique:users,email",
+// 		"team_id": "required|exists:teams,id",
+// 	})
+func SetRecordChecker(c RecordChecker) {
+	recordMux.Lock()
+	recordChecker = c
+	recordMux.Unlock()
+}
+
+// WithRecordChecker set the RecordChecker for the validation, it takes precedence over the global one.
+func (v *Validation) WithRecordChecker(c RecordChecker) *Validation {
+	v.recordChecker = c
+	return v
+}
+
+// WithContext set the context pass to the RecordChecker and the remote validators. default is context.Background()
+// for the slice data, the validation will be aborted on the context is canceled. see OnProgress()
+func (v *Validation) WithContext(ctx context.Context) *Validation {
+	v.ctx = ctx
+	return v
+}
+
+// get the context of the validation, default is the context.Background()
+func (v *Validation) context() context.Context {
+	if v.ctx != nil {
+		return v.ctx
+	}
+	return context.Background()
+}
+
+// RecordError get the first error returned by the RecordChecker on the last validate.
+// the field is failed on the checker return error, use it to distinguish the storage error.
+func (v *Validation) RecordError() error {
+	recordMux.RLock()
+	defer recordMux.RUnlock()
+	return v.recordErr
+}
+
+// Unique the value must not exist in the column of the table. see RecordChecker
+// Usage:
+// 	v.StringRule("email", "unique:users,email")
+func (v *Validation) Unique(val interface{}, table, column string) bool {
+	exists, ok := v.recordExists(table, column, val)
+	return ok && !exists
+}
+
+// Exists the value must exist in the column of the table. see RecordChecker
+// Usage:
+// 	v.StringRule("team_id", "exists:teams,id")
+func (v *Validation) Exists(val interface{}, table, column string) bool {
+	exists, ok := v.recordExists(table, column, val)
+	return ok && exists
+}
+
+func (v *Validation) recordExists(table, column string, val interface{}) (exists, ok bool) {
+	c := v.recordChecker
+	if c == nil {
+		recordMux.RLock()
+		c = recordChecker
+		recordMux.RUnlock()
+	}
+
+	if c == nil {
+		panicf("the RecordChecker is not set for the validator 'unique' or 'exists', see SetRecordChecker()")
+	}
+
+	exists, err := c.Exists(v.context(), table, column, val)
+	if err != nil {
+		// the validators maybe called concurrently by the prefetch
+		recordMux.Lock()
+		if v.recordErr == nil {
+			v.recordErr = err
+		}
+		recordMux.Unlock()
+		return false, false
+	}
+	return exists, true
+}
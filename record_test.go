@@ -0,0 +1,129 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecordChecker struct {
+	mu      sync.Mutex
+	records map[string]bool
+	ctxs    []context.Context
+	err     error
+}
+
+func (c *testRecordChecker) Exists(ctx context.Context, table, column string, value interface{}) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ctxs = append(c.ctxs, ctx)
+	if c.err != nil {
+		return false, c.err
+	}
+	return c.records[fmt.Sprintf("%s.%s=%v", table, column, value)], nil
+}
+
+type testCtxKey string
+
+func TestValidation_Unique_Exists(t *testing.T) {
+	is := assert.New(t)
+
+	checker := &testRecordChecker{records: map[string]bool{
+		"users.email=taken@example.com": true,
+		"teams.id=12":                   true,
+	}}
+
+	// the checker is not set
+	v := Map(M{"email": "new@example.com"})
+	v.StringRule("email", "unique:users,email")
+	is.PanicsWithValue("validate: the RecordChecker is not set for the validator 'unique' or 'exists', see SetRecordChecker()", func() {
+		v.Validate()
+	})
+
+	SetRecordChecker(checker)
+	defer SetRecordChecker(nil)
+
+	v = Map(M{"email": "new@example.com", "team_id": 12})
+	v.StringRules(MS{
+		"email":   "required|email|unique:users,email",
+		"team_id": "required|exists:teams,id",
+	})
+	is.True(v.Validate(), v.Errors.One())
+	is.NoError(v.RecordError())
+
+	v = Map(M{"email": "taken@example.com", "team_id": 13})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"email":   "unique:users,email",
+		"team_id": "exists:teams,id",
+	})
+	is.False(v.Validate())
+	is.Equal("email value has already been taken", v.Errors.FieldOne("email"))
+	is.Equal("team_id value does not exist", v.Errors.FieldOne("team_id"))
+
+	// the empty value is skipped
+	v = Map(M{"email": ""})
+	v.StringRule("email", "unique:users,email")
+	is.True(v.Validate())
+
+	// the validation checker and context
+	other := &testRecordChecker{records: map[string]bool{}}
+	ctx := context.WithValue(context.Background(), testCtxKey("tenant"), "t1")
+	v = Map(M{"email": "taken@example.com"})
+	v.WithRecordChecker(other).WithContext(ctx)
+	v.StringRule("email", "unique:users,email")
+	is.True(v.Validate())
+	is.Len(other.ctxs, 1)
+	is.Equal("t1", other.ctxs[0].Value(testCtxKey("tenant")))
+
+	// the checker return error
+	other.err = errors.New("db is down")
+	v.ResetResult()
+	is.False(v.Validate())
+	is.EqualError(v.RecordError(), "db is down")
+	v.ResetResult()
+	is.NoError(v.RecordError())
+
+	// prefetch the I/O validators
+	v = Map(M{"email": "taken@example.com", "team_id": 12})
+	v.StopOnError = false
+	v.PrefetchWorkers = 2
+	v.StringRules(MS{
+		"email":   "unique:users,email",
+		"team_id": "exists:teams,id",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "email")
+}
+
+func TestValidation_Unique_globalOverride(t *testing.T) {
+	is := assert.New(t)
+
+	// the global validator added by the user takes precedence
+	AddValidator("unique", func(val interface{}) bool {
+		return val != "taken"
+	})
+	defer func() {
+		delete(validators, "unique")
+		delete(validatorValues, "unique")
+		delete(validatorMetas, "unique")
+	}()
+
+	v := Map(M{"name": "taken", "team_id": 12})
+	v.StopOnError = false
+	v.WithRecordChecker(&testRecordChecker{records: map[string]bool{}})
+	v.StringRules(MS{"name": "unique", "team_id": "exists:teams,id"})
+	is.False(v.Validate())
+	is.Equal("name value has already been taken", v.Errors.FieldOne("name"))
+	is.Equal("team_id value does not exist", v.Errors.FieldOne("team_id"))
+
+	v = Map(M{"name": "free"})
+	v.StringRule("name", "unique")
+	is.True(v.Validate())
+}
@@ -0,0 +1,100 @@
+package validate
+
+import "strings"
+
+// RenderMode the mode to render the error details. see Validation.RenderErrors()
+type RenderMode uint8
+
+const (
+	// RenderClient render the client-safe error details by the RedactPolicy. use for the API response
+	RenderClient RenderMode = iota
+	// RenderDebug render all the error details, include the submitted values. use for the audit log
+	RenderDebug
+)
+
+// RedactedValue the value of the sensitive fields in the rendered error details
+const RedactedValue = "[REDACTED]"
+
+// RedactPolicy control which parts of the error details are exposed to the client on the RenderClient.
+// the zero value exposes nothing but the field, validator, code and message.
+type RedactPolicy struct {
+	// ShowValues expose the submitted values of the failed fields
+	ShowValues bool
+	// ShowArgs expose the rule args
+	ShowArgs bool
+	// ShowInternal expose the internal error messages. eg: the filter errors, the args convert errors.
+	// on false, they are replaced by the InternalMessage
+	ShowInternal bool
+	// InternalMessage the message replaced the internal error messages. default is "internal validation error"
+	InternalMessage string
+	// SensitiveFields the values of the fields are always redacted, include the RenderDebug. eg: "password"
+	// the name match the field or the last part of the field path. eg: "password" match "users.0.password"
+	SensitiveFields []string
+}
+
+// DefaultRedactPolicy the default policy on the validation has not set the policy. see Validation.WithRedactPolicy()
+var DefaultRedactPolicy = RedactPolicy{ShowArgs: true}
+
+// WithRedactPolicy set the policy for render the error details. see RenderErrors()
+func (v *Validation) WithRedactPolicy(p RedactPolicy) *Validation {
+	v.redactPolicy = &p
+	return v
+}
+
+// RenderErrors render the error details by the mode, so one validation result can serve both
+// the API response(RenderClient) and the audit log(RenderDebug).
+// Usage:
+// 	v.WithRedactPolicy(validate.RedactPolicy{SensitiveFields: []string{"password"}})
+// 	if !v.Validate() {
+// 		logger.Info("validate failed", "errors", v.RenderErrors(validate.RenderDebug))
+// 		writeJSON(w, 422, v.RenderErrors(validate.RenderClient))
+// 	}
+func (v *Validation) RenderErrors(mode RenderMode) []ErrorDetail {
+	p := v.redactPolicy
+	if p == nil {
+		p = &DefaultRedactPolicy
+	}
+
+	details := v.ErrorDetails()
+	for i := range details {
+		d := &details[i]
+		// the internal errors. eg: "_filter", "_validate"
+		internal := strings.HasPrefix(d.Field, "_")
+		if !internal && (mode == RenderDebug || p.ShowValues) {
+			if val, ok := v.Raw(d.Field); ok {
+				d.Value = val
+				if p.isSensitive(d.Field) {
+					d.Value = RedactedValue
+				}
+			}
+		}
+
+		if mode == RenderDebug {
+			continue
+		}
+
+		if !p.ShowArgs {
+			d.Args = nil
+		}
+		if internal && !p.ShowInternal {
+			d.Message = p.internalMessage()
+		}
+	}
+	return details
+}
+
+func (p *RedactPolicy) isSensitive(field string) bool {
+	for _, name := range p.SensitiveFields {
+		if field == name || strings.HasSuffix(field, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RedactPolicy) internalMessage() string {
+	if p.InternalMessage != "" {
+		return p.InternalMessage
+	}
+	return "internal validation error"
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_RenderErrors(t *testing.T) {
+	is := assert.New(t)
+
+	newV := func() *Validation {
+		v := Map(M{"name": "in", "password": "123", "age": "abc"})
+		v.StopOnError = false
+		v.StringRules(MS{
+			"name":     "minLen:3",
+			"password": "minLen:6",
+		})
+		v.FilterRule("age", "int")
+		return v
+	}
+
+	// the default policy
+	v := newV()
+	is.False(v.Validate())
+	details := v.RenderErrors(RenderClient)
+	is.Len(details, 3)
+	is.Equal("_filter", details[0].Field)
+	is.Equal("internal validation error", details[0].Message)
+	is.Equal("name", details[1].Field)
+	is.Equal([]interface{}{3}, details[1].Args)
+	is.Nil(details[1].Value)
+
+	// the debug mode
+	v = newV()
+	v.WithRedactPolicy(RedactPolicy{SensitiveFields: []string{"password"}})
+	is.False(v.Validate())
+	details = v.RenderErrors(RenderDebug)
+	is.Contains(details[0].Message, "abc")
+	is.Equal("in", details[1].Value)
+	is.Equal([]interface{}{3}, details[1].Args)
+	is.Equal("password", details[2].Field)
+	is.Equal(RedactedValue, details[2].Value)
+
+	// the client mode of the custom policy
+	details = v.RenderErrors(RenderClient)
+	is.Equal("internal validation error", details[0].Message)
+	is.Nil(details[1].Value)
+	is.Nil(details[1].Args)
+	is.Equal("name min length is 3", details[1].Message)
+
+	v = newV()
+	v.WithRedactPolicy(RedactPolicy{
+		ShowValues:      true,
+		ShowInternal:    true,
+		InternalMessage: "server error",
+		SensitiveFields: []string{"password"},
+	})
+	is.False(v.Validate())
+	details = v.RenderErrors(RenderClient)
+	is.Contains(details[0].Message, "abc")
+	is.Equal("in", details[1].Value)
+	is.Equal(RedactedValue, details[2].Value)
+
+	// the nested field path
+	p := RedactPolicy{SensitiveFields: []string{"password"}}
+	is.True(p.isSensitive("users.0.password"))
+	is.False(p.isSensitive("password_hint"))
+}
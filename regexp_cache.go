@@ -0,0 +1,188 @@
+package validate
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+	"sync/atomic"
+)
+
+// RegexpMatcher the compiled pattern of the regex engine. the *regexp.Regexp is a RegexpMatcher
+type RegexpMatcher interface {
+	MatchString(s string) bool
+}
+
+// RegexpEngine compile the pattern to the RegexpMatcher. see SetRegexpEngine()
+type RegexpEngine func(pattern string) (RegexpMatcher, error)
+
+// the current regex engine, stores the *regexpEngine. avoid the lock on match the built-in patterns
+var rxEngine atomic.Value
+
+// the regex engine set by the SetRegexpEngine(), the new instance is created on each set.
+type regexpEngine struct {
+	compile RegexpEngine
+}
+
+func init() {
+	rxEngine.Store(&regexpEngine{})
+}
+
+// get the current regex engine, returns nil on use the standard package regexp.
+func currentRegexpEngine() *regexpEngine {
+	if e := rxEngine.Load().(*regexpEngine); e.compile != nil {
+		return e
+	}
+	return nil
+}
+
+// the LRU cache of the compiled patterns for the validator "regexp". see GlobalOption.RegexpCacheSize
+var rxCache = &regexpCache{
+	items: make(map[string]*list.Element),
+	order: list.New(),
+}
+
+type regexpCache struct {
+	mu sync.Mutex
+	// pattern => the element of the order list
+	items map[string]*list.Element
+	// the recently used is at the front
+	order *list.List
+}
+
+type regexpEntry struct {
+	pattern string
+	rx      RegexpMatcher
+}
+
+// SetRegexpEngine set the regex engine for the validator "regexp" and the built-in pattern-based validators.
+// eg: the RE2-compatible or hyperscan wrappers. set nil to restore the standard package regexp.
+// the built-in patterns are compiled once by each engine on the first use, they are not in the cache of the "regexp".
+// the built-in patterns the engine cannot compile will fallback to the standard package regexp.
+// Usage:
+// 	validate.SetRegexpEngine(func(pattern string) (validate.RegexpMatcher, error) {
+// 		return re2.Compile(pattern)
+// 	})
+func SetRegexpEngine(engine RegexpEngine) {
+	rxCache.mu.Lock()
+	rxEngine.Store(&regexpEngine{compile: engine})
+	// the cached patterns are compiled by the old engine
+	rxCache.items = make(map[string]*list.Element)
+	rxCache.order.Init()
+	rxCache.mu.Unlock()
+}
+
+// compile the pattern by the regex engine, the compiled pattern is cached.
+func compileRegexp(pattern string) (RegexpMatcher, error) {
+	size := globalOpt.RegexpCacheSize
+	if size <= 0 {
+		return rxCache.compile(pattern)
+	}
+
+	if rx := rxCache.get(pattern); rx != nil {
+		return rx, nil
+	}
+
+	rx, err := rxCache.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	rxCache.add(pattern, rx, size)
+	return rx, nil
+}
+
+func (c *regexpCache) compile(pattern string) (RegexpMatcher, error) {
+	if e := currentRegexpEngine(); e != nil {
+		return e.compile(pattern)
+	}
+	return regexp.Compile(pattern)
+}
+
+func (c *regexpCache) get(pattern string) RegexpMatcher {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[pattern]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*regexpEntry).rx
+	}
+	return nil
+}
+
+func (c *regexpCache) add(pattern string, rx RegexpMatcher, size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// added by other goroutine
+	if el, ok := c.items[pattern]; ok {
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[pattern] = c.order.PushFront(&regexpEntry{pattern: pattern, rx: rx})
+	for c.order.Len() > size {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.items, el.Value.(*regexpEntry).pattern)
+	}
+}
+
+func (c *regexpCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// the pattern of the built-in validators, it is matched by the regex engine on it is set.
+type builtinPattern struct {
+	rx *regexp.Regexp
+	// the compiled pattern by the regex engine, stores the *enginePattern
+	compiled atomic.Value
+}
+
+// the pattern compiled by the engine, the rx is nil on the engine cannot compile it.
+type enginePattern struct {
+	engine *regexpEngine
+	rx     RegexpMatcher
+}
+
+func mustCompilePattern(pattern string) *builtinPattern {
+	return &builtinPattern{rx: regexp.MustCompile(pattern)}
+}
+
+// get the pattern compiled by the current regex engine, returns nil on use the standard package regexp.
+// it is compiled once by each engine, the concurrent first calls may compile it more than once.
+func (p *builtinPattern) matcher() RegexpMatcher {
+	e := currentRegexpEngine()
+	if e == nil {
+		return nil
+	}
+
+	if ep, ok := p.compiled.Load().(*enginePattern); ok && ep.engine == e {
+		return ep.rx
+	}
+
+	rx, err := e.compile(p.rx.String())
+	if err != nil {
+		rx = nil
+	}
+	p.compiled.Store(&enginePattern{engine: e, rx: rx})
+	return rx
+}
+
+// MatchString reports whether the string s contains any match of the pattern.
+func (p *builtinPattern) MatchString(s string) bool {
+	if rx := p.matcher(); rx != nil {
+		return rx.MatchString(s)
+	}
+	return p.rx.MatchString(s)
+}
+
+// FindStringSubmatch returns the submatches of the leftmost match in s. see regexp.Regexp.FindStringSubmatch
+// the RegexpMatcher cannot extract the submatches, so the regex engine only checks the string is matched.
+func (p *builtinPattern) FindStringSubmatch(s string) []string {
+	if rx := p.matcher(); rx != nil && !rx.MatchString(s) {
+		return nil
+	}
+	return p.rx.FindStringSubmatch(s)
+}
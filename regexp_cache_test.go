@@ -0,0 +1,139 @@
+package validate
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexpCache(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(Regexp("abc123", `^[a-z]+\d+$`))
+	rx1, err := compileRegexp(`^[a-z]+\d+$`)
+	is.NoError(err)
+	rx2, _ := compileRegexp(`^[a-z]+\d+$`)
+	// use the cached
+	is.True(rx1 == rx2)
+
+	// the invalid pattern is not cached
+	n := rxCache.len()
+	is.False(Regexp("abc", `[a-z`))
+	is.Equal(n, rxCache.len())
+
+	v := Map(M{"code": "1234"})
+	v.StringRule("code", `regexp:^\d{4,6}$`)
+	is.True(v.Validate())
+
+	// the least recently used is evicted
+	Config(func(opt *GlobalOption) {
+		opt.RegexpCacheSize = 2
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.RegexpCacheSize = 256
+	})
+
+	for i := 0; i < 3; i++ {
+		is.True(Regexp("a"+strconv.Itoa(i), `^a`+strconv.Itoa(i)+`$`))
+	}
+	// "^a1$" is recently used
+	is.True(Regexp("a1", `^a1$`))
+	is.True(Regexp("a3", `^a3$`))
+	is.Equal(2, rxCache.len())
+	is.Nil(rxCache.get(`^a2$`))
+	is.NotNil(rxCache.get(`^a1$`))
+	is.NotNil(rxCache.get(`^a3$`))
+
+	// disable the cache
+	Config(func(opt *GlobalOption) {
+		opt.RegexpCacheSize = 0
+	})
+	is.True(Regexp("a4", `^a4$`))
+	is.Nil(rxCache.get(`^a4$`))
+}
+
+// a strict engine, only allow the simple patterns
+type testStrictEngine struct {
+	patterns []string
+}
+
+func (e *testStrictEngine) compile(pattern string) (RegexpMatcher, error) {
+	e.patterns = append(e.patterns, pattern)
+	if strings.Contains(pattern, "(?:") {
+		return nil, errors.New("the group is not allowed")
+	}
+	return regexp.Compile(pattern)
+}
+
+func TestSetRegexpEngine(t *testing.T) {
+	is := assert.New(t)
+
+	engine := &testStrictEngine{}
+	SetRegexpEngine(engine.compile)
+	defer SetRegexpEngine(nil)
+
+	// the validator "regexp"
+	is.True(Regexp("abc", `^[a-z]+$`))
+	is.False(Regexp("abc", `^(?:[a-z]+)$`))
+	is.Equal([]string{`^[a-z]+$`, `^(?:[a-z]+)$`}, engine.patterns)
+
+	// the built-in pattern
+	is.True(IsAlpha("abc"))
+	is.Contains(engine.patterns, rxAlpha.rx.String())
+	// fallback to the standard regexp on the engine cannot compile
+	is.True(IsAlphaDash("ab-c"))
+	is.False(IsAlphaDash("ab c"))
+
+	v := Map(M{"code": "1234"})
+	v.StringRule("code", `regexp:^\d{4}$`)
+	is.True(v.Validate())
+	is.Contains(engine.patterns, `^\d{4}$`)
+
+	// the built-in patterns are compiled once by the engine, and not in the cache of the "regexp"
+	Config(func(opt *GlobalOption) {
+		opt.RegexpCacheSize = 0
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.RegexpCacheSize = 256
+	})
+	is.True(IsAlpha("abc"))
+	is.True(IsAlpha("def"))
+	is.Equal(1, countString(engine.patterns, rxAlpha.rx.String()))
+	is.Nil(rxCache.get(rxAlpha.rx.String()))
+
+	// the other built-in patterns
+	is.True(IsRGBColor("rgb(1, 2, 3)"))
+	is.Contains(engine.patterns, rxRGBColor.rx.String())
+	is.True(IsPlate("AB12 CDE", "GB"))
+	is.Contains(engine.patterns, platePatterns["GB"].rx.String())
+	_, err := ParseISODuration("PT30M")
+	is.NoError(err)
+	is.Contains(engine.patterns, rxISODuration.rx.String())
+
+	// compile again by the new engine
+	engine2 := &testStrictEngine{}
+	SetRegexpEngine(engine2.compile)
+	is.True(IsAlpha("abc"))
+	is.Equal([]string{rxAlpha.rx.String()}, engine2.patterns)
+	is.Equal(1, countString(engine.patterns, rxAlpha.rx.String()))
+
+	// restore the standard regexp, the cache is cleared
+	SetRegexpEngine(nil)
+	n := len(engine.patterns)
+	is.True(Regexp("abc", `^(?:[a-z]+)$`))
+	is.True(IsAlpha("abc"))
+	is.Len(engine.patterns, n)
+}
+
+func countString(ss []string, s string) (n int) {
+	for _, item := range ss {
+		if item == s {
+			n++
+		}
+	}
+	return
+}
@@ -28,13 +28,19 @@ var validatorValues = map[string]reflect.Value{
 	"min": reflect.ValueOf(Min),
 	"max": reflect.ValueOf(Max),
 	// value check
-	"enum":     reflect.ValueOf(Enum),
-	"notIn":    reflect.ValueOf(NotIn),
-	"between":  reflect.ValueOf(Between),
-	"regexp":   reflect.ValueOf(Regexp),
-	"isEqual":  reflect.ValueOf(IsEqual),
-	"intEqual": reflect.ValueOf(IntEqual),
-	"notEqual": reflect.ValueOf(NotEqual),
+	"enum":            reflect.ValueOf(Enum),
+	"notIn":           reflect.ValueOf(NotIn),
+	"enumIgnoreCase":  reflect.ValueOf(EnumIgnoreCase),
+	"notInIgnoreCase": reflect.ValueOf(NotInIgnoreCase),
+	"between":         reflect.ValueOf(Between),
+	"betweenX":        reflect.ValueOf(BetweenX),
+	"isPercent":       reflect.ValueOf(Percent),
+	"isProbability":   reflect.ValueOf(Probability),
+	"multipleOf":      reflect.ValueOf(MultipleOf),
+	"regexp":          reflect.ValueOf(Regexp),
+	"isEqual":         reflect.ValueOf(IsEqual),
+	"intEqual":        reflect.ValueOf(IntEqual),
+	"notEqual":        reflect.ValueOf(NotEqual),
 	// contains
 	"contains":    reflect.ValueOf(Contains),
 	"notContains": reflect.ValueOf(NotContains),
@@ -58,6 +64,16 @@ var validatorValues = map[string]reflect.Value{
 	"minLength":    reflect.ValueOf(MinLength),
 	"maxLength":    reflect.ValueOf(MaxLength),
 	"stringLength": reflect.ValueOf(StringLength),
+	"fitsVarchar":  reflect.ValueOf(FitsVarchar),
+	"minWords":     reflect.ValueOf(MinWords),
+	"maxWords":     reflect.ValueOf(MaxWords),
+	// email domain
+	"emailMX":            reflect.ValueOf(EmailMX),
+	"notDisposableEmail": reflect.ValueOf(NotDisposableEmail),
+	// payment
+	"creditCard": reflect.ValueOf(CreditCard),
+	"isIBAN":     reflect.ValueOf(IsIBAN),
+	"isBIC":      reflect.ValueOf(IsBIC),
 	// string
 	"isIntString": reflect.ValueOf(IsIntString),
 	// ip
@@ -79,9 +95,16 @@ var validatorValues = map[string]reflect.Value{
 	"isHexColor":  reflect.ValueOf(IsHexColor),
 	"isISBN10":    reflect.ValueOf(IsISBN10),
 	"isISBN13":    reflect.ValueOf(IsISBN13),
+	"isEAN8":      reflect.ValueOf(IsEAN8),
+	"isEAN13":     reflect.ValueOf(IsEAN13),
+	"isUPC":       reflect.ValueOf(IsUPC),
+	"isGTIN14":    reflect.ValueOf(IsGTIN14),
+	"isVIN":       reflect.ValueOf(IsVIN),
+	"isPlate":     reflect.ValueOf(IsPlate),
 	"isJSON":      reflect.ValueOf(IsJSON),
 	"isLatitude":  reflect.ValueOf(IsLatitude),
 	"isLongitude": reflect.ValueOf(IsLongitude),
+	"isGeohash":   reflect.ValueOf(IsGeohash),
 	"isMAC":       reflect.ValueOf(IsMAC),
 	"isMultiByte": reflect.ValueOf(IsMultiByte),
 	"isNumber":    reflect.ValueOf(IsNumber),
@@ -92,6 +115,39 @@ var validatorValues = map[string]reflect.Value{
 	"hasWhitespace":    reflect.ValueOf(HasWhitespace),
 	"isHexadecimal":    reflect.ValueOf(IsHexadecimal),
 	"isPrintableASCII": reflect.ValueOf(IsPrintableASCII),
+	"isLatLngString":   reflect.ValueOf(IsLatLngString),
+	"isGeoJSON":        reflect.ValueOf(IsGeoJSON),
+	"geoJSONType":      reflect.ValueOf(GeoJSONType),
+	"isSafeFilename":   reflect.ValueOf(IsSafeFilename),
+	// ISO 8601 duration and interval
+	"isISO8601Duration": reflect.ValueOf(IsISO8601Duration),
+	"isISO8601Interval": reflect.ValueOf(IsISO8601Interval),
+	// duration
+	"isDuration":  reflect.ValueOf(IsDuration),
+	"minDuration": reflect.ValueOf(MinDuration),
+	"maxDuration": reflect.ValueOf(MaxDuration),
+	// recurrence rule
+	"isRRule": reflect.ValueOf(IsRRule),
+	// money amount
+	"moneyString": reflect.ValueOf(MoneyString),
+	// case format
+	"isCamelCase":  reflect.ValueOf(IsCamelCase),
+	"isPascalCase": reflect.ValueOf(IsPascalCase),
+	"isSnakeCase":  reflect.ValueOf(IsSnakeCase),
+	"isKebabCase":  reflect.ValueOf(IsKebabCase),
+	// text content
+	"noEmoji":        reflect.ValueOf(NoEmoji),
+	"noControlChars": reflect.ValueOf(NoControlChars),
+	"singleLine":     reflect.ValueOf(SingleLine),
+	// text encoding
+	"validUTF8":  reflect.ValueOf(ValidUTF8),
+	"latin1Safe": reflect.ValueOf(Latin1Safe),
+	// token format
+	"token":                  reflect.ValueOf(Token),
+	"isCrockfordBase32":      reflect.ValueOf(IsCrockfordBase32),
+	"isCrockfordBase32Check": reflect.ValueOf(IsCrockfordBase32Check),
+	// rich text
+	"richText": reflect.ValueOf(RichText),
 	//
 	"isRGBColor": reflect.ValueOf(IsRGBColor),
 	"isURL":      reflect.ValueOf(IsURL),
@@ -100,6 +156,8 @@ var validatorValues = map[string]reflect.Value{
 	"isUUID3":    reflect.ValueOf(IsUUID3),
 	"isUUID4":    reflect.ValueOf(IsUUID4),
 	"isUUID5":    reflect.ValueOf(IsUUID5),
+	"isULID":     reflect.ValueOf(IsULID),
+	"isKSUID":    reflect.ValueOf(IsKSUID),
 	// file system
 	"pathExists": reflect.ValueOf(PathExists),
 	"isDirPath":  reflect.ValueOf(IsDirPath),
@@ -113,13 +171,27 @@ var validatorValues = map[string]reflect.Value{
 	//
 	"afterOrEqualDate":  reflect.ValueOf(AfterOrEqualDate),
 	"beforeOrEqualDate": reflect.ValueOf(BeforeOrEqualDate),
+	// time window check
+	"withinBusinessHours": reflect.ValueOf(WithinBusinessHours),
+	"notInDateRanges":     reflect.ValueOf(NotInDateRanges),
 }
 
 // define validator alias name mapping
 var validatorAliases = map[string]string{
 	// alias -> real name
-	"in":    "enum",
-	"range": "between",
+	"in":     "enum",
+	"range":  "between",
+	"rangeX": "betweenX",
+	// number range
+	"percent":     "isPercent",
+	"probability": "isProbability",
+	"step":        "multipleOf",
+	"multiple_of": "multipleOf",
+	// enum ignore case
+	"inIgnoreCase":       "enumIgnoreCase",
+	"in_ignore_case":     "enumIgnoreCase",
+	"enum_ignore_case":   "enumIgnoreCase",
+	"not_in_ignore_case": "notInIgnoreCase",
 	// type
 	"int":     "isInt",
 	"integer": "isInt",
@@ -172,6 +244,13 @@ var validatorAliases = map[string]string{
 	"runeLen":    "stringLength",
 	"rune_len":   "stringLength",
 	"runeLength": "stringLength",
+	// string byte length
+	"fits_varchar": "fitsVarchar",
+	"maxBytes":     "fitsVarchar",
+	"max_bytes":    "fitsVarchar",
+	// words count
+	"min_words": "minWords",
+	"max_words": "maxWords",
 	// string contains
 	"string_contains": "stringContains",
 	"str_contains":    "stringContains",
@@ -181,6 +260,16 @@ var validatorAliases = map[string]string{
 	"endWith":         "endsWith",
 	"end_with":        "endsWith",
 	"ends_with":       "endsWith",
+	// email domain
+	"email_mx":             "emailMX",
+	"not_disposable_email": "notDisposableEmail",
+	// payment
+	"credit_card": "creditCard",
+	"iban":        "isIBAN",
+	"IBAN":        "isIBAN",
+	"bic":         "isBIC",
+	"BIC":         "isBIC",
+	"swift":       "isBIC",
 	// string
 	"ip":         "isIP",
 	"ipv4":       "isIPv4",
@@ -236,12 +325,20 @@ var validatorAliases = map[string]string{
 	"ISBN10":     "isISBN10",
 	"isbn13":     "isISBN13",
 	"ISBN13":     "isISBN13",
+	"ean8":       "isEAN8",
+	"ean13":      "isEAN13",
+	"upc":        "isUPC",
+	"gtin14":     "isGTIN14",
+	"vin":        "isVIN",
+	"VIN":        "isVIN",
+	"plate":      "isPlate",
 	"json":       "isJSON",
 	"JSON":       "isJSON",
 	"lat":        "isLatitude",
 	"latitude":   "isLatitude",
 	"lon":        "isLongitude",
 	"longitude":  "isLongitude",
+	"geohash":    "isGeohash",
 	"mac":        "isMAC",
 	"multiByte":  "isMultiByte",
 	"num":        "isNumber",
@@ -263,6 +360,10 @@ var validatorAliases = map[string]string{
 	"UUID4":      "isUUID4",
 	"uuid5":      "isUUID5",
 	"UUID5":      "isUUID5",
+	"ulid":       "isULID",
+	"ULID":       "isULID",
+	"ksuid":      "isKSUID",
+	"KSUID":      "isKSUID",
 	"cnMobile":   "isCnMobile",
 	"cn_mobile":  "isCnMobile",
 	// file system
@@ -285,6 +386,37 @@ var validatorAliases = map[string]string{
 	"gte_date": "afterOrEqualDate",
 	"lteDate":  "beforeOrEqualDate",
 	"lte_date": "beforeOrEqualDate",
+	// coordinate
+	"latLngString":   "isLatLngString",
+	"lat_lng_string": "isLatLngString",
+	"geoJSON":        "isGeoJSON",
+	"geo_json":       "isGeoJSON",
+	"geo_json_type":  "geoJSONType",
+	// ISO 8601 duration and interval
+	"iso8601Duration":  "isISO8601Duration",
+	"iso8601_duration": "isISO8601Duration",
+	"iso8601Interval":  "isISO8601Interval",
+	"iso8601_interval": "isISO8601Interval",
+	// duration
+	"duration":     "isDuration",
+	"min_duration": "minDuration",
+	"max_duration": "maxDuration",
+	// recurrence rule
+	"rrule":           "isRRule",
+	"recurrenceRule":  "isRRule",
+	"recurrence_rule": "isRRule",
+	// money amount
+	"money_string": "moneyString",
+	// file name
+	"safeFilename":  "isSafeFilename",
+	"safe_filename": "isSafeFilename",
+	// time window
+	"within_business_hours": "withinBusinessHours",
+	"not_in_date_ranges":    "notInDateRanges",
+	// archive file
+	"archive_max_entries": "archiveMaxEntries",
+	"archive_max_size":    "archiveMaxSize",
+	"archive_exts":        "archiveExts",
 	// uploaded file
 	"img":        "isImage",
 	"image":      "isImage",
@@ -302,6 +434,38 @@ var validatorAliases = map[string]string{
 	"gte_field": "gteField",
 	"lt_field":  "ltField",
 	"lte_field": "lteField",
+	// compare with sibling fields
+	"different_from": "differentFrom",
+	"all_different":  "allDifferent",
+	"sum_to":         "sumTo",
+	"excludes_with":  "excludesWith",
+	// similarity with the field
+	"not_similar_to_field": "notSimilarToField",
+	// color contrast with the field
+	"contrast_with_field": "contrastWithField",
+	// case format
+	"camelCase":   "isCamelCase",
+	"camel_case":  "isCamelCase",
+	"pascalCase":  "isPascalCase",
+	"pascal_case": "isPascalCase",
+	"snakeCase":   "isSnakeCase",
+	"snake_case":  "isSnakeCase",
+	"kebabCase":   "isKebabCase",
+	"kebab_case":  "isKebabCase",
+	// text content
+	"no_emoji":         "noEmoji",
+	"no_control_chars": "noControlChars",
+	"single_line":      "singleLine",
+	// text encoding
+	"asciiOnly":   "isASCII",
+	"ascii_only":  "isASCII",
+	"valid_utf8":  "validUTF8",
+	"latin1_safe": "latin1Safe",
+	// token format
+	"crockfordBase32":      "isCrockfordBase32",
+	"crockfordBase32Check": "isCrockfordBase32Check",
+	// rich text
+	"rich_text": "richText",
 	// requiredXXX
 	"required_if":          "requiredIf",
 	"required_unless":      "requiredUnless",
@@ -309,4 +473,8 @@ var validatorAliases = map[string]string{
 	"required_with_all":    "requiredWithAll",
 	"required_without":     "requiredWithout",
 	"required_without_all": "requiredWithoutAll",
+	"required_one_of":      "requiredOneOf",
+	"only_one_of":          "onlyOneOf",
+	"changed_requires":     "changedRequires",
+	"read_only_in_scene":   "readOnlyInScene",
 }
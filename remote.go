@@ -0,0 +1,205 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultRemoteTimeout the default timeout of the remote validator request
+const DefaultRemoteTimeout = 3 * time.Second
+
+// the max number of the cached results of a remote validator
+const maxRemoteCacheSize = 1024
+
+// the max size of the response body read by the default decoder
+const maxRemoteBodySize = 1 << 20
+
+// RemoteValidator the config of the remote HTTP validator. see RegisterRemoteValidator()
+type RemoteValidator struct {
+	// URL the endpoint, the value is POST to it as JSON. eg: {"value": "SAVE10", "args": ["tenant-1"]}
+	URL string
+	// Client the HTTP client. default is http.DefaultClient
+	Client *http.Client
+	// Timeout of the request. default is DefaultRemoteTimeout
+	Timeout time.Duration
+	// CacheTTL cache the results by the value and args. default is 0, not cache
+	CacheTTL time.Duration
+	// PassOnError pass the validation on the request failed(eg: timeout, the 5xx, 401, 429 status). default is false
+	PassOnError bool
+	// Decode interpret the response. the returned error is treated as the request failed.
+	// default: the status 400, 404, 409, 422 are invalid, the other non 2xx status are the request failed,
+	// the 2xx status is valid unless the JSON body is {"valid": false}
+	Decode func(resp *http.Response) (bool, error)
+}
+
+type remoteResult struct {
+	ok      bool
+	expires time.Time
+}
+
+// the status codes of the remote validator response mean the value is invalid
+var remoteInvalidStatus = map[int]bool{
+	http.StatusBadRequest:          true,
+	http.StatusNotFound:            true,
+	http.StatusConflict:            true,
+	http.StatusUnprocessableEntity: true,
+}
+
+// the remote validator runtime, with the results cache
+type remoteChecker struct {
+	RemoteValidator
+	mu    sync.Mutex
+	cache map[string]remoteResult
+}
+
+// RegisterRemoteValidator register the global validator checks the value by the remote HTTP endpoint.
+// eg: check the coupon codes against a separate service. it is marked as the I/O validator, see MarkIOValidators()
+// the request is canceled with the context of the Validation, see Validation.WithContext()
+// Usage:
+// 	validate.RegisterRemoteValidator("coupon", validate.RemoteValidator{
+// 		URL:      "http://coupon-service/check",
+// 		Timeout:  time.Second,
+// 		CacheTTL: time.Minute,
+// 	})
+//
+// 	v.StringRule("coupon_code", "coupon:tenant-1")
+func RegisterRemoteValidator(name string, rv RemoteValidator) {
+	if rv.URL == "" {
+		panicf("the URL of the remote validator '%s' is required", name)
+	}
+
+	c := &remoteChecker{RemoteValidator: rv, cache: make(map[string]remoteResult)}
+	AddValidator(name, c.check)
+	validatorMetas[name].ctxFunc = func(ctx context.Context, val interface{}, args []interface{}) bool {
+		return c.checkContext(ctx, val, args2strings(args))
+	}
+	MarkIOValidators(name)
+}
+
+func (c *remoteChecker) check(val interface{}, args ...string) bool {
+	return c.checkContext(context.Background(), val, args)
+}
+
+func (c *remoteChecker) checkContext(ctx context.Context, val interface{}, args []string) bool {
+	key := fmt.Sprintf("%#v|%q", val, args)
+	if c.CacheTTL > 0 {
+		if ok, has := c.cached(key); has {
+			return ok
+		}
+	}
+
+	ok, err := c.request(ctx, val, args)
+	if err != nil {
+		return c.PassOnError
+	}
+
+	if c.CacheTTL > 0 {
+		c.save(key, ok)
+	}
+	return ok
+}
+
+func (c *remoteChecker) request(ctx context.Context, val interface{}, args []string) (bool, error) {
+	body, err := json.Marshal(map[string]interface{}{"value": val, "args": args})
+	if err != nil {
+		return false, err
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRemoteTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if c.Decode != nil {
+		return c.Decode(resp)
+	}
+	return decodeRemoteResponse(resp)
+}
+
+// the default decoder of the remote validator response
+func decodeRemoteResponse(resp *http.Response) (bool, error) {
+	switch {
+	case remoteInvalidStatus[resp.StatusCode]:
+		return false, nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		// eg: 401, 403, 429, 5xx. the value cannot be checked
+		return false, fmt.Errorf("the remote validator response the status %d", resp.StatusCode)
+	}
+
+	bs, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRemoteBodySize))
+	if err != nil {
+		return false, err
+	}
+
+	var ret struct {
+		Valid *bool `json:"valid"`
+	}
+	if len(bytes.TrimSpace(bs)) > 0 && json.Unmarshal(bs, &ret) == nil && ret.Valid != nil {
+		return *ret.Valid, nil
+	}
+	return true, nil
+}
+
+func (c *remoteChecker) cached(key string) (ok, has bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ret, has := c.cache[key]
+	if !has {
+		return false, false
+	}
+
+	if time.Now().After(ret.expires) {
+		delete(c.cache, key)
+		return false, false
+	}
+	return ret.ok, true
+}
+
+func (c *remoteChecker) save(key string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// remove the expired results on the cache is full
+	if len(c.cache) >= maxRemoteCacheSize {
+		now := time.Now()
+		for k, ret := range c.cache {
+			if now.After(ret.expires) {
+				delete(c.cache, k)
+			}
+		}
+
+		if len(c.cache) >= maxRemoteCacheSize {
+			c.cache = make(map[string]remoteResult)
+		}
+	}
+
+	c.cache[key] = remoteResult{ok: ok, expires: time.Now().Add(c.CacheTTL)}
+}
@@ -0,0 +1,138 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterRemoteValidator(t *testing.T) {
+	is := assert.New(t)
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		var body struct {
+			Value string   `json:"value"`
+			Args  []string `json:"args"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch body.Value {
+		case "SAVE10":
+			if len(body.Args) > 0 && body.Args[0] != "tenant-1" {
+				_, _ = w.Write([]byte(`{"valid": false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"valid": true}`))
+		case "EXPIRED":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case "AUTH":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "LIMITED":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "SLOW":
+			time.Sleep(100 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	is.PanicsWithValue("validate: the URL of the remote validator 'coupon' is required", func() {
+		RegisterRemoteValidator("coupon", RemoteValidator{})
+	})
+
+	RegisterRemoteValidator("coupon", RemoteValidator{
+		URL:      srv.URL,
+		Timeout:  50 * time.Millisecond,
+		CacheTTL: time.Minute,
+	})
+	defer func() {
+		delete(ioValidators, "coupon")
+	}()
+	is.True(isIOValidator("coupon"))
+
+	v := Map(M{"code": "SAVE10"})
+	v.StringRule("code", "required|coupon:tenant-1")
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	// use the cached result
+	v = Map(M{"code": "SAVE10"})
+	v.StringRule("code", "coupon:tenant-1")
+	is.True(v.Validate())
+	is.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	tests := map[string]string{
+		"SAVE10":  "tenant-2",
+		"EXPIRED": "tenant-1",
+		"SLOW":    "tenant-1",
+		"OTHER":   "tenant-1",
+	}
+	for code, tenant := range tests {
+		v = Map(M{"code": code})
+		v.StringRule("code", "coupon:"+tenant)
+		is.False(v.Validate(), code)
+	}
+
+	// pass on the request failed, the custom decoder
+	RegisterRemoteValidator("coupon2", RemoteValidator{
+		URL:         srv.URL,
+		PassOnError: true,
+		Decode: func(resp *http.Response) (bool, error) {
+			if resp.StatusCode == http.StatusInternalServerError {
+				return false, http.ErrHandlerTimeout
+			}
+			return resp.StatusCode == http.StatusOK, nil
+		},
+	})
+	defer func() {
+		delete(ioValidators, "coupon2")
+	}()
+
+	v = Map(M{"code": "OTHER"})
+	v.StringRule("code", "coupon2")
+	is.True(v.Validate())
+
+	v = Map(M{"code": "EXPIRED"})
+	v.StringRule("code", "coupon2")
+	is.False(v.Validate())
+
+	// the status 401, 429 ... are the request failed, not the invalid value
+	RegisterRemoteValidator("coupon3", RemoteValidator{URL: srv.URL, PassOnError: true})
+	defer func() {
+		delete(ioValidators, "coupon3")
+	}()
+
+	for _, code := range []string{"AUTH", "LIMITED", "OTHER"} {
+		v = Map(M{"code": code})
+		v.StringRule("code", "coupon3")
+		is.True(v.Validate(), code)
+	}
+	v = Map(M{"code": "EXPIRED"})
+	v.StringRule("code", "coupon3")
+	is.False(v.Validate())
+
+	// the request is canceled by the context of the validation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n := atomic.LoadInt32(&calls)
+	v = Map(M{"code": "SAVE10"})
+	v.WithContext(ctx).StringRule("code", "coupon:tenant-3")
+	is.False(v.Validate())
+	is.Equal(n, atomic.LoadInt32(&calls))
+
+	// the prefetch use the context too
+	v = Map(M{"code": "EXPIRED"})
+	v.PrefetchWorkers = 2
+	v.WithContext(ctx).StringRule("code", "coupon3")
+	is.True(v.Validate())
+	is.Equal(n, atomic.LoadInt32(&calls))
+}
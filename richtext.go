@@ -0,0 +1,230 @@
+package validate
+
+import (
+	"html"
+	"strings"
+	"sync"
+)
+
+// RichTextPolicy the allowlist policy of the HTML tags and attributes for the rich text.
+type RichTextPolicy struct {
+	// Tags the allowed tags and the attributes of each tag. eg: {"a": {"href", "title"}, "p": nil}
+	Tags map[string][]string
+	// GlobalAttrs the attributes allowed on all the allowed tags. eg: "class"
+	GlobalAttrs []string
+	// URLSchemes the allowed schemes of the URL attributes(href, src ...). default is: http, https, mailto
+	URLSchemes []string
+}
+
+var (
+	rxHTMLTag  = mustCompilePattern(`(?s)<!--.*?-->|<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:\s+[^\s/>"'=]+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'>]+))?)*)\s*(/?)>`)
+	rxHTMLAttr = mustCompilePattern(`([^\s/>"'=]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+)
+
+// the content of the elements will be removed together if the tag is not allowed.
+const rawContentTags = "|script|style|iframe|object|embed|template|textarea|title|noscript|"
+
+// the attributes value is URL, will check the scheme.
+const urlAttrs = "|href|src|cite|action|formaction|background|poster|"
+
+var defaultURLSchemes = []string{"http", "https", "mailto"}
+
+var basicTags = map[string][]string{
+	"a": {"href", "title"}, "b": nil, "i": nil, "u": nil, "em": nil, "strong": nil,
+	"p": nil, "br": nil, "ul": nil, "ol": nil, "li": nil, "blockquote": nil, "code": nil, "pre": nil,
+}
+
+// the named rich text policies for the validator "richText"
+var richTextPolicyMux sync.RWMutex
+
+var richTextPolicies = map[string]*RichTextPolicy{
+	// plain text, not allow any tag
+	"strict": {},
+	// the basic inline formatting, links and lists. eg: comments
+	"basic": {Tags: basicTags},
+	// the user generated content. eg: CMS articles
+	"ugc": {Tags: mergeTags(basicTags, map[string][]string{
+		"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+		"hr": nil, "s": nil, "del": nil, "sub": nil, "sup": nil, "span": nil, "div": nil,
+		"img":   {"src", "alt", "title", "width", "height"},
+		"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+	})},
+}
+
+func mergeTags(ms ...map[string][]string) map[string][]string {
+	tags := make(map[string][]string)
+	for _, m := range ms {
+		for tag, attrs := range m {
+			tags[tag] = attrs
+		}
+	}
+	return tags
+}
+
+// AddRichTextPolicy add or override the named policy for the validator "richText" and the filter "sanitizeRichText"
+// Usage:
+// 	validate.AddRichTextPolicy("article", &validate.RichTextPolicy{
+// 		Tags: map[string][]string{"p": nil, "a": {"href"}, "img": {"src", "alt"}},
+// 	})
+func AddRichTextPolicy(name string, policy *RichTextPolicy) {
+	richTextPolicyMux.Lock()
+	richTextPolicies[name] = policy
+	richTextPolicyMux.Unlock()
+}
+
+func richTextPolicy(name string) *RichTextPolicy {
+	richTextPolicyMux.RLock()
+	policy, ok := richTextPolicies[name]
+	richTextPolicyMux.RUnlock()
+	if !ok {
+		panicf("the rich text policy '%s' is not exists", name)
+	}
+	return policy
+}
+
+// RichText check the HTML string only contains the tags and attributes allowed by the policy.
+// the comments and the unsafe URLs(eg: "javascript:...") are not allowed.
+// Usage:
+// 	v.StringRule("content", "richText:ugc")
+func RichText(val interface{}, policy string) bool {
+	str, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	_, clean := sanitizeHTML(str, richTextPolicy(policy), writeHTMLText)
+	return clean
+}
+
+// SanitizeRichText remove the tags, attributes and comments that are not allowed by the policy.
+// the content of the disallowed "script", "style" ... elements are also removed.
+// Usage:
+// 	v.FilterRule("content", "sanitizeRichText:basic")
+func SanitizeRichText(s, policy string) string {
+	out, _ := sanitizeHTML(s, richTextPolicy(policy), writeHTMLText)
+	return out
+}
+
+// StripTags remove all the HTML tags and comments, the content of the "script", "style" ... elements are also removed.
+// the text is not be escaped. eg: "<p>a &amp; b</p>" => "a &amp; b"
+func StripTags(s string) string {
+	out, _ := sanitizeHTML(s, &RichTextPolicy{}, func(sb *strings.Builder, text string) bool {
+		sb.WriteString(text)
+		return true
+	})
+	return out
+}
+
+// sanitize the HTML string by the policy, clean is true on nothing need to remove.
+// the writeText func write the text between the tags, return false on the text is not clean.
+func sanitizeHTML(s string, p *RichTextPolicy, writeText func(sb *strings.Builder, text string) bool) (out string, clean bool) {
+	var sb strings.Builder
+	var skipTag string // skip the content until the end tag
+
+	clean = true
+	last := 0
+	// the tokenizer need the submatch indexes, so always use the standard regexp. see builtinPattern
+	for _, m := range rxHTMLTag.rx.FindAllStringSubmatchIndex(s, -1) {
+		if skipTag == "" {
+			clean = writeText(&sb, s[last:m[0]]) && clean
+		}
+		last = m[1]
+
+		// is comment
+		if m[4] < 0 {
+			clean = false
+			continue
+		}
+
+		isEnd := m[3] > m[2]
+		name := strings.ToLower(s[m[4]:m[5]])
+		if skipTag != "" {
+			if isEnd && name == skipTag {
+				skipTag = ""
+			}
+			continue
+		}
+
+		allowedAttrs, ok := p.Tags[name]
+		if !ok {
+			clean = false
+			if !isEnd && strings.Contains(rawContentTags, "|"+name+"|") {
+				skipTag = name
+			}
+			continue
+		}
+
+		if isEnd {
+			sb.WriteString("</" + name + ">")
+			continue
+		}
+
+		sb.WriteString("<" + name)
+		for _, am := range rxHTMLAttr.rx.FindAllStringSubmatch(s[m[6]:m[7]], -1) {
+			attr := strings.ToLower(am[1])
+			if !Enum(attr, allowedAttrs) && !Enum(attr, p.GlobalAttrs) {
+				clean = false
+				continue
+			}
+
+			val := html.UnescapeString(am[2] + am[3] + am[4])
+			if strings.Contains(urlAttrs, "|"+attr+"|") && !p.safeURL(val) {
+				clean = false
+				continue
+			}
+			sb.WriteString(" " + attr + `="` + html.EscapeString(val) + `"`)
+		}
+
+		if m[9] > m[8] {
+			sb.WriteString(" /")
+		}
+		sb.WriteByte('>')
+	}
+
+	if skipTag == "" {
+		clean = writeText(&sb, s[last:]) && clean
+	}
+	return sb.String(), clean
+}
+
+// write the text and escape the "<". return false on has the broken tag. eg: "<img src=x"
+func writeHTMLText(sb *strings.Builder, text string) (ok bool) {
+	ok = true
+	for i := strings.IndexByte(text, '<'); i >= 0; i = strings.IndexByte(text, '<') {
+		if i+1 < len(text) && (isASCIILetter(text[i+1]) || strings.IndexByte("/!?", text[i+1]) >= 0) {
+			ok = false
+		}
+
+		sb.WriteString(text[:i] + "&lt;")
+		text = text[i+1:]
+	}
+
+	sb.WriteString(text)
+	return
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// check the URL scheme is allowed, the relative URL is allowed.
+func (p *RichTextPolicy) safeURL(val string) bool {
+	// the browsers ignore the whitespace and control chars in the scheme. eg: "java\tscript:"
+	val = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, val)
+
+	pos := strings.IndexByte(val, ':')
+	if pos < 0 || strings.ContainsAny(val[:pos], "/?#") {
+		return true
+	}
+
+	schemes := p.URLSchemes
+	if len(schemes) == 0 {
+		schemes = defaultURLSchemes
+	}
+	return Enum(strings.ToLower(val[:pos]), schemes)
+}
@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRichText(t *testing.T) {
+	is := assert.New(t)
+
+	for _, s := range []string{
+		"plain text",
+		"1 < 2 &amp; 3 > 2",
+		`<p>hello <b>world</b></p><br/>`,
+		`<a href="https://example.com" title='home'>link</a>`,
+		`<a href="/docs?id=1">relative</a>`,
+		`<UL><LI>item</LI></UL>`,
+	} {
+		is.True(RichText(s, "basic"), s)
+	}
+
+	for _, s := range []string{
+		`<script>alert(1)</script>`,
+		`<p onclick="alert(1)">text</p>`,
+		`<a href="javascript:alert(1)">x</a>`,
+		`<a href="java&#x09;script:alert(1)">x</a>`,
+		`<img src="a.png">`,
+		`<!-- comment -->text`,
+		`<p>broken <img src=x onerror=alert(1)//`,
+	} {
+		is.False(RichText(s, "basic"), s)
+	}
+
+	is.False(RichText(123, "basic"))
+	is.False(RichText("<b>bold</b>", "strict"))
+	is.True(RichText(`<h2>title</h2><img src="https://example.com/a.png" alt="a">`, "ugc"))
+	is.Panics(func() {
+		RichText("text", "not-exists")
+	})
+}
+
+func TestSanitizeRichText(t *testing.T) {
+	is := assert.New(t)
+
+	tests := map[string]string{
+		`<p onclick="alert(1)">hi <b>all</b></p>`:        `<p>hi <b>all</b></p>`,
+		`<div>text<script>alert("<b>")</script></div>`:   `text`,
+		`<a href="javascript:alert(1)" title=t>link</a>`: `<a title="t">link</a>`,
+		`<a href='/x?a=1&b="2"'>x</a>`:                   `<a href="/x?a=1&amp;b=&#34;2&#34;">x</a>`,
+		`a<!-- secret -->b<br/>`:                         `ab<br />`,
+		`<p>broken <img src=x onerror=alert(1)//`:        `<p>broken &lt;img src=x onerror=alert(1)//`,
+	}
+	for in, want := range tests {
+		is.Equal(want, SanitizeRichText(in, "basic"), in)
+	}
+
+	AddRichTextPolicy("article", &RichTextPolicy{
+		Tags:        map[string][]string{"p": nil, "img": {"src"}},
+		GlobalAttrs: []string{"class"},
+		URLSchemes:  []string{"https", "data"},
+	})
+	is.Equal(`<p class="lead"><img src="data:image/png;base64,AA==" /></p>`,
+		SanitizeRichText(`<p class="lead" id="x"><img src="data:image/png;base64,AA==" /></p>`, "article"))
+
+	v := Map(M{"content": `<p onclick="alert(1)">hello</p>`, "comment": `<b>hi</b>`})
+	v.FilterRule("content", "sanitizeRichText:article")
+	v.StringRules(MS{"content": "required|richText:article", "comment": "rich_text:strict"})
+	is.False(v.Validate())
+	is.Equal("<p>hello</p>", v.Filtered("content"))
+	is.Equal("comment value contains the HTML content not allowed by the policy strict", v.Errors.One())
+}
+
+func TestStripTags(t *testing.T) {
+	is := assert.New(t)
+
+	is.Equal("hello world", StripTags(`<p class="a">hello <b>world</b></p>`))
+	is.Equal("a &amp; b, 1 < 2", StripTags(`<!-- c -->a &amp; b<br/>, 1 < 2`))
+	is.Equal("text", StripTags(`text<script>alert("<b>")</script><style>p{}</style>`))
+
+	v := Map(M{"bio": "  <p>I am\n\n <b>inhere</b></p>  "})
+	v.FilterRule("bio", "stripTags|collapseWhitespace|escapeHTML")
+	v.StringRule("bio", "required")
+	is.True(v.Validate())
+	is.Equal("I am inhere", v.SafeVal("bio"))
+}
@@ -0,0 +1,361 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the recurrence frequencies, from the smallest to the largest.
+const (
+	freqSecondly = iota
+	freqMinutely
+	freqHourly
+	freqDaily
+	freqWeekly
+	freqMonthly
+	freqYearly
+)
+
+var rruleFreqs = map[string]int{
+	"SECONDLY": freqSecondly,
+	"MINUTELY": freqMinutely,
+	"HOURLY":   freqHourly,
+	"DAILY":    freqDaily,
+	"WEEKLY":   freqWeekly,
+	"MONTHLY":  freqMonthly,
+	"YEARLY":   freqYearly,
+}
+
+const rruleWeekdays = "|MO|TU|WE|TH|FR|SA|SU|"
+
+// the value range of the numeric BYxxx parts. the signed parts allow the negative value, but not zero.
+var rruleByRanges = map[string]struct {
+	min, max int
+	signed   bool
+}{
+	"BYSECOND":   {0, 60, false},
+	"BYMINUTE":   {0, 59, false},
+	"BYHOUR":     {0, 23, false},
+	"BYMONTH":    {1, 12, false},
+	"BYMONTHDAY": {1, 31, true},
+	"BYYEARDAY":  {1, 366, true},
+	"BYWEEKNO":   {1, 53, true},
+	"BYSETPOS":   {1, 366, true},
+}
+
+// the parsed iCalendar(RFC 5545) recurrence rule
+type rrule struct {
+	freq     int
+	interval int
+	count    int
+	// the number of the items in each BYxxx part
+	byParts map[string]int
+	// has the numbered weekday in the BYDAY. eg: "1MO", "-1FR"
+	numberedDay bool
+
+	until, start        time.Time
+	untilDate, untilUTC bool
+	hasStart            bool
+	startDate, floated  bool
+}
+
+// IsRRule check the value is a valid iCalendar(RFC 5545) recurrence rule. eg: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"
+//
+// the "RRULE:" prefix is optional, and can be with the "DTSTART" line for check the UNTIL coherence.
+// eg: "DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;UNTIL=20240131T090000Z"
+//
+// the optional maxCount limit the number of the occurrences: the rule must be with the COUNT or UNTIL,
+// and the occurrences until the UNTIL are estimated(the upper bound) on the DTSTART is given.
+// Usage:
+// 	v.StringRule("repeat", "rrule:100")
+func IsRRule(val interface{}, maxCount ...int) bool {
+	str, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	r, err := parseRRule(str)
+	if err != nil {
+		return false
+	}
+
+	if len(maxCount) == 0 || maxCount[0] <= 0 {
+		return true
+	}
+
+	if r.count > 0 {
+		return r.count <= maxCount[0]
+	}
+	// unbounded
+	if r.until.IsZero() {
+		return false
+	}
+	return !r.hasStart || r.estimateCount() <= int64(maxCount[0])
+}
+
+func parseRRule(s string) (*rrule, error) {
+	r := &rrule{interval: 1, byParts: make(map[string]int)}
+	lines := strings.Split(strings.TrimSpace(strings.Replace(s, "\r\n", "\n", -1)), "\n")
+
+	var rule string
+	for _, line := range lines {
+		upper := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(upper, "DTSTART"):
+			if err := r.parseStart(line[len("DTSTART"):]); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(upper, "RRULE:"):
+			rule = line[len("RRULE:"):]
+		case len(lines) == 1:
+			rule = line
+		default:
+			return nil, fmt.Errorf("invalid recurrence rule line %q", line)
+		}
+	}
+
+	if err := r.parseRule(rule); err != nil {
+		return nil, err
+	}
+	return r, r.check()
+}
+
+// parse the DTSTART value. eg: ":20240101T090000Z", ";TZID=Europe/Berlin:20240101T090000", ";VALUE=DATE:20240101"
+func (r *rrule) parseStart(s string) (err error) {
+	pos := strings.IndexByte(s, ':')
+	if pos < 0 || pos > 0 && s[0] != ';' {
+		return fmt.Errorf("invalid DTSTART %q", s)
+	}
+
+	loc := time.UTC
+	for _, param := range strings.Split(s[:pos], ";")[1:] {
+		if strings.HasPrefix(strings.ToUpper(param), "TZID=") {
+			if loc, err = time.LoadLocation(param[len("TZID="):]); err != nil {
+				return err
+			}
+		}
+	}
+
+	var utc bool
+	r.hasStart = true
+	r.start, r.startDate, utc, err = parseICalTime(s[pos+1:], loc)
+	// the time without the "Z" and TZID is the floating time
+	r.floated = !utc && loc == time.UTC
+	return
+}
+
+func (r *rrule) parseRule(s string) (err error) {
+	seen := make(map[string]bool)
+	hasFreq := false
+
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		key := strings.ToUpper(strings.TrimSpace(kv[0]))
+		if len(kv) != 2 || kv[1] == "" || seen[key] {
+			return fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		seen[key] = true
+
+		val := strings.ToUpper(kv[1])
+		switch key {
+		case "FREQ":
+			r.freq, hasFreq = rruleFreqs[val]
+		case "INTERVAL":
+			r.interval, err = parsePositiveInt(val)
+		case "COUNT":
+			r.count, err = parsePositiveInt(val)
+		case "UNTIL":
+			r.until, r.untilDate, r.untilUTC, err = parseICalTime(val, time.UTC)
+		case "WKST":
+			if !strings.Contains(rruleWeekdays, "|"+val+"|") {
+				err = fmt.Errorf("invalid WKST %q", val)
+			}
+		case "BYDAY":
+			err = r.parseByDay(val)
+		default:
+			if strings.HasPrefix(key, "X-") {
+				continue
+			}
+
+			rg, ok := rruleByRanges[key]
+			if !ok {
+				return fmt.Errorf("unknown recurrence rule part %q", key)
+			}
+
+			items := strings.Split(val, ",")
+			for _, item := range items {
+				n, e := strconv.Atoi(item)
+				if rg.signed && n < 0 {
+					n = -n
+				}
+				if e != nil || n < rg.min || n > rg.max || !rg.signed && item[0] == '+' {
+					return fmt.Errorf("invalid %s value %q", key, item)
+				}
+			}
+			r.byParts[key] = len(items)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if !hasFreq {
+		return fmt.Errorf("the recurrence rule FREQ is required")
+	}
+	return nil
+}
+
+// parse the BYDAY value. eg: "MO,WE", "1MO,-1FR"
+func (r *rrule) parseByDay(val string) error {
+	items := strings.Split(val, ",")
+	for _, item := range items {
+		if len(item) < 2 || !strings.Contains(rruleWeekdays, "|"+item[len(item)-2:]+"|") {
+			return fmt.Errorf("invalid BYDAY value %q", item)
+		}
+
+		if num := item[:len(item)-2]; num != "" {
+			n, err := strconv.Atoi(num)
+			if n < 0 {
+				n = -n
+			}
+			if err != nil || n < 1 || n > 53 {
+				return fmt.Errorf("invalid BYDAY value %q", item)
+			}
+			r.numberedDay = true
+		}
+	}
+
+	r.byParts["BYDAY"] = len(items)
+	return nil
+}
+
+// check the coherence of the parts. see RFC 5545 3.3.10
+func (r *rrule) check() error {
+	if r.count > 0 && !r.until.IsZero() {
+		return fmt.Errorf("the COUNT and UNTIL cannot be used together")
+	}
+
+	if r.numberedDay && (r.freq < freqMonthly || r.byParts["BYWEEKNO"] > 0) {
+		return fmt.Errorf("the numbered BYDAY is only allowed with MONTHLY or YEARLY(without BYWEEKNO)")
+	}
+	if r.byParts["BYMONTHDAY"] > 0 && r.freq == freqWeekly {
+		return fmt.Errorf("the BYMONTHDAY is not allowed with WEEKLY")
+	}
+	if r.byParts["BYYEARDAY"] > 0 && r.freq >= freqDaily && r.freq <= freqMonthly {
+		return fmt.Errorf("the BYYEARDAY is not allowed with DAILY, WEEKLY and MONTHLY")
+	}
+	if r.byParts["BYWEEKNO"] > 0 && r.freq != freqYearly {
+		return fmt.Errorf("the BYWEEKNO is only allowed with YEARLY")
+	}
+	if r.byParts["BYSETPOS"] > 0 && len(r.byParts) == 1 {
+		return fmt.Errorf("the BYSETPOS must be used with another BYxxx part")
+	}
+
+	if r.until.IsZero() || !r.hasStart {
+		return nil
+	}
+
+	// the UNTIL must be same value type as the DTSTART, and be UTC time if the DTSTART is not floating.
+	if r.untilDate != r.startDate || !r.startDate && r.untilUTC == r.floated {
+		return fmt.Errorf("the UNTIL value type is not match the DTSTART")
+	}
+	if r.until.Before(r.start) {
+		return fmt.Errorf("the UNTIL must not be before the DTSTART")
+	}
+	return nil
+}
+
+// estimate the upper bound of the number of the occurrences from the DTSTART to the UNTIL.
+func (r *rrule) estimateCount() int64 {
+	start, until := r.start, r.until.In(r.start.Location())
+	span := until.Sub(start)
+
+	var n int64
+	switch r.freq {
+	case freqYearly:
+		n = int64(until.Year() - start.Year())
+	case freqMonthly:
+		n = int64((until.Year()-start.Year())*12 + int(until.Month()-start.Month()))
+	case freqWeekly:
+		n = int64(span / (7 * 24 * time.Hour))
+	case freqDaily:
+		n = int64(span / (24 * time.Hour))
+	case freqHourly:
+		n = int64(span / time.Hour)
+	case freqMinutely:
+		n = int64(span / time.Minute)
+	default:
+		n = int64(span / time.Second)
+	}
+
+	periods := n/int64(r.interval) + 1
+	return periods * r.perPeriod()
+}
+
+// the max number of the occurrences in each period, by the BYxxx parts that expand the period.
+func (r *rrule) perPeriod() int64 {
+	num := int64(1)
+	expand := func(part string, minFreq int, times int) {
+		if c := r.byParts[part]; c > 0 && r.freq >= minFreq {
+			num *= int64(c * times)
+		}
+	}
+
+	expand("BYSECOND", freqMinutely, 1)
+	expand("BYMINUTE", freqHourly, 1)
+	expand("BYHOUR", freqDaily, 1)
+
+	if r.freq == freqYearly {
+		expand("BYMONTH", freqYearly, 1)
+		expand("BYWEEKNO", freqYearly, 1)
+		expand("BYYEARDAY", freqYearly, 1)
+		if r.byParts["BYMONTH"] > 0 {
+			expand("BYMONTHDAY", freqYearly, 1)
+		} else {
+			expand("BYMONTHDAY", freqYearly, 12)
+		}
+	} else {
+		expand("BYMONTHDAY", freqMonthly, 1)
+	}
+
+	// the weekday occurs 5 times in a month and 53 times in a year at most
+	switch {
+	case r.numberedDay || r.freq == freqWeekly || r.byParts["BYWEEKNO"] > 0:
+		expand("BYDAY", freqWeekly, 1)
+	case r.freq == freqMonthly || r.byParts["BYMONTH"] > 0:
+		expand("BYDAY", freqMonthly, 5)
+	default:
+		expand("BYDAY", freqYearly, 53)
+	}
+
+	if c := int64(r.byParts["BYSETPOS"]); c > 0 && c < num {
+		num = c
+	}
+	return num
+}
+
+// parse the iCalendar DATE or DATE-TIME value. eg: "20240101", "20240101T090000", "20240101T090000Z"
+func parseICalTime(s string, loc *time.Location) (t time.Time, isDate, isUTC bool, err error) {
+	switch {
+	case len(s) == 8:
+		isDate = true
+		t, err = time.ParseInLocation("20060102", s, loc)
+	case len(s) == 16 && s[15] == 'Z':
+		isUTC = true
+		t, err = time.Parse("20060102T150405Z", s)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", s, loc)
+	}
+	return
+}
+
+func parsePositiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || s[0] == '+' {
+		return 0, fmt.Errorf("invalid positive integer %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRRule(t *testing.T) {
+	is := assert.New(t)
+
+	for _, s := range []string{
+		"FREQ=DAILY",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR;COUNT=10",
+		"FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20241231",
+		"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		"FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1;WKST=SU",
+		"FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO;X-NAME=test",
+		"freq=hourly;byminute=0,30",
+		"DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;UNTIL=20240131T090000Z",
+		"DTSTART;VALUE=DATE:20240101\r\nRRULE:FREQ=DAILY;UNTIL=20240131",
+		"DTSTART;TZID=Europe/Berlin:20240101T090000\nRRULE:FREQ=DAILY;UNTIL=20240131T080000Z",
+	} {
+		is.True(IsRRule(s), s)
+	}
+
+	for _, s := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=FORTNIGHTLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;INTERVAL=-1",
+		"FREQ=DAILY;COUNT=5;UNTIL=20241231",
+		"FREQ=DAILY;UNTIL=2024-12-31",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYYEARDAY=100",
+		"FREQ=MONTHLY;BYWEEKNO=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=YEARLY;BYWEEKNO=1;BYDAY=1MO",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;UNKNOWN=1",
+		"FREQ=DAILY;",
+		"DTSTART:20240101\nRRULE:FREQ=DAILY;UNTIL=20240131T090000Z",
+		"DTSTART:20240101T090000\nRRULE:FREQ=DAILY;UNTIL=20240131T090000Z",
+		"DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;UNTIL=20231231T090000Z",
+		"DTSTART;TZID=Not/Exists:20240101T090000\nRRULE:FREQ=DAILY",
+		"EXDATE:20240102\nRRULE:FREQ=DAILY",
+	} {
+		is.False(IsRRule(s), s)
+	}
+	is.False(IsRRule(123))
+
+	// bound the expansion count
+	is.True(IsRRule("FREQ=DAILY;COUNT=100", 100))
+	is.False(IsRRule("FREQ=DAILY;COUNT=101", 100))
+	is.False(IsRRule("FREQ=DAILY", 100))
+	is.True(IsRRule("FREQ=DAILY;UNTIL=20991231", 100))
+	is.True(IsRRule("DTSTART:20240101\nRRULE:FREQ=DAILY;UNTIL=20240131", 31))
+	is.False(IsRRule("DTSTART:20240101\nRRULE:FREQ=DAILY;UNTIL=20240131", 30))
+	is.True(IsRRule("DTSTART:20240101\nRRULE:FREQ=DAILY;INTERVAL=2;UNTIL=20240131", 16))
+	// 12 months * 5 weekdays * 5 weeks
+	is.True(IsRRule("DTSTART:20240101\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20241231", 300))
+	is.False(IsRRule("DTSTART:20240101\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20241231", 299))
+	is.True(IsRRule("DTSTART:20240101\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;UNTIL=20241231", 12))
+
+	v := Map(M{"repeat": "FREQ=WEEKLY;BYDAY=MO", "remind": "FREQ=HOURLY;COUNT=500"})
+	v.StringRules(MS{"repeat": "rrule", "remind": "recurrence_rule:100"})
+	is.False(v.Validate())
+	is.Equal("remind value must be a valid recurrence rule(RRULE)", v.Errors.One())
+	is.NotContains(v.Errors, "repeat")
+}
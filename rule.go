@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"math"
 	"strings"
 )
 
@@ -31,6 +32,8 @@ type Rule struct {
 	// 	"field.validator": "error message",
 	// }
 	messages map[string]string
+	// description of the rule constraint. can be included in the error message
+	description string
 	// validator name, allow multi validators. eg "min", "range", "required"
 	validator string
 	// arguments for the validator
@@ -121,12 +124,53 @@ func (r *Rule) SetMessages(msgMap MS) *Rule {
 	return r
 }
 
+// SetDescription set the description of the rule constraint.
+// Usage:
+// 	v.AddRule("name", "minLen", 3).SetDescription("Username shown on your public profile; 3-32 chars")
+func (r *Rule) SetDescription(desc string) *Rule {
+	r.description = desc
+	return r
+}
+
+// Description get the description of the rule
+func (r *Rule) Description() string {
+	return r.description
+}
+
 // Fields field names list
 func (r *Rule) Fields() []string {
 	return r.fields
 }
 
-func (r *Rule) errorMessage(field, validator string, v *Validation) (msg string) {
+// the reason of the failed validator, the message of the key replace the default message of the validator.
+type failReason struct {
+	validator string
+	key       string
+	args      []interface{}
+}
+
+func (r *Rule) errorMessage(field, validator string, v *Validation) string {
+	msg := r.buildMessage(field, validator, v)
+	if !v.ErrorDescription {
+		return msg
+	}
+
+	desc := r.description
+	if desc == "" {
+		desc = v.descriptions[field]
+	}
+
+	if desc != "" {
+		return msg + " (" + desc + ")"
+	}
+	return msg
+}
+
+func (r *Rule) buildMessage(field, validator string, v *Validation) (msg string) {
+	// take the fail reason of the validator
+	reason := v.failReason
+	v.failReason = nil
+
 	if r.messages != nil {
 		var ok bool
 		// use full key. "field.validator"
@@ -144,10 +188,40 @@ func (r *Rule) errorMessage(field, validator string, v *Validation) (msg string)
 		return r.message
 	}
 
+	// the message of the fail reason. eg: "passwordMinLength"
+	name := ValidatorName(validator)
+	customized := v.trans.isCustomized(name, field) || name != validator && v.trans.isCustomized(validator, field)
+	if reason != nil && reason.validator == name && !customized {
+		return v.trans.Message(reason.key, field, reason.args...)
+	}
+
 	// built in error messages
 	return v.trans.Message(validator, field, r.arguments...)
 }
 
+// the max number of the indexes in the aggregated error message.
+const maxAggregateIndexes = 10
+
+// build the aggregated error message for the failed elements of the wildcard field.
+// eg: "17 elements failed minLen:3 (indexes 2,5,...)"
+func (r *Rule) aggregateMessage(pattern string, failed []string, v *Validation) string {
+	indexes := make([]string, 0, maxAggregateIndexes+1)
+	for i, field := range failed {
+		if i == maxAggregateIndexes {
+			indexes = append(indexes, "...")
+			break
+		}
+		indexes = append(indexes, wildcardKeys(pattern, field))
+	}
+
+	ruleStr := r.validator
+	if len(r.arguments) > 0 {
+		ruleStr += ":" + strings.Join(args2strings(r.arguments), ",")
+	}
+
+	return v.trans.Message(aggregateError, pattern, len(failed), ruleStr, strings.Join(indexes, ","))
+}
+
 /*************************************************************
  * add validate rules
  *************************************************************/
@@ -157,32 +231,46 @@ func (r *Rule) errorMessage(field, validator string, v *Validation) (msg string)
 // 	v.StringRule("name", "required|string|minLen:6")
 // 	// will try convert to int before apply validate.
 // 	v.StringRule("age", "required|int|min:12", "toInt")
+//
+// the separators can be custom by the v.RuleSep, v.ValidatorSep, v.ArgSep
 func (v *Validation) StringRule(field, rule string, filterRule ...string) *Validation {
 	rule = strings.TrimSpace(rule)
-	rules := stringSplit(strings.Trim(rule, "|:"), "|")
+	rules := stringSplit(strings.Trim(rule, v.RuleSep+v.ValidatorSep), v.RuleSep)
 	for _, validator := range rules {
-		validator = strings.Trim(validator, ":")
+		validator = strings.Trim(validator, v.ValidatorSep)
 		if validator == "" { // empty
 			continue
 		}
 
 		// has args
-		if strings.ContainsRune(validator, ':') {
-			list := stringSplit(validator, ":")
-			args := parseArgString(list[1])
-			name := ValidatorName(list[0])
+		if pos := strings.Index(validator, v.ValidatorSep); pos > 0 {
+			vName, argStr := strings.TrimSpace(validator[:pos]), validator[pos+len(v.ValidatorSep):]
+			args := parseArgString(argStr, v.ArgSep)
+			name := ValidatorName(vName)
 			switch name {
 			// add default value for the field
 			case "default":
-				v.SetDefValue(field, list[1])
+				v.SetDefValue(field, argStr)
+			// validate each element of the slice/array/map. eg: 'each:minLen:2' => "tags.*" 'minLen:2'
+			case "each", "values":
+				v.StringRule(field+".*", argStr)
+			// validate each key of the map. eg: 'keys:alphaDash'
+			case "keys":
+				v.AddRule(field, vName, argStr)
 			// eg 'regex:\d{4,6}' dont need split
 			case "regexp":
-				v.AddRule(field, list[0], list[1])
+				v.AddRule(field, vName, argStr)
+			// the empty args are allowed. eg: 'token:,8,digit' no prefix
+			case "token":
+				v.AddRule(field, vName, strings2Args(strings.Split(argStr, v.ArgSep))...)
+			// the open bounds are allowed. eg: 'range:10,', 'rangeX:,99'
+			case "between", "betweenX":
+				v.addRangeRule(field, vName, strings.Split(argStr, v.ArgSep))
 			// some special validator. need merge args to one.
-			case "enum", "notIn":
-				v.AddRule(field, list[0], args)
+			case "enum", "notIn", "enumIgnoreCase", "notInIgnoreCase":
+				v.AddRule(field, vName, args)
 			default:
-				v.AddRule(field, list[0], strings2Args(args)...)
+				v.AddRule(field, vName, strings2Args(args)...)
 			}
 		} else {
 			v.AddRule(field, validator)
@@ -222,8 +310,38 @@ func (v *Validation) ConfigRules(mp MS) *Validation {
 	return v
 }
 
+// add the range rule, the open bound is the math.MinInt64 or math.MaxInt64. see Between()
+func (v *Validation) addRangeRule(field, vName string, bounds []string) {
+	if len(bounds) != 2 {
+		v.AddRule(field, vName, strings2Args(bounds)...)
+		return
+	}
+
+	var min, max interface{} = strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
+	if min == "" && max == "" {
+		panicf("the validator '%s' must has one bound at least", vName)
+	}
+
+	if min == "" {
+		min = int64(math.MinInt64)
+	} else if max == "" {
+		max = int64(math.MaxInt64)
+	}
+	v.AddRule(field, vName, min, max)
+}
+
 // AddRule for current validate
+// Usage:
+// 	v.AddRule("name", "minLen", 6)
+// 	// the enum validator allow multi args OR an slice
+// 	v.AddRule("status", "in", 1, 2, 3)
+// 	v.AddRule("status", "in", []int{1, 2, 3})
 func (v *Validation) AddRule(fields, validator string, args ...interface{}) *Rule {
+	if len(args) > 1 && isEnumValidator(validator) {
+		// merge args to one.
+		args = []interface{}{args}
+	}
+
 	rule := NewRule(fields, validator, args...)
 	rule.skipEmpty = v.SkipOnEmpty
 	// append
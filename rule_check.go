@@ -0,0 +1,161 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TryStringRule add the field rules by string like the StringRule(), but check the rules first.
+// the unknown validators, the wrong number of args, the invalid args(eg: 'min:abc', 'regexp:[a-')
+// are returned as the error, and the rules will not be added.
+// Usage:
+// 	if err := v.TryStringRule("age", ruleFromConfig); err != nil {
+// 		return err
+// 	}
+func (v *Validation) TryStringRule(field, rule string, filterRule ...string) (err error) {
+	ruleNum, filterNum := len(v.rules), len(v.filterRules)
+	defer func() {
+		if err == nil {
+			err = recoverError(recover())
+		}
+
+		// rollback the added rules
+		if err != nil {
+			v.rules, v.filterRules = v.rules[:ruleNum], v.filterRules[:filterNum]
+			msg := strings.TrimPrefix(err.Error(), "validate: ")
+			err = fmt.Errorf("validate: invalid rule %q of the field '%s': %s", rule, field, msg)
+		}
+	}()
+
+	v.StringRule(field, rule, filterRule...)
+	for _, r := range v.rules[ruleNum:] {
+		if err = v.checkRule(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TryStringRules add multi rules by string map, see TryStringRule().
+// the fields are added by the sorted order, stop on the first invalid rule.
+func (v *Validation) TryStringRules(mp MS) error {
+	fields := make([]string, 0, len(mp))
+	for field := range mp {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if err := v.TryStringRule(field, mp[field]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TryValidate like the Validate(), but the panics of the invalid rules or the invalid settings are returned as the error.
+// eg: the validator is not exists, the filter is not exists.
+//
+// NOTICE: the other panics(eg: the panics in the custom validators) are not recovered.
+func (v *Validation) TryValidate(scene ...string) (ok bool, err error) {
+	defer func() {
+		if err = recoverError(recover()); err != nil {
+			ok = false
+			v.hasValidated = true
+			v.safeData = make(map[string]interface{})
+			v.AddError(validateError, validateError, err.Error())
+		}
+	}()
+
+	return v.Validate(scene...), nil
+}
+
+// convert the panic value of the panicf() to the error, re-panic the others
+func recoverError(r interface{}) error {
+	if r == nil {
+		return nil
+	}
+
+	if msg, ok := r.(string); ok && strings.HasPrefix(msg, "validate: ") {
+		return fmt.Errorf("%s", msg)
+	}
+	panic(r)
+}
+
+// check the rule can be applied. it is same as the checks on Rule.Apply()
+func (v *Validation) checkRule(r *Rule) error {
+	name := ValidatorName(r.validator)
+	if r.checkFuncMeta != nil || name == "-" || name == "safe" || isGroupValidator(name) {
+		return nil
+	}
+
+	args := args2strings(r.arguments)
+	switch {
+	case name == "keys":
+		if len(args) == 0 || args[0] == "" {
+			return fmt.Errorf("the validator 'keys' must has the sub validator. eg: 'keys:alphaDash'")
+		}
+
+		subName := args[0]
+		if pos := strings.Index(subName, v.ValidatorSep); pos > 0 {
+			subName = subName[:pos]
+		}
+		if v.validatorMeta(ValidatorName(subName)) == nil {
+			return fmt.Errorf("the validator '%s' is not exists", subName)
+		}
+		return nil
+	case isFileValidator(name):
+		return checkFileArgs(name, args)
+	}
+
+	fm := v.validatorMeta(name)
+	if fm == nil {
+		return fmt.Errorf("the validator '%s' is not exists", r.validator)
+	}
+
+	isNotRequired := !strings.HasPrefix(name, "required") && !isFieldValidator(name)
+	if isNotRequired && !fm.matchArgNum(len(r.arguments)+1) {
+		return fmt.Errorf("the number of parameters does not match the validator '%s'", r.validator)
+	}
+
+	// convert the copied args, the rule args will be converted on apply
+	if err := convertArgs(fm, append([]interface{}(nil), r.arguments...)); err != nil {
+		return err
+	}
+
+	if name == "regexp" && len(args) > 0 {
+		if _, err := compileRegexp(args[0]); err != nil {
+			return err
+		}
+	}
+	if name == "notInDateRanges" && len(args) > 0 {
+		if _, ok := Calendar(args[0]); !ok {
+			return fmt.Errorf("the calendar '%s' is not exists", args[0])
+		}
+	}
+	if name == "isPlate" && len(args) > 0 {
+		if _, ok := PlatePattern(args[0]); !ok {
+			return fmt.Errorf("the license plate pattern of the region '%s' is not exists", args[0])
+		}
+	}
+	return nil
+}
+
+// check the args of the file validators. see Rule.fileValidate()
+func checkFileArgs(name string, args []string) error {
+	switch name {
+	case "inMimeTypes", "archiveExts":
+		if len(args) == 0 {
+			return fmt.Errorf("not enough parameters for validator '%s'", name)
+		}
+	case "archiveMaxEntries", "archiveMaxSize":
+		if len(args) == 0 {
+			return fmt.Errorf("not enough parameters for validator '%s'", name)
+		}
+		if _, err := parseByteSize(args[0]); err != nil {
+			return fmt.Errorf("invalid size parameter '%s' for validator '%s'", args[0], name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_TryStringRule(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "inhere", "age": 20})
+	tests := map[string]string{
+		"min:abc":            "cannot convert string to int64, validator 'min'",
+		"regexp:[a-":         "missing closing ]",
+		"between:,":          "the validator 'between' must has one bound at least",
+		"notExists":          "the validator 'notExists' is not exists",
+		"minLen:":            "the number of parameters does not match the validator 'minLen'",
+		"min:1,2":            "the number of parameters does not match the validator 'min'",
+		"keys:notExists":     "the validator 'notExists' is not exists",
+		"archiveMaxSize:abc": "invalid size parameter 'abc' for validator 'archiveMaxSize'",
+		"isInt:a":            "cannot convert string to int64, validator 'isInt'",
+		"required|min:x":     `invalid rule "required|min:x" of the field 'age'`,
+	}
+	for rule, want := range tests {
+		err := v.TryStringRule("age", rule)
+		is.Error(err, rule)
+		is.Contains(err.Error(), want, rule)
+	}
+	// the rules are rolled back
+	is.Len(v.rules, 0)
+
+	is.NoError(v.TryStringRule("name", "required|minLen:3|regexp:^\\w+$", "trim"))
+	is.NoError(v.TryStringRules(MS{"age": "int|between:1,120", "tags": "each:alphaDash"}))
+	is.Error(v.TryStringRules(MS{"age": "int", "name": "minLen:x"}))
+	is.True(v.Validate())
+
+	// the rule func
+	v = Map(M{"age": 20})
+	v.AddValidator("adult", func(val int) bool {
+		return val >= 18
+	})
+	is.NoError(v.TryStringRule("age", "adult"))
+	is.Error(v.TryStringRule("age", "adult:1"))
+}
+
+func TestValidation_TryValidate(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "inhere", "timeout": "5m"})
+	v.StringRule("name", "notExists")
+	ok, err := v.TryValidate()
+	is.False(ok)
+	is.EqualError(err, "validate: the validator 'notExists' is not exists")
+	is.True(v.IsFail())
+	is.Empty(v.SafeData())
+
+	v = Map(M{"timeout": "5m"})
+	v.StringRule("timeout", "maxDuration:abc")
+	ok, err = v.TryValidate()
+	is.False(ok)
+	is.Error(err)
+
+	v = Map(M{"name": "inhere"})
+	v.StringRule("name", "minLen:3")
+	ok, err = v.TryValidate()
+	is.True(ok)
+	is.NoError(err)
+
+	// the other panics are not recovered
+	v = Map(M{"name": "inhere"})
+	v.AddValidator("bad", func(val string) bool {
+		panic("some bug")
+	})
+	v.StringRule("name", "bad")
+	is.PanicsWithValue("some bug", func() {
+		_, _ = v.TryValidate()
+	})
+}
+
+func TestValidation_invalidNumberArgs(t *testing.T) {
+	is := assert.New(t)
+
+	// the invalid number args are not converted to 0
+	v := Map(M{"age": 20})
+	v.StringRule("age", "min:abc")
+	is.False(v.Validate())
+	is.Equal("cannot convert string to int64, validator 'min'", v.Errors.FieldOne("_convert"))
+
+	v = Map(M{"age": 20})
+	v.StringRule("age", "max:99999999999999999999")
+	is.False(v.Validate())
+	is.Contains(v.Errors.FieldOne("_convert"), "validator 'max'")
+
+	// the invalid value only fail the field
+	v = Map(M{"age": "abc"})
+	v.AddValidator("adult", func(val int64) bool {
+		return val >= 18
+	})
+	v.StringRule("age", "adult")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "age")
+}
@@ -114,3 +114,36 @@ func TestRule_SetSkipEmpty(t *testing.T) {
 	is.False(v.Validate())
 	is.Equal("age value must be an integer and mix value is 1", v.Errors.One())
 }
+
+func TestRule_SetDescription(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "ab", "age": 200})
+	v.StopOnError = false
+	r := v.AddRule("name", "minLen", 3).SetDescription("Username shown on your public profile; 3-32 chars")
+	is.Equal("Username shown on your public profile; 3-32 chars", r.Description())
+	v.StringRule("age", "max:120")
+	v.WithDescriptions(MS{"age": "age in years", "email": "the contact email"})
+
+	is.Equal(map[string]string{
+		"name":  "Username shown on your public profile; 3-32 chars",
+		"age":   "age in years",
+		"email": "the contact email",
+	}, v.Descriptions())
+
+	// not include description by default
+	is.False(v.Validate())
+	is.Equal("name min length is 3", v.Errors.FieldOne("name"))
+
+	v = Map(M{"name": "ab", "age": 200})
+	v.StopOnError = false
+	v.ErrorDescription = true
+	v.AddRule("name", "minLen", 3).SetDescription("3-32 chars")
+	v.StringRule("age", "max:120")
+	v.StringRule("code", "required")
+	v.WithDescriptions(MS{"age": "age in years"})
+	is.False(v.Validate())
+	is.Equal("name min length is 3 (3-32 chars)", v.Errors.FieldOne("name"))
+	is.Equal("age max value is 120 (age in years)", v.Errors.FieldOne("age"))
+	is.Equal("code is required and not empty", v.Errors.FieldOne("code"))
+}
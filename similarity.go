@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"strings"
+)
+
+// the min length of the dst value to check the value contains it.
+const minSimilarContainsLen = 3
+
+// Similarity calc the similarity of the two strings by the Levenshtein distance, ignore case.
+// return the value in the range 0 - 1, 1 is the same.
+// Usage:
+// 	Similarity("password", "Passw0rd") // 0.875
+func Similarity(a, b string) float64 {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(maxLen)
+}
+
+// calc the Levenshtein edit distance of the two rune slices.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(n int, ns ...int) int {
+	for _, x := range ns {
+		if x < n {
+			n = x
+		}
+	}
+	return n
+}
+
+// the value is similar to the dst value: the similarity reached the threshold,
+// or the value contains the dst value(ignore case).
+func isSimilar(val, dst string, threshold float64) bool {
+	if Similarity(val, dst) >= threshold {
+		return true
+	}
+
+	dst = strings.ToLower(dst)
+	return len(dst) >= minSimilarContainsLen && strings.Contains(strings.ToLower(val), dst)
+}
+
+// NotSimilarToField the string value should not resemble the dst field value.
+// the similarity is calc by Similarity(), the value contains the dst value is also similar.
+// if the dst value is an email, will also check with the local part.
+// Usage:
+// 	v.StringRule("password", "notSimilarToField:username,0.8")
+func (v *Validation) NotSimilarToField(val interface{}, dstField string, threshold float64) bool {
+	str, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	dstVal, has := v.Get(dstField)
+	if !has {
+		return true
+	}
+
+	dst, ok := dstVal.(string)
+	if !ok || dst == "" {
+		return true
+	}
+
+	if isSimilar(str, dst, threshold) {
+		return false
+	}
+
+	// check with the email local part
+	if pos := strings.IndexByte(dst, '@'); pos > 0 {
+		return !isSimilar(str, dst[:pos], threshold)
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	is := assert.New(t)
+
+	is.Equal(float64(1), Similarity("", ""))
+	is.Equal(float64(1), Similarity("Inhere", "inhere"))
+	is.Equal(float64(0), Similarity("abc", ""))
+	is.Equal(0.875, Similarity("password", "Passw0rd"))
+	is.Equal(0.5, Similarity("中文测试", "中文"))
+	is.InDelta(0.571, Similarity("kitten", "sitting"), 0.001)
+}
+
+func TestValidation_NotSimilarToField(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"username": "inhere", "password": "Inhere1"})
+	v.StringRule("password", "notSimilarToField:username,0.8")
+	is.False(v.Validate())
+	is.Equal("password value is too similar to the field username", v.Errors.One())
+
+	// contains the dst value
+	v = Map(M{"username": "inhere", "password": "my-INHERE-2020"})
+	v.StringRule("password", "not_similar_to_field:username,0.8")
+	is.False(v.Validate())
+
+	// the email local part
+	v = Map(M{"email": "tom.smith@example.com", "password": "Tom.Smith!"})
+	v.StringRule("password", "notSimilarToField:email,0.8")
+	is.False(v.Validate())
+
+	v = Map(M{"username": "inhere", "password": "c0rrect-h0rse"})
+	v.StringRule("password", "notSimilarToField:username,0.8")
+	is.True(v.Validate())
+
+	// the dst field not exists
+	v = Map(M{"password": "inhere"})
+	v.StringRule("password", "notSimilarToField:username,0.8")
+	is.True(v.Validate())
+}
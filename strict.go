@@ -0,0 +1,97 @@
+package validate
+
+import (
+	"sort"
+	"strings"
+)
+
+// the validator name for the errors of the unknown fields
+const unknownFieldName = "unknownField"
+
+// StrictFields on enable, the validation will fail when the input data contains the fields
+// that are not covered by any rule or the allowed fields. only for the Map and Form data.
+// Usage:
+// 	v.StrictFields(true, "_token", "page")
+func (v *Validation) StrictFields(enable bool, allowed ...string) *Validation {
+	v.strictFields = enable
+	if v.allowedFields == nil {
+		v.allowedFields = make(map[string]bool, len(allowed))
+	}
+
+	for _, field := range allowed {
+		v.allowedFields[field] = true
+	}
+	return v
+}
+
+// UnknownFields get the top level fields in the input data, that are not covered by any rule or the allowed fields.
+// it is useful for report the unexpected parameters without reject them.
+func (v *Validation) UnknownFields() (fields []string) {
+	known := make(map[string]bool, len(v.rules))
+	for _, r := range v.rules {
+		for _, field := range r.fields {
+			known[topField(field)] = true
+		}
+	}
+
+	for _, r := range v.filterRules {
+		for _, field := range r.fields {
+			known[topField(field)] = true
+		}
+	}
+
+	for _, field := range v.inputFields() {
+		if !known[field] && !v.allowedFields[field] {
+			fields = append(fields, field)
+		}
+	}
+	return
+}
+
+// reject the unknown fields on strict mode.
+func (v *Validation) validateUnknownFields() {
+	if !v.strictFields {
+		return
+	}
+
+	for _, field := range v.UnknownFields() {
+		v.AddError(field, unknownFieldName, v.trans.Message(unknownFieldName, field))
+		if v.shouldStopRule() {
+			return
+		}
+	}
+}
+
+// get the sorted top level field names of the input data.
+func (v *Validation) inputFields() []string {
+	var fields []string
+	switch d := v.data.(type) {
+	case *MapData:
+		for key := range d.Map {
+			fields = append(fields, key)
+		}
+	case *FormData:
+		seen := make(map[string]bool, len(d.Form)+len(d.Files))
+		for key := range d.Form {
+			seen[topField(key)] = true
+		}
+		for key := range d.Files {
+			seen[topField(key)] = true
+		}
+
+		for key := range seen {
+			fields = append(fields, key)
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
+
+// get the top level name of the field. eg: "user.name", "user[name]" => "user"
+func topField(field string) string {
+	if pos := strings.IndexAny(field, ".["); pos > 0 {
+		return field[:pos]
+	}
+	return field
+}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_StrictFields(t *testing.T) {
+	is := assert.New(t)
+
+	data := M{"name": "inhere", "age": 20, "role": "admin", "_token": "abc", "addr": M{"city": "Paris"}}
+	v := Map(data)
+	v.StringRule("name", "required")
+	v.StringRule("age", "int")
+	v.StringRule("addr.city", "required")
+	is.True(v.Validate())
+	is.Equal([]string{"_token", "role"}, v.UnknownFields())
+
+	v = Map(data).StrictFields(true, "_token")
+	v.StringRule("name", "required")
+	v.StringRule("age", "int")
+	v.StringRule("addr.city", "required")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("role is an unknown field", v.Errors.One())
+
+	// the filter rule field is known
+	v = Map(data).StrictFields(true, "_token")
+	v.StopOnError = false
+	v.StringRule("name", "required|minLen:7")
+	v.FilterRule("role", "trim")
+	is.False(v.Validate())
+	is.Len(v.Errors, 3)
+	is.Contains(v.Errors, "age")
+	is.Contains(v.Errors, "addr")
+	is.Contains(v.Errors, "name")
+
+	// form data
+	q, _ := url.ParseQuery("name=inhere&user[age]=20&debug=1")
+	v = FromQuery(q).Create().StrictFields(true)
+	v.StringRule("name", "required")
+	v.StringRule("user.age", "required")
+	is.False(v.Validate())
+	is.Equal("debug is an unknown field", v.Errors.One())
+
+	v = FromQuery(q).Create().StrictFields(false)
+	v.StringRule("name", "required")
+	is.True(v.Validate())
+	is.Equal([]string{"debug", "user"}, v.UnknownFields())
+}
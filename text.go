@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// the zero width joiner, use for join the emoji sequence. eg: "👨‍👩‍👧"
+const zeroWidthJoiner = '\u200D'
+
+// emojiTable the code points of the emoji, modifiers and the emoji presentation selectors.
+// the text symbols like "©", "®", "™" are not included.
+var emojiTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x20E3, Hi: 0x20E3, Stride: 1}, // combining enclosing keycap
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x23CF, Hi: 0x23CF, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x23F8, Hi: 0x23FA, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25AB, Stride: 1},
+		{Lo: 0x25B6, Hi: 0x25B6, Stride: 1},
+		{Lo: 0x25C0, Hi: 0x25C0, Stride: 1},
+		{Lo: 0x25FB, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27BF, Stride: 1}, // misc symbols, dingbats
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B07, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+		{Lo: 0xFE0F, Hi: 0xFE0F, Stride: 1}, // variation selector-16, emoji presentation
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1FAFF, Stride: 1}, // pictographs, emoticons, transport, flags, skin tones ...
+		{Lo: 0xE0020, Hi: 0xE007F, Stride: 1}, // tag sequences. eg: the flag of England
+	},
+}
+
+// IsEmoji check the rune is an emoji code point. see emojiTable
+func IsEmoji(r rune) bool {
+	return unicode.Is(emojiTable, r)
+}
+
+// NoEmoji check the string does not contain any emoji.
+func NoEmoji(s string) bool {
+	for _, r := range s {
+		if IsEmoji(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// StripEmoji remove the emoji from the string, the zero width joiners in the emoji sequence also be removed.
+func StripEmoji(s string) string {
+	var inEmoji bool
+	return strings.Map(func(r rune) rune {
+		if IsEmoji(r) || inEmoji && r == zeroWidthJoiner {
+			inEmoji = true
+			return -1
+		}
+
+		inEmoji = false
+		return r
+	}, s)
+}
+
+// is the control char, but the tab and line breaks are allowed.
+func isControlChar(r rune) bool {
+	switch r {
+	case '\t', '\n', '\r':
+		return false
+	}
+	return unicode.IsControl(r)
+}
+
+// NoControlChars check the string does not contain the control chars. the tab, CR and LF are allowed.
+func NoControlChars(s string) bool {
+	return strings.IndexFunc(s, isControlChar) < 0
+}
+
+// StripControlChars remove the control chars from the string. the tab, CR and LF are kept.
+func StripControlChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isControlChar(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// is the line break char. eg: "\n", "\r", "\u2028"
+func isLineBreak(r rune) bool {
+	switch r {
+	case '\n', '\r', '\v', '\f', '\u0085', '\u2028', '\u2029':
+		return true
+	}
+	return false
+}
+
+// SingleLine check the string does not contain the line breaks.
+func SingleLine(s string) bool {
+	return strings.IndexFunc(s, isLineBreak) < 0
+}
+
+// StripNewlines join the lines to single line, each line breaks will be replaced to one space.
+// eg: "line1\r\nline2" => "line1 line2"
+func StripNewlines(s string) string {
+	return strings.Join(strings.FieldsFunc(s, isLineBreak), " ")
+}
+
+// CollapseWhitespace replace the continuous whitespace chars to one space, and trim the both sides.
+// eg: " hello \t\n  world " => "hello world"
+func CollapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// ValidUTF8 check the string or bytes value is valid UTF-8 encoded.
+func ValidUTF8(val interface{}) bool {
+	switch tv := val.(type) {
+	case string:
+		return utf8.ValidString(tv)
+	case []byte:
+		return utf8.Valid(tv)
+	}
+	return false
+}
+
+// Latin1Safe check the string can be represented in Latin-1(ISO-8859-1). eg: "café", "Größe"
+func Latin1Safe(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxLatin1 { // the invalid byte is decoded as utf8.RuneError
+			return false
+		}
+	}
+	return true
+}
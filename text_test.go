@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoEmoji(t *testing.T) {
+	is := assert.New(t)
+
+	for _, s := range []string{"", "hello", "中文 テスト", "© 2020 ®™", "a → b", "क्\u200dष"} {
+		is.True(NoEmoji(s), s)
+	}
+	for _, s := range []string{"hi 😀", "☀", "❤️", "👍🏽", "🇨🇳", "1️⃣", "⭐"} {
+		is.False(NoEmoji(s), s)
+	}
+
+	is.Equal("hi ", StripEmoji("hi 😀"))
+	is.Equal("family: ", StripEmoji("family: 👨‍👩‍👧"))
+	is.Equal("ok", StripEmoji("ok👍🏽"))
+	is.Equal("1", StripEmoji("1️⃣"))
+	// the zero width joiner in other scripts is kept
+	is.Equal("क्\u200dष", StripEmoji("क्\u200dष"))
+}
+
+func TestNoControlChars(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(NoControlChars("line1\r\nline2\tend"))
+	is.False(NoControlChars("abc\x00"))
+	is.False(NoControlChars("abc\x1b[31m"))
+	is.False(NoControlChars("abc\u0085"))
+	is.Equal("abc[31m\n", StripControlChars("a\x00bc\x1b[31m\n"))
+
+	is.True(SingleLine("a single line\t"))
+	is.False(SingleLine("line1\nline2"))
+	is.False(SingleLine("line1\rline2"))
+	is.False(SingleLine("line1\u2028line2"))
+	is.Equal("line1 line2 line3", StripNewlines("line1\r\nline2\n\nline3\n"))
+}
+
+func TestText_validateAndFilter(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"sms": "hello 😀\nworld"})
+	v.StopOnError = false
+	v.StringRule("sms", "required|noEmoji|single_line|noControlChars")
+	is.False(v.Validate())
+	is.Len(v.Errors.Field("sms"), 2)
+	is.Equal("sms value cannot contain emoji", v.Errors.Field("sms")["noEmoji"])
+
+	v = Map(M{"sms": "hello 😀\nworld\x00"})
+	v.StringRule("sms", "required|noEmoji|singleLine|noControlChars", "stripEmoji|stripControlChars|stripNewlines")
+	is.True(v.Validate())
+	is.Equal("hello  world", v.SafeVal("sms"))
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	is := assert.New(t)
+
+	is.Equal("hello world", CollapseWhitespace(" hello \t\n  world "))
+	is.Equal("a b", CollapseWhitespace("a\u00a0\u3000b"))
+	is.Equal("", CollapseWhitespace(" \r\n "))
+}
+
+func TestTextEncoding(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(ValidUTF8("中文 café"))
+	is.True(ValidUTF8([]byte("abc")))
+	is.False(ValidUTF8("abc\xff"))
+	is.False(ValidUTF8([]byte{0xe4, 0xb8}))
+	is.False(ValidUTF8(123))
+
+	is.True(Latin1Safe("café Größe ¿"))
+	is.True(Latin1Safe(""))
+	is.False(Latin1Safe("€10"))
+	is.False(Latin1Safe("中文"))
+	is.False(Latin1Safe("caf\xe9"))
+
+	v := Map(M{"name": "José", "code": "José", "memo": "ok\xff"})
+	v.StopOnError = false
+	v.StringRules(MS{"name": "latin1Safe", "code": "asciiOnly", "memo": "valid_utf8"})
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("code value can only contain ASCII characters", v.Errors.FieldOne("code"))
+	is.Equal("memo value must be valid UTF-8 encoded", v.Errors.FieldOne("memo"))
+}
@@ -0,0 +1,19 @@
+module github.com/gookit/validate/textnorm
+
+go 1.25.0
+
+require (
+	github.com/gookit/validate v0.0.0
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/text v0.40.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gookit/filter v1.1.0 // indirect
+	github.com/gookit/goutil v0.2.4 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/gookit/validate => ../
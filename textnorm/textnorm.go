@@ -0,0 +1,63 @@
+// Package textnorm provide the unicode normalization filters, it is a sub-module
+// to avoid add the dependency "golang.org/x/text" to the validate.
+//
+// call Register() to add the filters "nfc", "nfd", "nfkc", "nfkd" to the validate.
+//
+// 	textnorm.Register()
+//
+// 	v := validate.Map(data)
+// 	v.FilterRule("username", "trim|nfkc|lower")
+package textnorm
+
+import (
+	"strings"
+
+	"github.com/gookit/validate"
+	"golang.org/x/text/unicode/norm"
+)
+
+// the filter name to the normalization form
+var forms = map[string]norm.Form{
+	"nfc":  norm.NFC,
+	"nfd":  norm.NFD,
+	"nfkc": norm.NFKC,
+	"nfkd": norm.NFKD,
+}
+
+// Register add the normalization filters and the validator "isNormalized" to the validate.
+func Register() {
+	for name, form := range forms {
+		validate.AddFilter(name, form.String)
+	}
+
+	validate.AddValidator("isNormalized", IsNormalized)
+	validate.AddGlobalMessages(map[string]string{
+		"isNormalized": "{field} value must be in the unicode normalization form %s",
+	})
+}
+
+// Normalize the string to the form. the form is one of: nfc, nfd, nfkc, nfkd(ignore case)
+func Normalize(s, form string) string {
+	return mustForm(form).String(s)
+}
+
+// IsNormalized check the string is in the normalization form. returns false on the form is invalid.
+// Usage:
+// 	v.StringRule("name", "isNormalized:nfc")
+func IsNormalized(val interface{}, form string) bool {
+	s, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	f, ok := forms[strings.ToLower(form)]
+	return ok && f.IsNormalString(s)
+}
+
+func mustForm(name string) norm.Form {
+	form, ok := forms[strings.ToLower(name)]
+	if !ok {
+		panic("textnorm: invalid normalization form '" + name + "'")
+	}
+	return form
+}
@@ -0,0 +1,44 @@
+package textnorm
+
+import (
+	"testing"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	is := assert.New(t)
+
+	// "\u00e9" decomposed: "e" + U+0301
+	is.Equal("caf\u00e9", Normalize("cafe\u0301", "nfc"))
+	is.Equal("cafe\u0301", Normalize("caf\u00e9", "NFD"))
+	// full width and ligature
+	is.Equal("ABC fi", Normalize("\uff21\uff22\uff23 \ufb01", "nfkc"))
+	is.Panics(func() {
+		Normalize("abc", "invalid")
+	})
+
+	is.True(IsNormalized("caf\u00e9", "nfc"))
+	is.False(IsNormalized("cafe\u0301", "nfc"))
+	is.False(IsNormalized(123, "nfc"))
+	is.False(IsNormalized("abc", "xyz"))
+}
+
+func TestRegister(t *testing.T) {
+	is := assert.New(t)
+	Register()
+
+	v := validate.Map(map[string]interface{}{"name": " \uff21dmin ", "title": "cafe\u0301"})
+	v.FilterRule("name", "trim|nfkc|lower")
+	v.StringRules(validate.MS{"name": "required", "title": "isNormalized:nfc"})
+	is.False(v.Validate())
+	is.Equal("admin", v.Filtered("name"))
+	is.Equal("title value must be in the unicode normalization form nfc", v.Errors.One())
+
+	// the invalid form argument
+	v = validate.Map(map[string]interface{}{"title": "abc"})
+	v.StringRule("title", "isNormalized:xyz")
+	is.False(v.Validate())
+	is.Equal("title value must be in the unicode normalization form xyz", v.Errors.One())
+}
@@ -0,0 +1,11 @@
+module github.com/gookit/validate/tomldata
+
+go 1.11
+
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/gookit/validate v0.0.0
+	github.com/stretchr/testify v1.6.1
+)
+
+replace github.com/gookit/validate => ../
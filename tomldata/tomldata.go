@@ -0,0 +1,23 @@
+// Package tomldata provide the TOML data source, it is a sub-module
+// to avoid add the dependency "github.com/BurntSushi/toml" to the validate.
+//
+// 	d, err := tomldata.FromString(s)
+// 	v := d.Create()
+// 	v.StringRule("database.port", "required|int|max:65535")
+package tomldata
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/gookit/validate"
+)
+
+// FromString build data instance from TOML string.
+func FromString(s string) (*validate.MapData, error) {
+	return FromBytes([]byte(s))
+}
+
+// FromBytes build data instance from TOML bytes.
+// the nested tables can be access by the dot path. eg: "database.port"
+func FromBytes(bs []byte) (*validate.MapData, error) {
+	return validate.FromBytesWith(bs, toml.Unmarshal)
+}
@@ -0,0 +1,53 @@
+package tomldata
+
+import (
+	"testing"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromString(t *testing.T) {
+	is := assert.New(t)
+
+	_, err := FromString("invalid = ")
+	is.Error(err)
+
+	d, err := FromString(`
+name = "inhere"
+age = 30
+
+[database]
+host = "127.0.0.1"
+port = 3306
+
+[database.pool]
+size = 10
+
+[[servers]]
+name = "web1"
+
+[[servers]]
+name = "web2"
+`)
+	is.Nil(err)
+	v := d.Create()
+	v.StopOnError = false
+	v.StringRules(validate.MS{
+		"name":               "required|string:6,12",
+		"age":                "required|int|range:1,100",
+		"database.host":      "required|ip",
+		"database.port":      "required|int|max:65535",
+		"database.pool.size": "required|int|min:1",
+		"servers.*.name":     "required|minLen:3",
+	})
+	is.True(v.Validate(), v.Errors.String())
+	is.Equal(int64(3306), v.SafeVal("database.port"))
+
+	d, err = FromBytes([]byte("[database]\nport = 70000\n"))
+	is.Nil(err)
+	v = d.Validation()
+	v.StringRule("database.port", "required|int|max:65535")
+	is.False(v.Validate())
+	is.Contains(v.Errors.String(), "database.port")
+}
@@ -0,0 +1,79 @@
+package validate
+
+// some trace stage names
+const (
+	TraceRaw      = "raw"
+	TraceDefault  = "default"
+	TraceFilter   = "filter"
+	TraceSkip     = "skip"
+	TraceValidate = "validate"
+	TraceFinal    = "final"
+)
+
+// TraceStep a processing step of the field value. available on Validation.Debug is true
+type TraceStep struct {
+	// Stage name. allow: raw, default, filter, skip, validate, final
+	Stage string
+	// Name of the filter or validator. is empty on stage: raw, default, final
+	Name string
+	// Value of the field after the step
+	Value interface{}
+	// OK the validate result. only for the validate stage
+	OK bool
+}
+
+// Trace get the processing steps of the field value.
+// Usage:
+// 	v := validate.Map(data)
+// 	v.Debug = true
+// 	v.Validate()
+// 	steps := v.Trace("age")
+func (v *Validation) Trace(field string) []TraceStep {
+	return v.traces[field]
+}
+
+// Traces get the processing steps of all fields
+func (v *Validation) Traces() map[string][]TraceStep {
+	return v.traces
+}
+
+// record an processing step for the field
+func (v *Validation) trace(field, stage, name string, val interface{}, ok bool) {
+	if !v.Debug {
+		return
+	}
+
+	if v.traces == nil {
+		v.traces = make(map[string][]TraceStep)
+	}
+
+	v.traces[field] = append(v.traces[field], TraceStep{
+		Stage: stage,
+		Name:  name,
+		Value: val,
+		OK:    ok,
+	})
+}
+
+// record the raw value of the field, only record once
+func (v *Validation) traceRaw(field string, val interface{}, exist bool) {
+	if v.Debug && len(v.traces[field]) == 0 {
+		v.trace(field, TraceRaw, "", val, exist)
+	}
+}
+
+// record the final value for all traced fields
+func (v *Validation) traceFinal() {
+	if !v.Debug {
+		return
+	}
+
+	for field := range v.traces {
+		val, ok := v.safeData[field]
+		if !ok {
+			val, _ = v.Get(field)
+		}
+
+		v.trace(field, TraceFinal, "", val, ok)
+	}
+}
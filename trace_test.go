@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidation_Trace(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{
+		"age":  " 23 ",
+		"name": "inhere",
+	})
+	v.Debug = true
+	v.StopOnError = false
+	v.FilterRule("age", "trim|int")
+	v.StringRule("age", "required|int|max:99")
+	v.StringRule("name", "minLen:10")
+	v.StringRule("city", "string|default:chengdu")
+	is.False(v.Validate())
+
+	steps := v.Trace("age")
+	is.Len(steps, 7)
+	is.Equal(TraceRaw, steps[0].Stage)
+	is.Equal(" 23 ", steps[0].Value)
+	is.Equal(TraceFilter, steps[1].Stage)
+	is.Equal("trim", steps[1].Name)
+	is.Equal("23", steps[1].Value)
+	is.Equal("int", steps[2].Name)
+	is.Equal(23, steps[2].Value)
+	is.Equal(TraceValidate, steps[3].Stage)
+	is.Equal("required", steps[3].Name)
+	is.True(steps[5].OK)
+	is.Equal(TraceFinal, steps[6].Stage)
+	is.Equal(23, steps[6].Value)
+
+	steps = v.Trace("name")
+	is.Len(steps, 3)
+	is.Equal("minLen", steps[1].Name)
+	is.False(steps[1].OK)
+	is.False(steps[2].OK)
+
+	steps = v.Trace("city")
+	is.Equal(TraceDefault, steps[1].Stage)
+	is.Equal("chengdu", steps[1].Value)
+	is.Len(v.Traces(), 3)
+
+	v.ResetResult()
+	is.Nil(v.Trace("age"))
+
+	// disable debug
+	v = Map(M{"age": 23})
+	v.StringRule("age", "required|int")
+	is.True(v.Validate())
+	is.Empty(v.Traces())
+}
@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"reflect"
+	"sort"
+)
+
+// the validator name for the errors of the validatable field value
+const validatableName = "isValid"
+
+// ValidatableFace definition. the field value type knows how to validate itself.
+// Usage:
+// 	type Money struct {
+// 		Amount   int64
+// 		Currency string
+// 	}
+//
+// 	func (m Money) IsValid() bool {
+// 		return m.Amount >= 0 && len(m.Currency) == 3
+// 	}
+type ValidatableFace interface {
+	IsValid() bool
+}
+
+// ValidatableErrFace definition. like the ValidatableFace, the returned error message will be used.
+type ValidatableErrFace interface {
+	Validate() error
+}
+
+// call the IsValid()/Validate() method of the field values. see ValidatableFace, ValidatableErrFace
+func (v *Validation) validateValidatable() {
+	if !v.CheckValidatable || v.data == nil {
+		return
+	}
+
+	var fields []string
+	values := make(map[string]reflect.Value)
+
+	switch d := v.data.(type) {
+	case *StructData:
+		for i := 0; i < d.valueTpy.NumField(); i++ {
+			name := d.valueTpy.Field(i).Name
+			// skip don't exported field
+			if name[0] >= 'a' && name[0] <= 'z' {
+				continue
+			}
+
+			fields = append(fields, name)
+			values[name] = d.value.Field(i)
+		}
+	case *MapData:
+		for name, val := range d.Map {
+			fields = append(fields, name)
+			values[name] = reflect.ValueOf(val)
+		}
+		// keep the error order stable
+		sort.Strings(fields)
+	default:
+		return
+	}
+
+	for _, field := range fields {
+		if v.isNotNeedToCheck(field) || v.isStoppedField(field) {
+			continue
+		}
+
+		if v.callValidatable(field, values[field]) && v.shouldStopRule() {
+			return
+		}
+	}
+}
+
+// call the validatable method of the value, return true on validate failed.
+func (v *Validation) callValidatable(field string, rv reflect.Value) bool {
+	if !rv.IsValid() {
+		return false
+	}
+
+	// skip the empty value. eg: the nil pointer, the zero struct of the optional field
+	if !rv.CanInterface() || IsEmpty(rv.Interface()) {
+		return false
+	}
+
+	// the method has the pointer receiver
+	if rv.Kind() != reflect.Ptr && rv.CanAddr() {
+		rv = rv.Addr()
+	}
+
+	switch fv := rv.Interface().(type) {
+	case ValidatableFace:
+		if !fv.IsValid() {
+			v.AddError(field, validatableName, v.trans.Message(validatableName, field))
+			return true
+		}
+	case ValidatableErrFace:
+		if err := fv.Validate(); err != nil {
+			v.AddError(field, validatableName, err.Error())
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMoney struct {
+	Amount   int64
+	Currency string
+}
+
+func (m testMoney) IsValid() bool {
+	return m.Amount >= 0 && len(m.Currency) == 3
+}
+
+type testPhone string
+
+func (p *testPhone) Validate() error {
+	if len(*p) < 6 {
+		return errors.New("phone number is too short")
+	}
+	return nil
+}
+
+type testOrder struct {
+	Name  string `validate:"required"`
+	Price testMoney
+	Phone testPhone
+	Fee   *testMoney
+}
+
+func TestValidation_validatable(t *testing.T) {
+	is := assert.New(t)
+
+	Config(func(opt *GlobalOption) {
+		opt.CheckValidatable = true
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.CheckValidatable = false
+	})
+
+	o := &testOrder{Name: "order", Price: testMoney{100, "USD"}, Phone: "1234567"}
+	v := Struct(o)
+	is.True(v.Validate())
+
+	// skip the empty values
+	o = &testOrder{Name: "order"}
+	v = Struct(o)
+	is.True(v.Validate())
+
+	o = &testOrder{Name: "order", Price: testMoney{-1, "USD"}, Phone: "123"}
+	v = Struct(o)
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.Equal("Price value is invalid", v.Errors.FieldOne("Price"))
+	is.Equal("phone number is too short", v.Errors.FieldOne("Phone"))
+
+	o = &testOrder{Name: "order", Price: testMoney{1, "USD"}, Phone: "1234567", Fee: &testMoney{1, "US"}}
+	v = Struct(o)
+	is.False(v.Validate())
+	is.Equal("Fee value is invalid", v.Errors.One())
+
+	// disable by option
+	v = Struct(o)
+	v.CheckValidatable = false
+	is.True(v.Validate())
+
+	// map data
+	v = Map(M{"price": testMoney{1, "CNY"}, "fee": testMoney{1, ""}})
+	is.False(v.Validate())
+	is.Equal("fee value is invalid", v.Errors.One())
+
+	// on scene
+	v = Map(M{"price": testMoney{1, "CNY"}, "fee": testMoney{1, ""}})
+	v.StringRule("price", "required")
+	v.WithScenarios(SValues{"create": {"price"}})
+	is.True(v.Validate("create"))
+}
+
+func TestValidation_validatable_default(t *testing.T) {
+	is := assert.New(t)
+
+	// disabled by default
+	o := &testOrder{Name: "order", Price: testMoney{-1, "USD"}, Phone: "123"}
+	v := Struct(o)
+	is.False(v.CheckValidatable)
+	is.True(v.Validate())
+
+	v = Struct(o)
+	v.CheckValidatable = true
+	is.False(v.Validate())
+	is.Equal("Price value is invalid", v.Errors.One())
+}
@@ -7,18 +7,21 @@
 package validate
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 )
 
 // const requiredValidator = "required"
 
 // the validate result status:
-// 0 ok 1 skip 2 fail
+// 0 ok 1 skip 2 fail 3 break(stop the validate)
 const (
 	statusOk uint8 = iota
 	statusSkip
 	statusFail
+	statusBreak
 )
 
 // Apply current rule for the rule fields
@@ -28,11 +31,20 @@ func (r *Rule) Apply(v *Validation) (stop bool) {
 		return false
 	}
 
-	var err error
 	// get real validator name
 	name := ValidatorName(r.validator)
-	// validator name is not "required"
-	isNotRequired := !strings.HasPrefix(name, "required")
+	// validator name is not "required" and not need the field name.
+	isNotRequired := !strings.HasPrefix(name, "required") && !isFieldValidator(name)
+
+	// validate the keys of the map value. see applyKeys()
+	if name == "keys" {
+		return r.applyKeys(v)
+	}
+
+	// validate the group of the fields. see applyGroup()
+	if isGroupValidator(name) {
+		return r.applyGroup(name, v)
+	}
 
 	// validate each field
 	for _, field := range r.fields {
@@ -40,100 +52,311 @@ func (r *Rule) Apply(v *Validation) (stop bool) {
 			continue
 		}
 
-		// has beforeFunc and it return FALSE, skip validate
-		if r.beforeFunc != nil && !r.beforeFunc(field, v) {
+		// has wildcard. eg: "items.*.sku"
+		if isWildcardField(field) {
+			if r.applyWildcard(field, name, isNotRequired, v) {
+				return true
+			}
 			continue
 		}
 
-		// uploaded file validate
-		if isFileValidator(name) {
-			status := r.fileValidate(field, name, v)
-			if status == statusFail {
-				// build and collect error message
-				v.AddError(field, r.validator, r.errorMessage(field, r.validator, v))
-				if v.StopOnError {
-					return true
-				}
-			}
+		if v.isStoppedField(field) {
 			continue
 		}
 
-		// get field value.
-		val, exist := v.Get(field)
+		status := r.applyField(field, name, isNotRequired, v)
+		if status == statusBreak {
+			return true
+		}
 
-		// field not exist
+		if status == statusFail {
+			// build and collect error message
+			v.addRuleError(field, r, r.errorMessage(field, r.validator, v))
+		}
+
+		// stop on error
+		if v.shouldStopRule() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply the rule for the wildcard field. eg: "items.*.sku"
+func (r *Rule) applyWildcard(pattern, name string, isNotRequired bool, v *Validation) (stop bool) {
+	var failed []string
+	for _, field := range v.expandField(pattern) {
+		if v.isStoppedField(field) {
+			continue
+		}
+
+		status := r.applyField(field, name, isNotRequired, v)
+		if status == statusBreak {
+			return true
+		}
+
+		if status != statusFail {
+			continue
+		}
+
+		// collect failed fields, will aggregate them to one error.
+		if v.AggregateErrors {
+			failed = append(failed, field)
+			continue
+		}
+
+		v.addRuleError(field, r, r.errorMessage(field, r.validator, v))
+		if v.shouldStopRule() {
+			return true
+		}
+	}
+
+	if len(failed) > 0 {
+		v.addRuleError(pattern, r, r.aggregateMessage(pattern, failed, v))
+	}
+	return v.shouldStopRule()
+}
+
+// apply the sub validator to each key of the map field. the rule arguments is the sub validator.
+// eg: 'keys:maxLen:10' => "attrs.some-key" error on the key is invalid.
+func (r *Rule) applyKeys(v *Validation) (stop bool) {
+	if len(r.arguments) == 0 {
+		panicf("the validator 'keys' must has the sub validator. eg: 'keys:alphaDash'")
+	}
+
+	subName, argStr := fmt.Sprint(r.arguments[0]), ""
+	if pos := strings.Index(subName, v.ValidatorSep); pos > 0 {
+		subName, argStr = subName[:pos], subName[pos+len(v.ValidatorSep):]
+	}
+
+	var args []interface{}
+	if ValidatorName(subName) == "regexp" {
+		args = []interface{}{argStr}
+	} else {
+		args = strings2Args(parseArgString(argStr, v.ArgSep))
+	}
+
+	var fields []string
+	for _, field := range r.fields {
+		if v.isNotNeedToCheck(field) {
+			continue
+		}
+
+		if isWildcardField(field) {
+			fields = append(fields, v.expandField(field)...)
+		} else {
+			fields = append(fields, field)
+		}
+	}
+
+	for _, field := range fields {
+		if v.isStoppedField(field) {
+			continue
+		}
+
+		val, exist := v.Get(field)
 		if !exist {
-			defVal, ok := v.GetDefValue(field)
-			// has default value
-			if ok {
-				// update source data field value
-				newVal, err := v.updateValue(field, defVal)
-				if err != nil {
-					panicf(err.Error())
-				}
-
-				// re-set value
-				val = newVal
-
-				// dont need check default value
-				if !v.CheckDefault {
-					// save validated value.
-					v.safeData[field] = val
-					continue
-				}
-
-				// go on check custom default value
-				exist = true
-			} else if r.optional { // r.optional=true. skip check.
+			continue
+		}
+
+		rv, isNil := indirect(reflect.ValueOf(val))
+		if isNil || rv.Kind() != reflect.Map {
+			continue
+		}
+
+		keys := make([]string, 0, rv.Len())
+		for _, mk := range rv.MapKeys() {
+			keys = append(keys, fmt.Sprint(mk.Interface()))
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if ok, _ := v.Check(subName, key, args...); ok {
 				continue
 			}
-		}
 
-		// apply filter func.
-		if exist && r.filterFunc != nil {
-			if val, err = r.filterFunc(val); err != nil { // has error
-				v.AddError(filterError, filterError, err.Error())
+			msg := r.message
+			if msg == "" {
+				msg = v.trans.Message(subName, fmt.Sprintf("%s key %q", field, key), args...)
+			}
+
+			v.addRuleError(field+"."+key, r, msg)
+			if v.shouldStopRule() {
 				return true
 			}
+		}
+	}
+	return false
+}
+
+// apply the validator to the group of the fields, the rule arguments are also the group fields.
+// eg: 'requiredOneOf' for "email,phone" => error on the group "email,phone"
+func (r *Rule) applyGroup(name string, v *Validation) (stop bool) {
+	fields := append(make([]string, 0, len(r.fields)+len(r.arguments)), r.fields...)
+	fields = append(fields, args2strings(r.arguments)...)
+	if v.isNotNeedToCheck(fields[0]) {
+		return false
+	}
+
+	group := strings.Join(fields, ",")
+	if v.isStoppedField(group) {
+		return false
+	}
 
-			// update source field value
-			newVal, err := v.updateValue(field, val)
+	var ok bool
+	switch name {
+	case "requiredOneOf":
+		ok = v.RequiredOneOf(fields...)
+	case "onlyOneOf":
+		ok = v.OnlyOneOf(fields...)
+	}
+
+	v.trace(group, TraceValidate, r.validator, nil, ok)
+	if ok {
+		return false
+	}
+
+	v.addRuleError(group, r, r.errorMessage(group, r.validator, v))
+	return v.shouldStopRule()
+}
+
+// apply the rule for one field, return the validate status.
+func (r *Rule) applyField(field, name string, isNotRequired bool, v *Validation) uint8 {
+	// has beforeFunc and it return FALSE, skip validate
+	if r.beforeFunc != nil && !r.beforeFunc(field, v) {
+		v.trace(field, TraceSkip, r.validator, nil, true)
+		return statusSkip
+	}
+
+	// on partial mode, skip the field not exists in the data
+	if v.partial && !v.hasInputField(field) {
+		v.trace(field, TraceSkip, r.validator, nil, true)
+		return statusSkip
+	}
+
+	// skip the nil pointer field
+	if v.NilPointer == NilPointerSkip && v.isNilPointer(field) {
+		v.trace(field, TraceSkip, r.validator, nil, true)
+		return statusSkip
+	}
+
+	// uploaded file validate
+	if isFileValidator(name) {
+		status := r.fileValidate(field, name, v)
+		v.trace(field, TraceValidate, r.validator, nil, status != statusFail)
+		return status
+	}
+
+	// get field value.
+	val, exist := v.Get(field)
+	v.traceRaw(field, val, exist)
+
+	// the "required" enforces the input value, the default value is applied after it.
+	afterRequired := v.DefaultMode == DefaultAfterRequired && strings.HasPrefix(name, "required")
+	if afterRequired && v.defaulted[field] {
+		// check as the field is not exists, the filled default value is kept.
+		ok := r.valueValidate(field, name, isNotRequired, nil, v)
+		v.trace(field, TraceValidate, r.validator, nil, ok)
+		if ok {
+			return statusOk
+		}
+		return statusFail
+	}
+
+	// field not exist
+	if !exist && !afterRequired {
+		defVal, ok := v.GetDefValue(field)
+		// has default value
+		if ok {
+			// update source data field value
+			newVal, err := v.fillDefValue(field, defVal)
 			if err != nil {
 				panicf(err.Error())
 			}
 
 			// re-set value
 			val = newVal
-			// save filtered value.
-			v.filteredData[field] = val
-		}
 
-		// empty value AND skip on empty.
-		if r.skipEmpty && isNotRequired && IsEmpty(val) {
-			continue
+			// dont need check default value
+			if !v.CheckDefault {
+				// save validated value.
+				v.safeData[field] = val
+				return statusSkip
+			}
+
+			// go on check custom default value
+			exist = true
+		} else if r.optional { // r.optional=true. skip check.
+			v.trace(field, TraceSkip, r.validator, val, true)
+			return statusSkip
 		}
+	}
 
-		// validate field value
-		if r.valueValidate(field, name, isNotRequired, val, v) {
-			v.safeData[field] = val // save validated value.
-		} else { // build and collect error message
-			v.AddError(field, r.validator, r.errorMessage(field, r.validator, v))
+	// apply filter func.
+	if exist && r.filterFunc != nil {
+		var err error
+		if val, err = r.filterFunc(val); err != nil { // has error
+			v.AddError(filterError, filterError, err.Error())
+			return statusBreak
 		}
 
-		// stop on error
-		if v.shouldStop() {
-			return true
+		// update source field value
+		newVal, err := v.updateValue(field, val)
+		if err != nil {
+			panicf(err.Error())
 		}
+
+		// re-set value
+		val = newVal
+		// save filtered value.
+		v.filteredData[field] = val
+		v.trace(field, TraceFilter, "filterFunc", val, true)
 	}
 
-	return false
+	// validate the underlying value. eg: *string => string
+	checkVal := v.checkValue(val)
+
+	// empty value AND skip on empty.
+	if r.skipEmpty && isNotRequired && IsEmpty(checkVal) {
+		v.trace(field, TraceSkip, r.validator, val, true)
+		return statusSkip
+	}
+
+	// validate field value
+	ok := r.valueValidate(field, name, isNotRequired, checkVal, v)
+	v.trace(field, TraceValidate, r.validator, val, ok)
+	if !ok {
+		return statusFail
+	}
+
+	// convert the validated value for the SafeData. see AddSafeValueFunc()
+	if fn := safeValueFunc(name); fn != nil {
+		val = fn(checkVal, r.arguments)
+	}
+
+	v.safeData[field] = val // save validated value.
+	return statusOk
 }
 
 // func (r *Rule) applyOneField() {}
 
+// get the size argument of the file validator. eg: "100", "10M"
+func (r *Rule) sizeArg(ss []string) int64 {
+	if len(ss) == 0 {
+		panicf("not enough parameters for validator '%s'!", r.validator)
+	}
+
+	size, err := parseByteSize(ss[0])
+	if err != nil {
+		panicf("invalid size parameter '%s' for validator '%s'", ss[0], r.validator)
+	}
+	return size
+}
+
 func (r *Rule) fileValidate(field, name string, v *Validation) uint8 {
 	// check data source
-	form, ok := v.data.(*FormData)
+	form, ok := v.formData()
 	if !ok {
 		return statusFail
 	}
@@ -145,7 +368,7 @@ func (r *Rule) fileValidate(field, name string, v *Validation) uint8 {
 
 	var ss []string
 	for _, item := range r.arguments {
-		ss = append(ss, item.(string))
+		ss = append(ss, fmt.Sprint(item))
 	}
 
 	switch name {
@@ -163,6 +386,15 @@ func (r *Rule) fileValidate(field, name string, v *Validation) uint8 {
 			//noinspection GoNilness
 			ok = v.InMimeTypes(form, field, ss[0], ss[1:]...)
 		}
+	case "archiveMaxEntries":
+		ok = v.ArchiveMaxEntries(form, field, r.sizeArg(ss))
+	case "archiveMaxSize":
+		ok = v.ArchiveMaxSize(form, field, r.sizeArg(ss))
+	case "archiveExts":
+		if len(ss) == 0 {
+			panicf("not enough parameters for validator '%s'!", r.validator)
+		}
+		ok = v.ArchiveExts(form, field, ss...)
 	}
 
 	if ok {
@@ -210,12 +442,22 @@ func (r *Rule) valueValidate(field, name string, isNotRequired bool, val interfa
 			}
 
 			// manual converted
-			if nVal, _ := convertType(val, ak, firstTyp); nVal != nil {
+			// the invalid value only fail the field. eg: "abc" to int
+			nVal, err := convertType(val, ak, firstTyp)
+			if err != nil {
+				return false
+			}
+			if nVal != nil {
 				val = nVal
 			}
 		}
 	}
 
+	// join the prefetched result of the I/O validator. see Validation.prefetch()
+	if task := v.prefetchedTask(r, field, val); task != nil {
+		return task.wait()
+	}
+
 	// call built in validators
 	return callValidator(v, fm, field, val, r.arguments)
 }
@@ -242,6 +484,20 @@ func callValidator(v *Validation, fm *funcMeta, field string, val interface{}, a
 		ok = v.RequiredWithout(field, val, args2strings(args)...)
 	case "requiredWithoutAll":
 		ok = v.RequiredWithoutAll(field, val, args2strings(args)...)
+	case "changedRequires":
+		ok = v.ChangedRequires(field, val, args2strings(args)...)
+	case "immutable":
+		ok = v.Immutable(field, val)
+	case "readOnlyInScene":
+		ok = v.ReadOnlyInScene(field, val, args2strings(args)...)
+	case "confirmed":
+		ok = v.Confirmed(field, val, args2strings(args)...)
+	case "allDifferent":
+		ok = v.AllDifferent(field, val, args2strings(args)...)
+	case "sumTo":
+		ok = v.SumTo(field, val, args2strings(args)...)
+	case "excludesWith":
+		ok = v.ExcludesWith(field, val, args2strings(args)...)
 	case "lt":
 		ok = Lt(val, args[0].(int64))
 	case "gt":
@@ -254,6 +510,10 @@ func callValidator(v *Validation, fm *funcMeta, field string, val interface{}, a
 		ok = Enum(val, args[0])
 	case "notIn":
 		ok = NotIn(val, args[0])
+	case "enumIgnoreCase":
+		ok = EnumIgnoreCase(val, args[0])
+	case "notInIgnoreCase":
+		ok = NotInIgnoreCase(val, args[0])
 	case "isInt":
 		if argLn := len(args); argLn == 0 {
 			ok = IsInt(val)
@@ -289,20 +549,35 @@ func callValidator(v *Validation, fm *funcMeta, field string, val interface{}, a
 	case "regexp":
 		ok = Regexp(val.(string), args[0].(string))
 	case "between":
-		ok = Between(val, args[0].(int64), args[1].(int64))
+		if ok = Between(val, args[0].(int64), args[1].(int64)); !ok {
+			v.openRangeReason(fm.name, args[0].(int64), args[1].(int64))
+		}
+	case "betweenX":
+		if ok = BetweenX(val, args[0].(int64), args[1].(int64)); !ok {
+			v.openRangeReason(fm.name, args[0].(int64), args[1].(int64))
+		}
 	case "isJSON":
 		ok = IsJSON(val.(string))
 	default:
 		// 3. call user custom validators, will call by reflect
-		ok = callValidatorValue(fm.fv, val, args)
+		ok = v.callFuncMeta(fm, val, args)
 	}
 	return
 }
 
 // convert args data type
-func convertArgsType(v *Validation, fm *funcMeta, args []interface{}) (ok bool) {
+func convertArgsType(v *Validation, fm *funcMeta, args []interface{}) bool {
+	if err := convertArgs(fm, args); err != nil {
+		v.AddError("_convert", validateError, err.Error())
+		return false
+	}
+	return true
+}
+
+// convert the args to the param types of the validator func, the args are converted in place.
+func convertArgs(fm *funcMeta, args []interface{}) error {
 	if len(args) == 0 {
-		return true
+		return nil
 	}
 
 	ft := fm.fv.Type()
@@ -330,8 +605,7 @@ func convertArgsType(v *Validation, fm *funcMeta, args []interface{}) (ok bool)
 
 			ak, err := basicKind(av)
 			if err != nil {
-				v.convertArgTypeError(fm.name, av.Kind(), wantTyp)
-				return
+				return argTypeError(fm.name, av.Kind(), lastTyp)
 			}
 
 			// manual converted
@@ -341,8 +615,7 @@ func convertArgsType(v *Validation, fm *funcMeta, args []interface{}) (ok bool)
 			}
 
 			// unable to convert
-			v.convertArgTypeError(fm.name, av.Kind(), wantTyp)
-			return
+			return argTypeError(fm.name, av.Kind(), lastTyp)
 		}
 
 		// "+1" because func first arg is val, need skip it.
@@ -356,8 +629,7 @@ func convertArgsType(v *Validation, fm *funcMeta, args []interface{}) (ok bool)
 
 		ak, err := basicKind(av)
 		if err != nil {
-			v.convertArgTypeError(fm.name, av.Kind(), wantTyp)
-			return
+			return argTypeError(fm.name, av.Kind(), wantTyp)
 		}
 
 		if av.Type().ConvertibleTo(argITyp) { // can auto convert type.
@@ -365,12 +637,19 @@ func convertArgsType(v *Validation, fm *funcMeta, args []interface{}) (ok bool)
 		} else if nVal, _ := convertType(args[i], ak, wantTyp); nVal != nil { // manual converted
 			args[i] = nVal
 		} else { // unable to convert
-			v.convertArgTypeError(fm.name, av.Kind(), wantTyp)
-			return
+			return argTypeError(fm.name, av.Kind(), wantTyp)
 		}
 	}
 
-	return true
+	return nil
+}
+
+// call the validator func, the validator has the ctxFunc is called with the context of the Validation.
+func (v *Validation) callFuncMeta(fm *funcMeta, val interface{}, args []interface{}) bool {
+	if fm.ctxFunc != nil {
+		return fm.ctxFunc(v.context(), val, args)
+	}
+	return callValidatorValue(fm.fv, val, args)
 }
 
 func callValidatorValue(fv reflect.Value, val interface{}, args []interface{}) bool {
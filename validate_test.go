@@ -1,8 +1,12 @@
 package validate
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -64,6 +68,296 @@ func TestStructUseDefault(t *testing.T) {
 	is.Equal("TOM", u.Name)
 }
 
+func TestStructUseDefault_tag(t *testing.T) {
+	is := assert.New(t)
+
+	type user struct {
+		Name    string        `validate:"required" default:"tom"`
+		Age     uint8         `default:"23"`
+		Score   float64       `validate:"min:1" default:"1.5"`
+		Active  bool          `default:"true"`
+		Timeout time.Duration `default:"5m"`
+		Email   string
+	}
+
+	// disabled by default
+	u := &user{Age: 90}
+	v := New(u)
+	is.False(v.Validate())
+	is.Equal(&user{Age: 90}, u)
+
+	Config(func(opt *GlobalOption) {
+		opt.DefaultTag = "default"
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.DefaultTag = ""
+	})
+
+	u = &user{Age: 90}
+	v = New(u)
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(&user{Name: "tom", Age: 90, Score: 1.5, Active: true, Timeout: 5 * time.Minute}, u)
+	is.Equal(uint8(90), v.SafeVal("Age"))
+	is.Equal(1.5, v.SafeVal("Score"))
+	is.Equal(true, v.SafeVal("Active"))
+	is.Equal(5*time.Minute, v.SafeVal("Timeout"))
+	is.NotContains(v.SafeData(), "Email")
+
+	type invalid struct {
+		Age int `default:"abc"`
+	}
+	is.PanicsWithValue(`validate: invalid default value "abc" of the field Age: strconv.ParseInt: parsing "abc": invalid syntax`, func() {
+		New(&invalid{})
+	})
+
+	// the unsupported types are ignored, the tag may be used by the other package
+	type other struct {
+		Tags    []string  `default:"a,b"`
+		Created time.Time `default:"now"`
+		Name    string    `validate:"required" default:"tom"`
+	}
+	o := &other{}
+	v = New(o)
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(&other{Name: "tom"}, o)
+	is.NotContains(v.SafeData(), "Tags")
+}
+
+func TestValidation_SetDefValueFunc(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "inhere"})
+	v.SetDefValue("age", 23)
+	v.SetDefValueFunc("nickname", func(d DataFace) interface{} {
+		name, _ := d.Get("name")
+		return "@" + name.(string)
+	})
+	v.StringRules(MS{"name": "required", "age": "int", "nickname": "string"})
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal(23, v.SafeVal("age"))
+	is.Equal("@inhere", v.SafeVal("nickname"))
+
+	defVal, ok := v.GetDefValue("nickname")
+	is.True(ok)
+	is.Equal("@inhere", defVal)
+
+	// the func is not called on the field exists
+	v = Map(M{"name": "inhere", "nickname": "in"})
+	v.SetDefValueFunc("nickname", func(d DataFace) interface{} {
+		panic("should not be called")
+	})
+	v.StringRule("nickname", "string")
+	is.True(v.Validate())
+	is.Equal("in", v.SafeVal("nickname"))
+}
+
+func TestValidation_ApplyDefaults(t *testing.T) {
+	is := assert.New(t)
+
+	Config(func(opt *GlobalOption) {
+		opt.DefaultTag = "default"
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.DefaultTag = ""
+	})
+
+	type config struct {
+		Host    string        `validate:"required|minLen:10" default:"localhost"`
+		Port    int           `validate:"min:1024" default:"8080"`
+		Timeout time.Duration `default:"30s"`
+	}
+
+	cfg := &config{Port: 80}
+	v := Struct(cfg)
+	is.NoError(v.ApplyDefaults())
+	is.Equal(&config{Host: "localhost", Port: 80, Timeout: 30 * time.Second}, cfg)
+	is.Equal("localhost", v.SafeVal("Host"))
+	is.Nil(v.SafeVal("Port"))
+	is.Empty(v.Errors)
+
+	// validate later, the filled values are validated too
+	is.False(v.Validate())
+	is.Equal("Host min length is 10", v.Errors.One())
+
+	v = Map(M{"name": "inhere"})
+	v.StringRule("role", "in:admin,user|default:user")
+	v.SetDefValue("name", "tom")
+	v.SetDefValueFunc("tags", func(d DataFace) interface{} {
+		return []string{"new"}
+	})
+	is.NoError(v.ApplyDefaults())
+	is.Equal(M{"role": "user", "tags": []string{"new"}}, M(v.SafeData()))
+}
+
+func TestValidation_DefaultMode(t *testing.T) {
+	is := assert.New(t)
+
+	Config(func(opt *GlobalOption) {
+		opt.DefaultTag = "default"
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.DefaultTag = ""
+	})
+
+	type user struct {
+		Name string `validate:"required" default:"tom"`
+		Role string `validate:"in:admin,user" default:"user"`
+	}
+
+	// default: the default value satisfies the "required"
+	u := &user{}
+	v := Struct(u)
+	is.Equal(DefaultBeforeRequired, v.DefaultMode)
+	is.True(v.Validate())
+	is.Equal("tom", u.Name)
+
+	// the "required" enforces the input value
+	u = &user{}
+	v = Struct(u)
+	v.DefaultMode = DefaultAfterRequired
+	is.False(v.Validate())
+	is.Equal("Name is required and not empty", v.Errors.One())
+	is.Equal("", u.Name)
+
+	u = &user{Name: "inhere"}
+	v = Struct(u)
+	v.DefaultMode = DefaultAfterRequired
+	is.True(v.Validate())
+	is.Equal("user", u.Role)
+
+	// the rule order not affect the result, the filled default value is not as the input
+	v = Map(M{})
+	v.DefaultMode = DefaultAfterRequired
+	v.StringRule("age", "int|default:18")
+	v.StringRule("age", "required")
+	is.False(v.Validate())
+	is.Equal("age is required and not empty", v.Errors.One())
+
+	v = Map(M{})
+	v.DefaultMode = DefaultAfterRequired
+	v.StringRule("age", "int|default:18")
+	v.StringRule("age", "required_if:name,inhere")
+	is.True(v.Validate())
+	is.Equal("18", v.SafeVal("age"))
+
+	// the global option
+	Config(func(opt *GlobalOption) {
+		opt.DefaultMode = DefaultAfterRequired
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.DefaultMode = DefaultBeforeRequired
+	})
+	is.Equal(DefaultAfterRequired, Struct(&user{}).DefaultMode)
+}
+
+func TestValidation_pointerFields(t *testing.T) {
+	is := assert.New(t)
+
+	type form struct {
+		Name  *string `validate:"required|minLen:3"`
+		Age   *int    `validate:"min:18"`
+		Email *string `validate:"email"`
+	}
+
+	name, age := "in", 16
+	// default: the nil pointer as the empty value
+	v := Struct(&form{})
+	is.Equal(NilPointerAsEmpty, v.NilPointer)
+	is.False(v.Validate())
+	is.Equal("Name is required and not empty", v.Errors.One())
+
+	// skip all rules of the nil pointer field
+	v = Struct(&form{})
+	v.NilPointer = NilPointerSkip
+	is.True(v.Validate(), v.Errors.One())
+
+	v = Map(M{"name": (*string)(nil)})
+	v.NilPointer = NilPointerSkip
+	v.StringRule("name", "required")
+	is.True(v.Validate())
+
+	// dereference the non-nil pointer
+	v = Struct(&form{Name: &name, Age: &age})
+	v.StopOnError = false
+	v.DerefPointer = true
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("Name min length is 3", v.Errors.FieldOne("Name"))
+	is.Equal("Age min value is 18", v.Errors.FieldOne("Age"))
+
+	name, age = "inhere", 20
+	v = Struct(&form{Name: &name, Age: &age})
+	v.DerefPointer = true
+	v.NilPointer = NilPointerSkip
+	is.True(v.Validate(), v.Errors.One())
+	// the safe value is not changed
+	is.Equal(&name, v.SafeVal("Name"))
+}
+
+// a DB wrapper type, implements the driver.Valuer
+type testDBString struct {
+	str string
+	bad bool
+}
+
+func (s testDBString) Value() (driver.Value, error) {
+	if s.bad {
+		return nil, errors.New("bad value")
+	}
+	return s.str, nil
+}
+
+func TestValidation_UnwrapValuer(t *testing.T) {
+	is := assert.New(t)
+
+	type form struct {
+		Name testDBString  `validate:"required|minLen:3"`
+		Nick *testDBString `validate:"maxLen:5"`
+	}
+
+	// default: not unwrap the value
+	v := Struct(&form{Name: testDBString{str: "inhere"}})
+	is.False(v.UnwrapValuer)
+	is.False(v.Validate())
+	is.Equal("Name min length is 3", v.Errors.One())
+
+	v = Struct(&form{Name: testDBString{str: "in"}})
+	v.UnwrapValuer = true
+	is.False(v.Validate())
+	is.Equal("Name min length is 3", v.Errors.One())
+
+	nick := testDBString{str: "tom"}
+	v = Struct(&form{Name: testDBString{str: "inhere"}, Nick: &nick})
+	v.UnwrapValuer = true
+	is.True(v.Validate(), v.Errors.One())
+	// the safe value is not changed
+	is.Equal(testDBString{str: "inhere"}, v.SafeVal("Name"))
+
+	// the nil pointer is not unwrapped
+	v = Struct(&form{Name: testDBString{str: "inhere"}})
+	v.UnwrapValuer = true
+	is.True(v.Validate(), v.Errors.One())
+
+	// keep the value on the Value() return error
+	v = Map(M{"name": testDBString{bad: true}})
+	v.UnwrapValuer = true
+	v.StringRule("name", "minLen:3")
+	is.False(v.Validate())
+
+	// the global option
+	Config(func(opt *GlobalOption) {
+		opt.UnwrapValuer = true
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.UnwrapValuer = false
+	})
+
+	v = Map(M{"age": sql.NullInt64{Int64: 20, Valid: true}})
+	v.StringRule("age", "min:18")
+	is.True(v.UnwrapValuer)
+	is.True(v.Validate(), v.Errors.One())
+}
+
 func TestValidation_RequiredIf(t *testing.T) {
 	v := New(M{
 		"name": "lee",
@@ -156,3 +450,70 @@ func TestValidation_RequiredWithoutAll(t *testing.T) {
 	v.Validate()
 	assert.Equal(t, "nothing field is required when none of [sex city] are present", v.Errors.One())
 }
+
+func TestValidation_RequiredOneOf(t *testing.T) {
+	is := assert.New(t)
+
+	v := New(M{"email": "", "name": "inhere"})
+	v.AddRule("email,phone", "requiredOneOf")
+	is.False(v.Validate())
+	is.Equal("one of the fields email,phone is required", v.Errors.Field("email,phone")["requiredOneOf"])
+
+	v = New(M{"email": "", "phone": "13688889999"})
+	v.AddRule("email,phone", "requiredOneOf")
+	is.True(v.Validate())
+
+	// the other fields in the arguments
+	v = New(M{"email": ""})
+	v.StringRule("email", "required_one_of:phone,wechat")
+	v.AddTranslates(MS{"email,phone,wechat": "email or phone or wechat"})
+	is.False(v.Validate())
+	is.Equal("one of the fields email or phone or wechat is required", v.Errors.One())
+
+	// on struct
+	type contact struct {
+		Email string `validate:"requiredOneOf:Phone"`
+		Phone string
+	}
+	v = Struct(&contact{Phone: "13688889999"})
+	is.True(v.Validate())
+	v = Struct(&contact{})
+	is.False(v.Validate())
+	is.Contains(v.Errors, "Email,Phone")
+}
+
+func TestValidation_OnlyOneOf(t *testing.T) {
+	is := assert.New(t)
+
+	v := New(M{"couponCode": "SAVE10", "giftCardId": "GC-001"})
+	v.AddRule("couponCode,giftCardId", "onlyOneOf")
+	is.False(v.Validate())
+	is.Equal("only one of the fields couponCode,giftCardId can be present", v.Errors.One())
+
+	// none or one is present
+	v = New(M{"couponCode": "", "giftCardId": "GC-001"})
+	v.AddRule("couponCode,giftCardId,points", "only_one_of")
+	is.True(v.Validate())
+	v = New(M{"name": "inhere"})
+	v.AddRule("couponCode,giftCardId", "onlyOneOf")
+	is.True(v.Validate())
+}
+
+func TestValidation_ExcludesWith(t *testing.T) {
+	is := assert.New(t)
+
+	v := New(M{"couponCode": "SAVE10", "giftCardId": "GC-001"})
+	v.StringRule("couponCode", "excludesWith:giftCardId,points")
+	is.False(v.Validate())
+	is.Equal("couponCode cannot be present together with [giftCardId points]", v.Errors.One())
+
+	v = New(M{"couponCode": "SAVE10", "giftCardId": ""})
+	v.StringRule("couponCode", "excludes_with:giftCardId")
+	is.True(v.Validate())
+	is.Equal("SAVE10", v.SafeVal("couponCode"))
+
+	// the field is empty
+	v = New(M{"giftCardId": "GC-001"})
+	v.StringRule("couponCode", "excludesWith:giftCardId")
+	is.True(v.Validate())
+}
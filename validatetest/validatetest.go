@@ -0,0 +1,160 @@
+// Package validatetest provide the test helpers for the rule sets built on the validate.
+//
+// 	func TestUserForm(t *testing.T) {
+// 		rules := validate.MS{"email": "required|email", "age": "int|min:1"}
+//
+// 		validatetest.AssertPasses(t, validate.M{"email": "a@b.com", "age": 20}, rules)
+// 		validatetest.AssertFails(t, validate.M{"email": "invalid"}, rules)
+//
+// 		v := validate.Map(validate.M{"email": "invalid"})
+// 		v.StringRules(rules)
+// 		validatetest.AssertFieldFails(t, v, "email", "email")
+// 		validatetest.AssertErrorsGolden(t, v, "testdata/user_form.golden")
+// 	}
+//
+// run the tests with the env VALIDATETEST_UPDATE=1 to create or update the golden files.
+package validatetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gookit/validate"
+)
+
+// UpdateEnv the env name for update the golden files. see AssertErrorsGolden()
+const UpdateEnv = "VALIDATETEST_UPDATE"
+
+// UpdateGolden write the golden files instead of compare them. default is set by the env UpdateEnv
+var UpdateGolden = os.Getenv(UpdateEnv) != ""
+
+// TestingT the subset of the *testing.T used by the helpers
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// NewValidation create the validation for the data and rules, it will not stop on the first error.
+// the data is same as the validate.New()
+func NewValidation(data interface{}, rules validate.MS) *validate.Validation {
+	v := validate.New(data)
+	v.StopOnError = false
+	v.StringRules(rules)
+	return v
+}
+
+// AssertPasses assert the data passes the rules
+func AssertPasses(t TestingT, data interface{}, rules validate.MS) bool {
+	t.Helper()
+	v := NewValidation(data, rules)
+	if v.Validate() {
+		return true
+	}
+
+	t.Errorf("validatetest: expected the validation passes, but got the errors:\n%s", formatErrors(v.Errors))
+	return false
+}
+
+// AssertFails assert the data fails the rules. the fields is optional, the errors must on them if set.
+func AssertFails(t TestingT, data interface{}, rules validate.MS, fields ...string) bool {
+	t.Helper()
+	v := NewValidation(data, rules)
+	if v.Validate() {
+		t.Errorf("validatetest: expected the validation fails, but it passes")
+		return false
+	}
+
+	ok := true
+	for _, field := range fields {
+		if _, has := v.Errors[field]; !has {
+			t.Errorf("validatetest: expected the field '%s' fails, the errors:\n%s", field, formatErrors(v.Errors))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertFieldFails assert the field fails by the validator. the validation will be run if it has not validated.
+// the validator is the name in the rule, eg: "email", "minLen"
+func AssertFieldFails(t TestingT, v *validate.Validation, field, validator string) bool {
+	t.Helper()
+	if v.Validate() {
+		t.Errorf("validatetest: expected the field '%s' fails by '%s', but the validation passes", field, validator)
+		return false
+	}
+
+	if _, has := v.Errors[field][validator]; !has {
+		t.Errorf("validatetest: expected the field '%s' fails by '%s', the errors:\n%s", field, validator, formatErrors(v.Errors))
+		return false
+	}
+	return true
+}
+
+// AssertFieldPasses assert the field has no errors. the validation will be run if it has not validated.
+func AssertFieldPasses(t TestingT, v *validate.Validation, field string) bool {
+	t.Helper()
+	v.Validate()
+	if fe, has := v.Errors[field]; has {
+		t.Errorf("validatetest: expected the field '%s' passes, but got the errors: %v", field, fe)
+		return false
+	}
+	return true
+}
+
+// AssertErrorsGolden assert the error details of the validation equals the golden file.
+// the validation will be run if it has not validated. the file is the indented JSON of the validate.ErrorDetail list.
+// set the UpdateGolden(or the env UpdateEnv) to create or update the file.
+func AssertErrorsGolden(t TestingT, v *validate.Validation, file string) bool {
+	t.Helper()
+	v.Validate()
+
+	got, err := json.MarshalIndent(v.ErrorDetails(), "", "  ")
+	if err != nil {
+		t.Errorf("validatetest: marshal the errors failed: %v", err)
+		return false
+	}
+	got = append(got, '\n')
+
+	if UpdateGolden {
+		if err = os.MkdirAll(filepath.Dir(file), 0755); err == nil {
+			err = ioutil.WriteFile(file, got, 0644)
+		}
+		if err != nil {
+			t.Errorf("validatetest: write the golden file '%s' failed: %v", file, err)
+			return false
+		}
+		return true
+	}
+
+	want, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Errorf("validatetest: read the golden file '%s' failed: %v (set the env %s=1 to create it)", file, err, UpdateEnv)
+		return false
+	}
+
+	// ignore the line ending differences. eg: checkout on windows
+	want = bytes.Replace(want, []byte("\r\n"), []byte("\n"), -1)
+	if !bytes.Equal(want, got) {
+		t.Errorf("validatetest: the errors not match the golden file '%s'\n--- want:\n%s\n--- got:\n%s", file, want, got)
+		return false
+	}
+	return true
+}
+
+// format the errors sorted by the field and validator
+func formatErrors(es validate.Errors) string {
+	lines := make([]string, 0, len(es))
+	for field, fe := range es {
+		for name, msg := range fe {
+			lines = append(lines, "  "+field+"."+name+": "+msg)
+		}
+	}
+
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
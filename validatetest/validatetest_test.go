@@ -0,0 +1,122 @@
+package validatetest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeT struct {
+	errs []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+var userRules = validate.MS{
+	"email": "required|email",
+	"age":   "int|min:1",
+}
+
+func TestAssertPasses(t *testing.T) {
+	is := assert.New(t)
+
+	ft := &fakeT{}
+	is.True(AssertPasses(ft, validate.M{"email": "a@b.com", "age": 20}, userRules))
+	is.Empty(ft.errs)
+
+	is.False(AssertPasses(ft, validate.M{"email": "invalid", "age": -1}, userRules))
+	is.Len(ft.errs, 1)
+	is.Contains(ft.errs[0], "\n  age.min: age min value is 1\n  email.email: ")
+
+	// use the real testing.T
+	AssertPasses(t, validate.M{"email": "a@b.com"}, userRules)
+}
+
+func TestAssertFails(t *testing.T) {
+	is := assert.New(t)
+
+	ft := &fakeT{}
+	is.True(AssertFails(ft, validate.M{"email": "invalid"}, userRules, "email"))
+	is.Empty(ft.errs)
+
+	is.False(AssertFails(ft, validate.M{"email": "invalid"}, userRules, "age"))
+	is.Contains(ft.errs[0], "the field 'age' fails")
+
+	ft = &fakeT{}
+	is.False(AssertFails(ft, validate.M{"email": "a@b.com"}, userRules))
+	is.Contains(ft.errs[0], "but it passes")
+}
+
+func TestAssertFieldFails(t *testing.T) {
+	is := assert.New(t)
+
+	ft := &fakeT{}
+	v := NewValidation(validate.M{"email": "invalid", "age": 20}, userRules)
+	is.True(AssertFieldFails(ft, v, "email", "email"))
+	is.True(AssertFieldPasses(ft, v, "age"))
+	is.Empty(ft.errs)
+
+	is.False(AssertFieldFails(ft, v, "email", "required"))
+	is.False(AssertFieldFails(ft, v, "age", "min"))
+	is.False(AssertFieldPasses(ft, v, "email"))
+	is.Len(ft.errs, 3)
+
+	ft = &fakeT{}
+	v = NewValidation(validate.M{"email": "a@b.com"}, userRules)
+	is.False(AssertFieldFails(ft, v, "email", "email"))
+	is.Contains(ft.errs[0], "but the validation passes")
+}
+
+func TestAssertErrorsGolden(t *testing.T) {
+	is := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "validatetest")
+	is.NoError(err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "sub", "user.golden")
+
+	update := UpdateGolden
+	UpdateGolden = false
+	defer func() {
+		UpdateGolden = update
+	}()
+
+	newV := func() *validate.Validation {
+		return NewValidation(validate.M{"email": "invalid", "age": -1}, userRules)
+	}
+
+	// the file not exists
+	ft := &fakeT{}
+	is.False(AssertErrorsGolden(ft, newV(), file))
+	is.Contains(ft.errs[0], UpdateEnv+"=1")
+
+	UpdateGolden = true
+	ft = &fakeT{}
+	is.True(AssertErrorsGolden(ft, newV(), file))
+	UpdateGolden = false
+	is.Empty(ft.errs)
+
+	bs, err := ioutil.ReadFile(file)
+	is.NoError(err)
+	is.Contains(string(bs), `"code": "isEmail"`)
+
+	is.True(AssertErrorsGolden(ft, newV(), file))
+	is.Empty(ft.errs)
+
+	v := NewValidation(validate.M{"email": "invalid", "age": 2}, userRules)
+	is.False(AssertErrorsGolden(ft, v, file))
+	is.Contains(ft.errs[0], "not match the golden file")
+
+	// the committed golden file
+	UpdateGolden = update
+	AssertErrorsGolden(t, newV(), "testdata/user_errors.golden")
+}
@@ -1,8 +1,14 @@
 package validate
 
 import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // some default value settings.
@@ -11,6 +17,16 @@ const (
 	filterError   = "_filter"
 	validateTag   = "validate"
 	validateError = "_validate"
+	// the message key for the aggregated errors
+	aggregateError = "_aggregate"
+	// the field name for Validation.Check()
+	checkField = "value"
+	// separators in the rule string. eg: "required|minLen:6|in:a,b"
+	ruleSep      = "|"
+	validatorSep = ":"
+	argSep       = ","
+	// the confirmation field name suffix. eg: "password_confirmation"
+	confirmSuffix = "_confirmation"
 	// sniff Length, use for detect file mime type
 	sniffLen = 512
 	// 32 MB
@@ -26,12 +42,37 @@ type MS map[string]string
 // SValues simple values
 type SValues map[string][]string
 
+// DefaultMode the interaction mode of the default values and the "required" validators
+type DefaultMode uint8
+
+// the default value modes. see GlobalOption.DefaultMode
+const (
+	// DefaultBeforeRequired apply the default values before the "required" check, the default value satisfies the "required".
+	DefaultBeforeRequired DefaultMode = iota
+	// DefaultAfterRequired apply the default values after the "required" check, the "required" still enforces the input value.
+	DefaultAfterRequired
+)
+
+// NilPointerMode how to treat the nil pointer fields
+type NilPointerMode uint8
+
+// the nil pointer modes. see GlobalOption.NilPointer
+const (
+	// NilPointerAsEmpty treat the nil pointer as the empty value, the "required" will fail, the other rules are skipped.
+	NilPointerAsEmpty NilPointerMode = iota
+	// NilPointerSkip skip all the rules of the nil pointer field, include the "required".
+	NilPointerSkip
+)
+
 // GlobalOption settings for validate
 type GlobalOption struct {
 	// FilterTag name in the struct tags.
 	FilterTag string
 	// ValidateTag in the struct tags.
 	ValidateTag string
+	// DefaultTag the default value tag name in the struct tags. eg: "default" for the `default:"18"`
+	// default is empty, the default value tag is not parsed.
+	DefaultTag string
 	// StopOnError If true: An error occurs, it will cease to continue to verify
 	StopOnError bool
 	// SkipOnEmpty Skip check on field not exist or value is empty
@@ -40,8 +81,60 @@ type GlobalOption struct {
 	UpdateSource bool
 	// CheckDefault Whether to validate the default value set by the user
 	CheckDefault bool
+	// DefaultMode the interaction of the default values and the "required" validators. default is DefaultBeforeRequired
+	DefaultMode DefaultMode
+	// NilPointer how to treat the nil pointer fields. default is NilPointerAsEmpty
+	NilPointer NilPointerMode
+	// DerefPointer Whether to dereference the non-nil pointer value before validate. eg: *string => string
+	DerefPointer bool
 	// CheckZero Whether validate the default zero value. (intX,uintX: 0, string: "")
 	CheckZero bool
+	// StripReadOnly If true: strip the read-only field from the safe data instead of reject it.
+	StripReadOnly bool
+	// RuleSep the separator of the validators/filters in the rule string. default is "|"
+	RuleSep string
+	// ValidatorSep the separator between the validator/filter name and args. default is ":"
+	ValidatorSep string
+	// ArgSep the separator of the validator/filter args. default is ","
+	ArgSep string
+	// ConfirmSuffix the suffix of the confirmation field name for the validator "confirmed". default is "_confirmation"
+	ConfirmSuffix string
+	// ErrorsJSONShape the JSON shape on marshal the Errors. default is ShapeNested
+	ErrorsJSONShape ErrorsShape
+	// FieldNaming the key naming of the struct fields in the Errors and SafeData. default is NamingOriginal
+	FieldNaming FieldNaming
+	// CheckValidatable Whether to call the IsValid()/Validate() method of the field value. default is False
+	// the empty field values are skipped. see ValidatableFace, ValidatableErrFace
+	CheckValidatable bool
+	// NumberLeadingZeros allow the leading zeros in the number string for the validators
+	// "isInt", "isUint", "isFloat", "isIntString". eg: "007". default is False
+	NumberLeadingZeros bool
+	// NumberPlusSign allow the explicit plus sign in the number string. eg: "+12". default is False
+	NumberPlusSign bool
+	// NumberUnderscores allow the underscores as the digit separators in the number string. eg: "1_000". default is False
+	NumberUnderscores bool
+	// NumberIntString allow the validator "isInt" to check the int string. eg: "12". default is False
+	NumberIntString bool
+	// UnwrapValuer Whether to call the Value() of the driver.Valuer value before validate. eg: the DB wrapper types
+	UnwrapValuer bool
+	// PrefetchWorkers the max number of the I/O validators are called concurrently before apply the rules.
+	// default is 0, disable the prefetch. see MarkIOValidators()
+	PrefetchWorkers int
+	// ValidatorNameHook map the custom validator names to the known names, return the name as it is on no need map.
+	// useful for the historical validator names. eg: "max_length" => "maxLength"
+	ValidatorNameHook func(name string) string
+	// TimeLayouts the layouts for the filters "toTime" and "toDate" when the rule has no layout.
+	// default is empty, will auto detect the common date formats.
+	TimeLayouts []string
+	// CountRunes Whether to count the unicode runes as the string length for the validators "len", "minLen", "maxLen".
+	// default is false, count the bytes. eg: "中文" is 6 bytes, 2 runes
+	CountRunes bool
+	// RegexpCacheSize the max number of the compiled patterns are cached for the validator "regexp".
+	// default is 256, the least recently used is evicted. set 0 to disable the cache
+	RegexpCacheSize int
+	// JSONUseNumber Whether to decode the JSON numbers as the json.Number, keep the big integers and the decimals exactly.
+	// default is false, the numbers are float64. it is not used on the Unmarshal is replaced
+	JSONUseNumber bool
 }
 
 var globalOpt = &GlobalOption{
@@ -51,12 +144,24 @@ var globalOpt = &GlobalOption{
 	FilterTag: filterTag,
 	// tag name in struct tags
 	ValidateTag: validateTag,
+	// the compiled patterns cache
+	RegexpCacheSize: 256,
+	// separators in the rule string
+	RuleSep:      ruleSep,
+	ValidatorSep: validatorSep,
+	ArgSep:       argSep,
+	// the confirmation field name suffix
+	ConfirmSuffix: confirmSuffix,
+	// call the validatable method of the field value
+	CheckValidatable: false,
 }
 
 // Validation definition
 type Validation struct {
 	// source input data
 	data DataFace
+	// the original data before update. see WithOriginal()
+	original DataFace
 	// all validated fields list
 	// fields []string
 	// filtered/validated safe data
@@ -75,10 +180,66 @@ type Validation struct {
 	UpdateSource bool
 	// CheckDefault Whether to validate the default value set by the user
 	CheckDefault bool
+	// DefaultMode the interaction of the default values and the "required" validators.
+	// default use GlobalOption.DefaultMode
+	DefaultMode DefaultMode
+	// UnwrapValuer Whether to call the Value() of the driver.Valuer value before validate.
+	// default use GlobalOption.UnwrapValuer
+	UnwrapValuer bool
+	// PrefetchWorkers the max number of the I/O validators are called concurrently.
+	// default use GlobalOption.PrefetchWorkers
+	PrefetchWorkers int
+	// NilPointer how to treat the nil pointer fields. default use GlobalOption.NilPointer
+	NilPointer NilPointerMode
+	// DerefPointer Whether to dereference the non-nil pointer value before validate.
+	// default use GlobalOption.DerefPointer
+	DerefPointer bool
+	// StripReadOnly If true: strip the read-only field from the safe data instead of reject it.
+	// see the validator "readOnlyInScene"
+	StripReadOnly bool
+	// RuleSep the separator of the validators/filters in the rule string. default use GlobalOption.RuleSep
+	RuleSep string
+	// ValidatorSep the separator between the validator/filter name and args. default use GlobalOption.ValidatorSep
+	ValidatorSep string
+	// ArgSep the separator of the validator/filter args. default use GlobalOption.ArgSep
+	ArgSep string
+	// ConfirmSuffix the suffix of the confirmation field name. default use GlobalOption.ConfirmSuffix
+	ConfirmSuffix string
+	// FieldNaming the key naming of the struct fields in the Errors and SafeData.
+	// default use GlobalOption.FieldNaming
+	FieldNaming FieldNaming
+	// CheckValidatable Whether to call the IsValid()/Validate() method of the field value.
+	// default use GlobalOption.CheckValidatable. see ValidatableFace, ValidatableErrFace
+	CheckValidatable bool
+	// AggregateErrors If true: aggregate the same failures of the wildcard field elements to one error.
+	// eg: "items.*.sku" => "17 elements failed minLen:3 (indexes 2,5,...)"
+	AggregateErrors bool
+	// ErrorDescription If true: append the rule/field description to the error message.
+	// see Rule.SetDescription() and Validation.WithDescriptions()
+	ErrorDescription bool
+	// Debug If true: will record the processing steps of each field value. see Trace()
+	Debug bool
 	// CachingRules switch. default is False
 	// CachingRules bool
 	// save user set default values
 	defValues map[string]interface{}
+	// the fields are filled by the default values
+	defaulted map[string]bool
+	// the prefetched I/O validator calls
+	prefetched map[prefetchKey]*prefetchTask
+	// the context and the checker for the validators "unique" and "exists". see RecordChecker
+	ctx           context.Context
+	recordChecker RecordChecker
+	// the first error returned by the RecordChecker
+	recordErr error
+	// the progress callback for the slice data. see OnProgress()
+	progressFn func(p BatchProgress)
+	// the progress tracker of the slice data on validating
+	batch *batchTracker
+	// the policy for render the error details. see RenderErrors()
+	redactPolicy *RedactPolicy
+	// the reason of the last failed validator, use for build the error message. eg: the "password"
+	failReason *failReason
 	// mark has error occurs
 	hasError bool
 	// mark is filtered
@@ -103,12 +264,36 @@ type Validation struct {
 	// 	"update": {"field0", "field2"}
 	// }
 	scenes SValues
+	// the parent scenes of the scene. see SceneExtends()
+	sceneParents SValues
+	// resolve the scene from the data. see SceneFunc()
+	sceneFunc func(d DataFace) string
+	// the scene fields are computed by the predicate. see SceneFieldsFunc()
+	scenePredicates map[string]func(field string, d DataFace) bool
 	// should checked fields in current scene.
 	sceneFields map[string]uint8
 	// filtering rules for the validation
 	filterRules []*FilterRule
 	// filter func reflect.Value map
 	filterValues map[string]reflect.Value
+	// processing steps of the field values. available on Debug is true
+	traces map[string][]TraceStep
+	// the read-only fields will be strip from the safe data
+	stripFields []string
+	// field descriptions. {field: description}
+	descriptions map[string]string
+	// the rule overlay key. see WithOverlay()
+	overlay string
+	// the validator arguments of the errors. {"field.validator": args}
+	errArgs map[string][]interface{}
+	// collect all failed validators of the failed fields. see CollectAllFieldErrors()
+	collectAll bool
+	// reject the unknown fields in the input data. see StrictFields()
+	strictFields bool
+	// the allowed fields on strict mode, although no rules for them.
+	allowedFields map[string]bool
+	// only validate the fields present in the data. see SetPartial()
+	partial bool
 }
 
 // NewEmpty new validation instance, but not add data.
@@ -133,6 +318,27 @@ func NewValidation(data DataFace, scene ...string) *Validation {
 		// default config
 		StopOnError: globalOpt.StopOnError,
 		SkipOnEmpty: globalOpt.SkipOnEmpty,
+		// the default values and the "required" interaction
+		DefaultMode: globalOpt.DefaultMode,
+		// the pointer fields
+		NilPointer:   globalOpt.NilPointer,
+		DerefPointer: globalOpt.DerefPointer,
+		// the driver.Valuer values
+		UnwrapValuer: globalOpt.UnwrapValuer,
+		// the concurrent I/O validators
+		PrefetchWorkers: globalOpt.PrefetchWorkers,
+		// strip the read-only fields
+		StripReadOnly: globalOpt.StripReadOnly,
+		// separators in the rule string
+		RuleSep:      globalOpt.RuleSep,
+		ValidatorSep: globalOpt.ValidatorSep,
+		ArgSep:       globalOpt.ArgSep,
+		// the confirmation field name suffix
+		ConfirmSuffix: globalOpt.ConfirmSuffix,
+		// the key naming of the struct fields
+		FieldNaming: globalOpt.FieldNaming,
+		// call the validatable method of the field value
+		CheckValidatable: globalOpt.CheckValidatable,
 	}
 
 	// init build in context validator
@@ -144,6 +350,16 @@ func NewValidation(data DataFace, scene ...string) *Validation {
 		"requiredWithAll":    reflect.ValueOf(v.RequiredWithAll),
 		"requiredWithout":    reflect.ValueOf(v.RequiredWithout),
 		"requiredWithoutAll": reflect.ValueOf(v.RequiredWithoutAll),
+		// compare with the original data
+		"changedRequires": reflect.ValueOf(v.ChangedRequires),
+		"immutable":       reflect.ValueOf(v.Immutable),
+		"readOnlyInScene": reflect.ValueOf(v.ReadOnlyInScene),
+		"confirmed":       reflect.ValueOf(v.Confirmed),
+		// check the record in the storage
+		"unique": reflect.ValueOf(v.Unique),
+		"exists": reflect.ValueOf(v.Exists),
+		// check the password by the policy
+		"password": reflect.ValueOf(v.Password),
 		// field compare
 		"eqField":  reflect.ValueOf(v.EqField),
 		"neField":  reflect.ValueOf(v.NeField),
@@ -151,16 +367,37 @@ func NewValidation(data DataFace, scene ...string) *Validation {
 		"gteField": reflect.ValueOf(v.GteField),
 		"ltField":  reflect.ValueOf(v.LtField),
 		"lteField": reflect.ValueOf(v.LteField),
+		// compare with sibling fields
+		"differentFrom": reflect.ValueOf(v.DifferentFrom),
+		"allDifferent":  reflect.ValueOf(v.AllDifferent),
+		"sumTo":         reflect.ValueOf(v.SumTo),
+		"excludesWith":  reflect.ValueOf(v.ExcludesWith),
+		// similarity with the field
+		"notSimilarToField": reflect.ValueOf(v.NotSimilarToField),
+		// color contrast with the field
+		"contrastWithField": reflect.ValueOf(v.ContrastWithField),
 		// file upload check
 		"isFile":      reflect.ValueOf(v.IsFile),
 		"isImage":     reflect.ValueOf(v.IsImage),
 		"inMimeTypes": reflect.ValueOf(v.InMimeTypes),
+		// archive file
+		"archiveMaxEntries": reflect.ValueOf(v.ArchiveMaxEntries),
+		"archiveMaxSize":    reflect.ValueOf(v.ArchiveMaxSize),
+		"archiveExts":       reflect.ValueOf(v.ArchiveExts),
 	}
 
 	v.validatorMetas = make(map[string]*funcMeta)
 
 	// collect meta info
 	for n, fv := range v.validatorValues {
+		// the global validator of the same name added by the user takes precedence. eg: "unique"
+		if overridableValidators[n] {
+			if _, ok := validatorMetas[n]; ok {
+				delete(v.validatorValues, n)
+				continue
+			}
+		}
+
 		v.validators[n] = 1 // built in
 		v.validatorMetas[n] = newFuncMeta(n, true, fv)
 	}
@@ -168,6 +405,9 @@ func NewValidation(data DataFace, scene ...string) *Validation {
 	return v.SetScene(scene...)
 }
 
+// the context validators can be overridden by the global validators of the same name. see AddValidator()
+var overridableValidators = map[string]bool{"unique": true, "exists": true, "password": true}
+
 func newWithError(d DataFace, err error) *Validation {
 	if d == nil {
 		if err != nil {
@@ -194,7 +434,14 @@ func (v *Validation) ResetResult() {
 	v.hasError = false
 	v.hasFiltered = false
 	v.hasValidated = false
+	v.traces = nil
+	v.stripFields = nil
+	v.errArgs = nil
 	// result data
+	v.defaulted = nil
+	v.prefetched = nil
+	v.recordErr = nil
+	v.failReason = nil
 	v.safeData = make(map[string]interface{})
 	v.filteredData = make(map[string]interface{})
 }
@@ -226,6 +473,60 @@ func (v *Validation) WithScenes(scenes map[string][]string) *Validation {
 	return v
 }
 
+// SceneExtends set the scene extends the parent scene, the extra fields will be added to the scene.
+// the parents are resolved at validate time, so the parent scene can be set later.
+// Usage:
+// 	v.WithScenes(SValues{
+// 		"base": []string{"name", "email"},
+// 	})
+// 	v.SceneExtends("create", "base", "password")
+// 	v.SceneExtends("update", "base", "id")
+func (v *Validation) SceneExtends(scene, parent string, fields ...string) *Validation {
+	if v.scenes == nil {
+		v.scenes = make(SValues)
+	}
+
+	if v.sceneParents == nil {
+		v.sceneParents = make(SValues)
+	}
+
+	v.sceneParents[scene] = append(v.sceneParents[scene], parent)
+	v.scenes[scene] = append(v.scenes[scene], fields...)
+	return v
+}
+
+// SceneFunc set the func to resolve the scene from the data at validate time.
+// the scene given by Validate(scene) will take precedence, and an empty result will keep the current scene.
+// Usage:
+// 	v.SceneFunc(func(d validate.DataFace) string {
+// 		if typ, _ := d.Get("type"); typ == "company" {
+// 			return "company"
+// 		}
+// 		return "person"
+// 	})
+func (v *Validation) SceneFunc(fn func(d DataFace) string) *Validation {
+	v.sceneFunc = fn
+	return v
+}
+
+// SceneFieldsFunc set the predicate to compute the fields of the scene at validate time,
+// the fields of the rules that the predicate returns true are added to the scene.
+// the predicate can be used with the scene fields config, the "!field" exclusions are still applied.
+// Usage:
+// 	// the scene "update" = the fields the client actually sent
+// 	v.SceneFieldsFunc("update", func(field string, d validate.DataFace) bool {
+// 		_, ok := d.Get(field)
+// 		return ok
+// 	})
+func (v *Validation) SceneFieldsFunc(scene string, fn func(field string, d DataFace) bool) *Validation {
+	if v.scenePredicates == nil {
+		v.scenePredicates = make(map[string]func(field string, d DataFace) bool)
+	}
+
+	v.scenePredicates[scene] = fn
+	return v
+}
+
 // AtScene setting current validate scene.
 func (v *Validation) AtScene(scene string) *Validation {
 	v.scene = scene
@@ -245,6 +546,54 @@ func (v *Validation) SetScene(scene ...string) *Validation {
 	return v
 }
 
+// WithOriginal set the original data(eg: the currently stored record) for the update scene.
+// allow: DataFace, map, struct. rules can compare the submitted values against it.
+// Usage:
+// 	v := validate.Map(input)
+// 	v.WithOriginal(user)
+// 	v.StringRule("email", "changedRequires:password")
+func (v *Validation) WithOriginal(orig interface{}) *Validation {
+	var err error
+	switch td := orig.(type) {
+	case nil:
+		v.original = nil
+	case DataFace:
+		v.original = td
+	case M:
+		v.original = FromMap(td)
+	case map[string]interface{}:
+		v.original = FromMap(td)
+	default:
+		v.original, err = FromStruct(orig)
+	}
+
+	return v.WithError(err)
+}
+
+// Original get the field value from the original data
+func (v *Validation) Original(field string) (interface{}, bool) {
+	if v.original == nil {
+		return nil, false
+	}
+	return v.original.Get(field)
+}
+
+// IsChanged check the field value is different from the original value.
+// return false if the field is not submitted or no original data.
+func (v *Validation) IsChanged(field string) bool {
+	if v.original == nil {
+		return false
+	}
+
+	val, has := v.Get(field)
+	if !has {
+		return false
+	}
+
+	oldVal, _ := v.Original(field)
+	return !isSameValue(val, oldVal)
+}
+
 /*************************************************************
  * add validators for validation
  *************************************************************/
@@ -306,6 +655,28 @@ func (v *Validation) HasValidator(name string) bool {
 	return ok
 }
 
+// UnresolvedValidators get the validator names used in the rules but cannot be resolved.
+// the names are as written in the rules. useful for check the rules on migrate from the other libraries.
+func (v *Validation) UnresolvedValidators() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, r := range v.rules {
+		if seen[r.validator] || r.checkFuncMeta != nil {
+			continue
+		}
+		seen[r.validator] = true
+
+		name := ValidatorName(r.validator)
+		if name == "-" || name == "safe" || name == "keys" || isGroupValidator(name) || v.validatorMeta(name) != nil {
+			continue
+		}
+		names = append(names, r.validator)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
 // Validators get all validator names
 func (v *Validation) Validators(withGlobal bool) map[string]int {
 	if withGlobal {
@@ -336,34 +707,125 @@ func (v *Validation) Validate(scene ...string) bool {
 
 	// init scene info
 	v.SetScene(scene...)
+	if len(scene) == 0 && v.sceneFunc != nil && v.data != nil {
+		if name := v.sceneFunc(v.data); name != "" {
+			v.scene = name
+		}
+	}
+	// resolve the rule overlay
+	v.applyOverlay()
 	v.sceneFields = v.sceneFieldMap()
+	// use the named keys as the field display names
+	v.initFieldNaming()
 
 	// apply filter rules before validate.
 	if false == v.Filtering() && v.StopOnError {
 		return false
 	}
 
-	// apply rule to validate data.
-	for _, rule := range v.rules {
-		if rule.Apply(v) {
+	// reject the unknown fields on strict mode
+	v.validateUnknownFields()
+	// call the I/O validators concurrently, the results are joined on apply the rules.
+	v.prefetch()
+
+	// apply rule to validate data. report the progress of the slice data
+	v.batch = v.newBatchTracker()
+	for i, rule := range v.rules {
+		if v.shouldStopRule() || v.batch.canceled(v) || rule.Apply(v) {
 			break
 		}
+		v.batch.ruleDone(v, i)
 	}
+	v.batch = nil
 
 	v.hasValidated = true
+	// the field values validate themselves
+	if !v.shouldStopRule() {
+		v.validateValidatable()
+	}
+
+	// struct level validation
+	if !v.shouldStop() {
+		v.validateStruct()
+	}
+
+	v.traceFinal()
 	if v.hasError {
 		// clear safe data on error.
 		v.safeData = make(map[string]interface{})
 	}
+
+	// strip the read-only fields
+	for _, field := range v.stripFields {
+		delete(v.safeData, field)
+	}
+
+	v.applyFieldNaming()
 	return v.IsSuccess()
 }
 
+// call the struct level validation. see StructValidatorFace, StructErrorFace
+func (v *Validation) validateStruct() {
+	sd, ok := v.data.(*StructData)
+	if !ok {
+		return
+	}
+
+	if sv, ok := sd.src.(StructValidatorFace); ok {
+		sv.Validate(v)
+	}
+
+	if se, ok := sd.src.(StructErrorFace); ok {
+		err := se.ValidateStruct()
+		if es, ok := err.(Errors); ok {
+			for field, fe := range es {
+				for validator, msg := range fe {
+					v.AddError(field, validator, msg)
+				}
+			}
+		} else if err != nil {
+			v.AddError(validateError, validateError, err.Error())
+		}
+	}
+}
+
 // ValidateData validate given data
 func (v *Validation) ValidateData(data DataFace) bool {
 	v.data = data
 	return v.Validate()
 }
 
+// Check the value by an registered validator, return the result and error message.
+// it is useful for call other validators in the custom validator.
+// Notice: the "{field}" in the message will be replaced to "value".
+//
+// Usage:
+// 	v.AddValidator("contact", func(val string) bool {
+// 		if ok, _ := v.Check("email", val); ok {
+// 			return true
+// 		}
+// 		ok, _ := v.Check("cnMobile", val)
+// 		return ok
+// 	})
+func (v *Validation) Check(validator string, val interface{}, args ...interface{}) (ok bool, msg string) {
+	name := ValidatorName(validator)
+	if isFileValidator(name) { // need the field name, not support.
+		return false, v.trans.Message(validator, checkField, args...)
+	}
+
+	if len(args) > 1 && isEnumValidator(name) {
+		args = []interface{}{args}
+	}
+
+	r := NewRule(checkField, validator, args...)
+	isNotRequired := !strings.HasPrefix(name, "required")
+	if ok = r.valueValidate(checkField, name, isNotRequired, val, v); ok {
+		return
+	}
+
+	return false, v.trans.Message(validator, checkField, r.arguments...)
+}
+
 /*************************************************************
  * Do filtering/sanitize
  *************************************************************/
@@ -411,6 +873,12 @@ func (v *Validation) AddTranslates(m map[string]string) {
 	v.trans.AddFieldMap(m)
 }
 
+// SetLocale set the locale for the field labels in the error messages. see AddFieldLabels()
+func (v *Validation) SetLocale(locale string) *Validation {
+	v.trans.SetLocale(locale)
+	return v
+}
+
 // WithMessages settings. you can custom validator error messages.
 // Usage:
 // 	v.WithMessages(map[string]string{
@@ -436,6 +904,68 @@ func (v *Validation) WithError(err error) *Validation {
 	return v
 }
 
+// CollectAllFieldErrors on enable, all validators of the failed field will be applied and the messages are reported.
+// on StopOnError is true, the other fields will be skipped after the first failed field.
+func (v *Validation) CollectAllFieldErrors(enable bool) *Validation {
+	v.collectAll = enable
+	return v
+}
+
+// SetPartial on enable, only the fields present in the data will be validated. like the JSON merge-patch semantics,
+// the "required" is only enforced for the present fields, and the defaults are not applied for the absent fields.
+// Usage:
+// 	v := validate.JSON(patchBody).SetPartial(true)
+// 	v.StringRule("name", "required|minLen:3") // skip on the "name" not in the body
+func (v *Validation) SetPartial(enable bool) *Validation {
+	v.partial = enable
+	return v
+}
+
+// check the field is present in the input data, the uploaded files are included.
+func (v *Validation) hasInputField(field string) bool {
+	if v.data == nil {
+		return false
+	}
+
+	_, exist := v.data.Get(field)
+	return exist
+}
+
+// WithDescriptions set the descriptions for the fields.
+// Usage:
+// 	v.WithDescriptions(MS{
+// 		"name": "Username shown on your public profile; 3-32 chars",
+// 	})
+func (v *Validation) WithDescriptions(m map[string]string) *Validation {
+	if v.descriptions == nil {
+		v.descriptions = make(map[string]string, len(m))
+	}
+
+	for field, desc := range m {
+		v.descriptions[field] = desc
+	}
+	return v
+}
+
+// Descriptions get the descriptions of all fields. the rule description will override the field description.
+func (v *Validation) Descriptions() map[string]string {
+	mp := make(map[string]string, len(v.descriptions))
+	for field, desc := range v.descriptions {
+		mp[field] = desc
+	}
+
+	for _, r := range v.rules {
+		if r.description == "" {
+			continue
+		}
+
+		for _, field := range r.fields {
+			mp[field] = r.description
+		}
+	}
+	return mp
+}
+
 // AddError message for a field
 func (v *Validation) AddError(field, validator, msg string) {
 	if !v.hasError {
@@ -443,6 +973,32 @@ func (v *Validation) AddError(field, validator, msg string) {
 	}
 
 	v.Errors.Add(field, validator, msg)
+	v.batch.addFailed(field)
+}
+
+// add the error of the rule, will record the validator arguments.
+func (v *Validation) addRuleError(field string, r *Rule, msg string) {
+	if len(r.arguments) > 0 {
+		if v.errArgs == nil {
+			v.errArgs = make(map[string][]interface{})
+		}
+		v.errArgs[field+"."+r.validator] = r.arguments
+	}
+
+	v.AddError(field, r.validator, msg)
+}
+
+// ErrorDetails get the structured errors with the validator arguments.
+// Usage:
+// 	for _, d := range v.ErrorDetails() {
+// 		fmt.Println(d.Field, d.Code, d.Args, d.Message)
+// 	}
+func (v *Validation) ErrorDetails() []ErrorDetail {
+	details := v.Errors.Detailed()
+	for i, d := range details {
+		details[i].Args = v.errArgs[d.Field+"."+d.Validator]
+	}
+	return details
 }
 
 // AddErrorf add a formatted error message
@@ -451,7 +1007,11 @@ func (v *Validation) AddErrorf(field, msgFormat string, args ...interface{}) {
 }
 
 func (v *Validation) convertArgTypeError(name string, argKind, wantKind reflect.Kind) {
-	v.AddErrorf("_convert", "cannot convert %s to %s, validator '%s'", argKind, wantKind, name)
+	v.AddError("_convert", validateError, argTypeError(name, argKind, wantKind).Error())
+}
+
+func argTypeError(name string, argKind, wantKind reflect.Kind) error {
+	return fmt.Errorf("cannot convert %s to %s, validator '%s'", argKind, wantKind, name)
 }
 
 /*************************************************************
@@ -499,7 +1059,9 @@ func (v *Validation) Safe(key string) (val interface{}, ok bool) {
 		return
 	}
 
-	val, ok = v.safeData[key]
+	if val, ok = v.safeData[key]; !ok && v.FieldNaming != NamingOriginal {
+		val, ok = v.safeData[v.namedKey(key)]
+	}
 	return
 }
 
@@ -515,19 +1077,21 @@ func (v *Validation) GetSafe(key string) interface{} {
 	return val
 }
 
-// BindSafeData to a struct.
+// BindSafeData to a struct. the filtered values will be converted to the field type.
+//
+// - field name: use the json tag name, otherwise the field name(case-insensitive)
+// - dot path field(eg: "addr.city") will be bind to the nested struct
+// - time.Time field: allow the date string and the unix timestamp, can custom layout by tag. eg: `layout:"2006-01-02"`
+// - pointer field: will be auto created
+// - json.Unmarshaler field: will be bind by the UnmarshalJSON method
+//
+// on the Unmarshal func is replaced or the target is a json.Unmarshaler, will bind by the Marshal and Unmarshal.
 func (v *Validation) BindSafeData(ptr interface{}) error {
 	if len(v.safeData) == 0 { // no safe data.
 		return nil
 	}
 
-	// to json bytes
-	bts, err := Marshal(v.safeData)
-	if err != nil {
-		return err
-	}
-
-	return Unmarshal(bts, ptr)
+	return bindData(v.safeData, ptr)
 }
 
 // Set value by key
@@ -541,11 +1105,22 @@ func (v *Validation) Set(field string, val interface{}) error {
 	return err
 }
 
+// get the form data from the data source. use for the uploaded file validate
+func (v *Validation) formData() (*FormData, bool) {
+	switch td := v.data.(type) {
+	case *FormData:
+		return td, true
+	case *MergedData:
+		return td.FormData()
+	}
+	return nil, false
+}
+
 // only update set value by key for struct
 func (v *Validation) updateValue(field string, val interface{}) (interface{}, error) {
 	// data source is struct
 	// if _, ok := v.data.(*StructData); ok {
-	if v.data.Type() == uint8(sourceStruct) {
+	if typ := v.data.Type(); typ == uint8(sourceStruct) || typ == uint8(sourceSlice) {
 		return v.data.Set(field, val)
 	}
 
@@ -562,34 +1137,202 @@ func (v *Validation) SetDefValue(field string, val interface{}) {
 	v.defValues[field] = val
 }
 
+// SetDefValueFunc set the func to compute the default value of given field.
+// the func is called on each validate when the field is not exists.
+// Usage:
+// 	v.SetDefValueFunc("createdAt", func(d validate.DataFace) interface{} {
+// 		return time.Now()
+// 	})
+func (v *Validation) SetDefValueFunc(field string, fn func(d DataFace) interface{}) {
+	v.SetDefValue(field, fn)
+}
+
 // GetDefValue get default value of the field
 func (v *Validation) GetDefValue(field string) (interface{}, bool) {
 	defVal, ok := v.defValues[field]
+	if fn, isFn := defVal.(func(d DataFace) interface{}); isFn {
+		return fn(v.data), true
+	}
 	return defVal, ok
 }
 
+// ApplyDefaults fill the default values of the not exists fields to the data and SafeData, without run the validators.
+// useful for pre-populating the form or loading the config that will be validated later.
+// the filled values will be validated on call Validate() later.
+// Usage:
+// 	v := validate.Struct(&cfg)
+// 	if err := v.ApplyDefaults(); err != nil {...}
+func (v *Validation) ApplyDefaults() error {
+	fields := make([]string, 0, len(v.defValues))
+	for field := range v.defValues {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if _, exist := v.Get(field); exist {
+			continue
+		}
+
+		defVal, _ := v.GetDefValue(field)
+		newVal, err := v.fillDefValue(field, defVal)
+		if err != nil {
+			return err
+		}
+		v.safeData[field] = newVal
+	}
+	return nil
+}
+
+// get the underlying value to pass to the validators. see the DerefPointer and UnwrapValuer
+func (v *Validation) checkValue(val interface{}) interface{} {
+	rv, isNil := indirect(reflect.ValueOf(val))
+	if val == nil || isNil {
+		return val
+	}
+
+	// the json.Number integer is validated as the int64(or uint64), the decimal is kept.
+	if n, ok := val.(json.Number); ok {
+		return jsonNumberValue(n)
+	}
+
+	// keep the value on the Value() return error
+	if vr, ok := val.(driver.Valuer); ok && v.UnwrapValuer {
+		if dv, err := vr.Value(); err == nil {
+			return dv
+		}
+	}
+
+	if v.DerefPointer {
+		return rv.Interface()
+	}
+	return val
+}
+
+// check the field value is a nil pointer
+func (v *Validation) isNilPointer(field string) bool {
+	var rv reflect.Value
+	if d, ok := v.data.(*StructData); ok {
+		rv, _ = d.fieldValue(field)
+	} else if v.data != nil {
+		val, _ := v.data.Get(field)
+		rv = reflect.ValueOf(val)
+	}
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// fill the default value to the source data, and mark the field is filled by the default value.
+func (v *Validation) fillDefValue(field string, defVal interface{}) (interface{}, error) {
+	newVal, err := v.updateValue(field, defVal)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.defaulted == nil {
+		v.defaulted = make(map[string]bool)
+	}
+	v.defaulted[field] = true
+	v.trace(field, TraceDefault, "", newVal, true)
+	return newVal, nil
+}
+
 // Trans get message Translator
 func (v *Validation) Trans() *Translator {
 	return v.trans
 }
 
-// SceneFields field names get
+// SceneFields field names get. contains the fields of the parent scenes.
 func (v *Validation) SceneFields() []string {
-	return v.scenes[v.scene]
+	fields, _ := v.resolveScene(v.scene, nil)
+	return fields
+}
+
+// resolve the fields of the scene and the parent scenes. the visited use for avoid the circular extends.
+func (v *Validation) resolveScene(scene string, visited map[string]bool) (fields []string, ok bool) {
+	if visited[scene] {
+		return
+	}
+
+	fields, ok = v.scenes[scene]
+	parents := v.sceneParents[scene]
+	if len(parents) == 0 {
+		return
+	}
+
+	if visited == nil {
+		visited = make(map[string]bool)
+	}
+	visited[scene] = true
+
+	// the fields of the parents first
+	var all []string
+	for _, parent := range parents {
+		if pFields, has := v.resolveScene(parent, visited); has {
+			all = append(all, pFields...)
+			ok = true
+		}
+	}
+	return append(all, fields...), ok
 }
 
-// scene field name map build
+// scene field name map build.
+// the field "*" means all fields of the rules, and the "!field" will exclude the field.
 func (v *Validation) sceneFieldMap() (m map[string]uint8) {
 	if v.scene == "" {
 		return
 	}
 
-	if fields, ok := v.scenes[v.scene]; ok {
-		m = make(map[string]uint8, len(fields))
-		for _, field := range fields {
+	fields, ok := v.resolveScene(v.scene, nil)
+	predicate := v.scenePredicates[v.scene]
+	if !ok && predicate == nil {
+		return
+	}
+
+	var all bool
+	var excludes []string
+	m = make(map[string]uint8, len(fields))
+	for _, field := range fields {
+		if field == "*" {
+			all = true
+		} else if strings.HasPrefix(field, "!") {
+			excludes = append(excludes, field[1:])
+		} else {
 			m[field] = 1
 		}
 	}
+
+	// "*" is all fields of the rules. only has exclusions is same as "*".
+	if all || len(m) == 0 && len(excludes) > 0 {
+		for _, r := range v.rules {
+			for _, field := range r.fields {
+				m[field] = 1
+			}
+		}
+	}
+
+	// the wildcard field is computed on the top field. eg: "tags" for "tags.*"
+	if predicate != nil && v.data != nil {
+		for _, r := range v.rules {
+			for _, field := range r.fields {
+				if keys := strings.Split(field, "."); wildcardIndex(keys) > 0 {
+					field = keys[0]
+				}
+
+				if predicate(field, v.data) {
+					m[field] = 1
+				}
+			}
+		}
+	}
+
+	for _, field := range excludes {
+		delete(m, field)
+	}
+
+	// all fields are excluded, use an placeholder to skip all fields.
+	if len(m) == 0 && (all || len(excludes) > 0 || predicate != nil) {
+		m[""] = 0
+	}
 	return
 }
 
@@ -631,11 +1374,110 @@ func (v *Validation) shouldStop() bool {
 	return v.hasError && v.StopOnError
 }
 
+// should stop apply the rules.
+// on collect all field errors, will go on to validate the failed fields.
+func (v *Validation) shouldStopRule() bool {
+	return v.shouldStop() && !v.collectAll
+}
+
+// the field is skipped after stop on error. only the failed fields will be checked on collect all field errors.
+func (v *Validation) isStoppedField(field string) bool {
+	if !v.collectAll || !v.shouldStop() {
+		return false
+	}
+
+	_, failed := v.Errors[field]
+	return !failed
+}
+
+// expand the wildcard field to the real field paths.
+// eg: "items.*.sku" => "items.0.sku", "items.1.sku". the top level is only for the slice data, eg: "*.name"
+func (v *Validation) expandField(pattern string) (fields []string) {
+	keys := strings.Split(pattern, ".")
+	pos := wildcardIndex(keys)
+	if pos < 0 { // not found
+		return
+	}
+
+	if pos == 0 {
+		return v.expandSliceField(strings.Join(keys[1:], "."))
+	}
+
+	prefix := strings.Join(keys[:pos], ".")
+	val, ok := v.Get(prefix)
+	if !ok {
+		return
+	}
+
+	var subKeys []string
+	rv, isNil := indirect(reflect.ValueOf(val))
+	if isNil {
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			subKeys = append(subKeys, strconv.Itoa(i))
+		}
+	case reflect.Map:
+		for _, mk := range rv.MapKeys() {
+			subKeys = append(subKeys, fmt.Sprint(mk.Interface()))
+		}
+		sort.Strings(subKeys)
+	}
+
+	rest := strings.Join(keys[pos+1:], ".")
+	for _, subKey := range subKeys {
+		field := prefix + "." + subKey
+		if rest != "" {
+			field += "." + rest
+		}
+
+		if isWildcardField(field) { // has more wildcard
+			fields = append(fields, v.expandField(field)...)
+		} else {
+			fields = append(fields, field)
+		}
+	}
+	return
+}
+
+// expand the top level wildcard of the slice data. eg: "*.name" => "0.name", "1.name"
+func (v *Validation) expandSliceField(rest string) (fields []string) {
+	sd, ok := v.data.(*SliceData)
+	if !ok {
+		return
+	}
+
+	for i := 0; i < sd.Len(); i++ {
+		field := strconv.Itoa(i)
+		if rest != "" {
+			field += "." + rest
+		}
+
+		if isWildcardField(field) { // has more wildcard
+			fields = append(fields, v.expandField(field)...)
+		} else {
+			fields = append(fields, field)
+		}
+	}
+	return
+}
+
 func (v *Validation) isNotNeedToCheck(field string) bool {
 	if len(v.sceneFields) == 0 {
 		return false
 	}
 
-	_, ok := v.sceneFields[field]
-	return !ok
+	if _, ok := v.sceneFields[field]; ok {
+		return false
+	}
+
+	// the wildcard field is checked on the top field in the scene. eg: "tags" for "tags.*"
+	if keys := strings.Split(field, "."); wildcardIndex(keys) > 0 {
+		_, ok := v.sceneFields[keys[0]]
+		return !ok
+	}
+	return true
 }
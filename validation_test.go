@@ -3,6 +3,7 @@ package validate
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -104,6 +105,112 @@ func TestValidation_StringRule(t *testing.T) {
 	is.Equal("", v.Errors.One())
 }
 
+func TestValidation_StringRule_each(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"tags": []string{"go", "a", "php"}})
+	v.StringRule("tags", "required|each:string|each:minLen:2")
+	is.False(v.Validate())
+	is.Equal("tags.1 min length is 2", v.Errors.FieldOne("tags.1"))
+
+	v = Map(M{"tags": []interface{}{"go", 12}, "ids": []int{1, 2, 30}})
+	v.StopOnError = false
+	v.StringRule("tags", "each:string")
+	v.StringRule("ids", "each:in:1,2,3")
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Contains(v.Errors, "tags.1")
+	is.Contains(v.Errors, "ids.2")
+
+	v = Map(M{"tags": []string{"go", "php"}})
+	v.StringRule("tags", "required|each:minLen:2")
+	is.True(v.Validate())
+
+	// on scene
+	v = Map(M{"tags": []string{"go", "a"}, "name": "inhere"})
+	v.StringRule("tags", "each:minLen:2")
+	v.StringRule("name", "required")
+	v.WithScenarios(SValues{"create": {"name", "tags"}, "update": {"name"}})
+	is.True(v.Validate("update"))
+	v.ResetResult()
+	is.False(v.Validate("create"))
+	is.Contains(v.Errors, "tags.1")
+}
+
+func TestValidation_StringRule_keysValues(t *testing.T) {
+	is := assert.New(t)
+
+	attrs := M{"color": "red", "size-cm": "12", "bad key": "x"}
+	v := Map(M{"attrs": attrs})
+	v.StringRule("attrs", "keys:alphaDash|values:maxLen:5")
+	is.False(v.Validate())
+	is.Equal(`attrs key "bad key" field did not pass validation`, v.Errors.FieldOne("attrs.bad key"))
+
+	v = Map(M{"attrs": M{"color": "red", "size": "too long value"}})
+	v.StringRule("attrs", "keys:alphaDash|values:maxLen:5")
+	is.False(v.Validate())
+	is.Equal("attrs.size max length is 5", v.Errors.FieldOne("attrs.size"))
+
+	v = Map(M{"attrs": map[string]int{"a": 1, "abcdef": 2, "xyz": 3}})
+	v.StopOnError = false
+	v.StringRule("attrs", "keys:maxLen:3|keys:regexp:^[a-z]+$|values:max:2")
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal(`attrs key "abcdef" max length is 3`, v.Errors.FieldOne("attrs.abcdef"))
+	is.Contains(v.Errors, "attrs.xyz")
+
+	v = Map(M{"attrs": M{"color": "red"}})
+	v.StringRule("attrs", "required|keys:alphaDash|values:maxLen:5")
+	is.True(v.Validate())
+
+	// not a map
+	v = Map(M{"attrs": "abc"})
+	v.StringRule("attrs", "keys:alphaDash")
+	is.True(v.Validate())
+}
+
+func TestValidation_StringRule_separators(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{
+		"code":   "a,12",
+		"status": "c|d",
+		"tags":   "x,y",
+	})
+	v.RuleSep, v.ValidatorSep, v.ArgSep = ";", "=", "#"
+	v.StringRule("code", "required;regexp=^(a|b),\\d+$;minLen=2")
+	v.StringRule("status", "in=a,b#c|d")
+	v.StringRule("tags", "required", "trim;str2arr=,")
+	is.True(v.Validate())
+	is.Equal([]string{"x", "y"}, v.SafeVal("tags"))
+
+	v = Map(M{"status": "a,b"})
+	v.ArgSep = "#"
+	v.StringRule("status", "in:a,b#c")
+	is.True(v.Validate())
+	v = Map(M{"status": "a"})
+	v.ArgSep = "#"
+	v.StringRule("status", "in:a,b#c")
+	is.False(v.Validate())
+
+	// regex contains the validator separator
+	v = Map(M{"time": "12:30"})
+	v.StringRule("time", "regexp:^\\d{2}:\\d{2}$")
+	is.True(v.Validate())
+
+	// global option
+	Config(func(opt *GlobalOption) {
+		opt.RuleSep = ";"
+	})
+	v = Map(M{"name": "inhere"})
+	v.StringRule("name", "required;minLen:3")
+	is.Equal(";", v.RuleSep)
+	is.True(v.Validate())
+	Config(func(opt *GlobalOption) {
+		opt.RuleSep = "|"
+	})
+}
+
 func TestErrorMessages(t *testing.T) {
 	is := assert.New(t)
 
@@ -451,6 +558,44 @@ func TestFromRequest_FileForm(t *testing.T) {
 	})
 }
 
+func TestNew_multipartForm(t *testing.T) {
+	is := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
+	w, err := mw.CreateFormFile("avatar", "a.jpg")
+	if is.NoError(err) {
+		_, _ = w.Write([]byte("\xFF\xD8\xFF"))
+	}
+	_ = mw.WriteField("name", "inhere")
+	_ = mw.Close()
+
+	// the form parsed by the framework. eg: gin's c.MultipartForm()
+	form, err := multipart.NewReader(buf, mw.Boundary()).ReadForm(defaultMaxMemory)
+	is.NoError(err)
+
+	v := New(form)
+	v.StringRules(MS{
+		"name":   "required|minLen:3",
+		"avatar": "required|image:jpg,jpeg",
+	})
+	is.True(v.Validate(), v.Errors.One())
+	is.Equal("inhere", v.SafeVal("name"))
+
+	// only the files
+	v = New(form.File)
+	v.StringRule("avatar", "required|mimeTypes:image/jpeg")
+	is.True(v.Validate(), v.Errors.One())
+
+	v = New(map[string][]*multipart.FileHeader{})
+	v.StringRule("avatar", "required")
+	is.False(v.Validate())
+
+	d := FromMultipartForm(nil)
+	is.Empty(d.Form)
+	is.Empty(d.Files)
+}
+
 func TestFromRequest_JSON(t *testing.T) {
 	is := assert.New(t)
 
@@ -491,6 +636,194 @@ func TestFromRequest_JSON(t *testing.T) {
 	is.Error(err)
 }
 
+func TestRegisterBodyDecoder(t *testing.T) {
+	is := assert.New(t)
+	bs := []byte("name=inhere;age=100")
+	decode := func(data []byte, v interface{}) error {
+		mp := *(v.(*map[string]interface{}))
+		for _, pair := range strings.Split(string(data), ";") {
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				return fmt.Errorf("invalid pair '%s'", pair)
+			}
+			mp[kv[0]] = kv[1]
+		}
+		return nil
+	}
+
+	// not registered
+	r, _ := http.NewRequest("POST", "/users", bytes.NewReader(bs))
+	r.Header.Set("Content-Type", "application/x-kv")
+	_, err := FromRequest(r)
+	is.Equal(ErrEmptyData, err)
+
+	RegisterBodyDecoder("x-kv", decode)
+	defer func() {
+		bodyDecoders = nil
+	}()
+
+	r, _ = http.NewRequest("POST", "/users", bytes.NewReader(bs))
+	r.Header.Set("Content-Type", "application/x-kv")
+	d, err := FromRequest(r)
+	is.Nil(err)
+	_, ok := d.(*MapData)
+	is.True(ok)
+
+	v := d.Create()
+	v.StringRules(MS{
+		"name": "required|minLen:4",
+		"age":  "required|intString",
+	})
+	is.True(v.Validate(), v.Errors.String())
+	is.Equal("inhere", v.SafeVal("name"))
+
+	// body can be read again
+	again, err := ioutil.ReadAll(r.Body)
+	is.Nil(err)
+	is.Equal(bs, again)
+
+	// invalid body
+	r, _ = http.NewRequest("POST", "/users", strings.NewReader("invalid"))
+	r.Header.Set("Content-Type", "application/x-kv; charset=utf-8")
+	_, err = FromRequest(r)
+	is.Error(err)
+
+	_, err = FromBytesWith([]byte("invalid"), decode)
+	is.Error(err)
+}
+
+func TestFromRequestWith(t *testing.T) {
+	is := assert.New(t)
+	apiKey := strings.Repeat("a", 32)
+
+	r, _ := http.NewRequest("POST", "/users", strings.NewReader(`{"name": "inhere"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Api-Key", apiKey)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc123"})
+
+	d, err := FromRequestWith(r, RequestOption{
+		Headers: []string{"X-Api-Key", "X-Request-Id"},
+		Cookies: []string{"session", "not-exist"},
+	})
+	is.Nil(err)
+	v := d.Create()
+	v.StringRules(MS{
+		"name":             "required",
+		"header:X-Api-Key": "required|len:32",
+		"cookie:session":   "required|alphaNum",
+	})
+	is.True(v.Validate(), v.Errors.String())
+	is.Equal(apiKey, v.SafeVal("header:X-Api-Key"))
+	_, ok := v.Raw("header:X-Request-Id")
+	is.False(ok)
+
+	// GET request, missing header
+	r, _ = http.NewRequest("GET", "/users?id=1", nil)
+	d, err = FromRequestWith(r, RequestOption{Headers: []string{"x-api-key"}})
+	is.Nil(err)
+	v = d.Create()
+	v.StringRule("header:x-api-key", "required|len:32")
+	is.False(v.Validate())
+
+	// no body, only headers
+	r, _ = http.NewRequest("POST", "/users", nil)
+	r.Header.Set("X-Api-Key", apiKey)
+	d, err = FromRequestWith(r, RequestOption{Headers: []string{"X-Api-Key"}})
+	is.Nil(err)
+	val, ok := d.Get("header:X-Api-Key")
+	is.True(ok)
+	is.Equal(apiKey, val)
+
+	_, err = FromRequestWith(r, RequestOption{})
+	is.Equal(ErrEmptyData, err)
+
+	// the headers and cookies can not be faked by the body or query data
+	r, _ = http.NewRequest("POST", "/users?header:X-Api-Key="+apiKey, strings.NewReader(`{"header:X-Api-Key": "`+apiKey+`"}`))
+	r.Header.Set("Content-Type", "application/json")
+	d, err = FromRequestWith(r, RequestOption{Headers: []string{"X-Api-Key"}})
+	is.Nil(err)
+	v = d.Create()
+	v.StringRule("header:X-Api-Key", "required|len:32")
+	is.False(v.Validate())
+
+	r, _ = http.NewRequest("POST", "/users?cookie:session=abc123", strings.NewReader("cookie:session=abc123&name=inhere"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	d, err = FromRequestWith(r, RequestOption{Cookies: []string{"session"}})
+	is.Nil(err)
+	_, ok = d.Get("cookie:session")
+	is.False(ok)
+	val, ok = d.Get("name")
+	is.True(ok)
+	is.Equal("inhere", val)
+}
+
+func TestFromRequestWith_pathParams(t *testing.T) {
+	is := assert.New(t)
+
+	r, _ := http.NewRequest("PUT", "/users/12?id=34", strings.NewReader(`{"id": 56, "name": "inhere"}`))
+	r.Header.Set("Content-Type", "application/json")
+	d, err := FromRequestWith(r, RequestOption{
+		PathParams: map[string]string{"id": "12"},
+	})
+	is.Nil(err)
+	v := d.Create()
+	v.StringRules(MS{"id": "required|numeric", "name": "required"})
+	is.True(v.Validate())
+	is.Equal("12", v.SafeVal("id"))
+	is.Equal("inhere", v.SafeVal("name"))
+
+	// the query is layered for the JSON body
+	r, _ = http.NewRequest("PUT", "/users?id=34&page=2", strings.NewReader(`{"id": 56, "name": "inhere"}`))
+	r.Header.Set("Content-Type", "application/json")
+	d, err = FromRequestWith(r, RequestOption{})
+	is.Nil(err)
+	val, _ := d.Get("id")
+	is.Equal(float64(56), val)
+	val, _ = d.Get("page")
+	is.Equal("2", val)
+
+	// the query first
+	r, _ = http.NewRequest("PUT", "/users?id=34&page=2", strings.NewReader(`{"id": 56, "name": "inhere"}`))
+	r.Header.Set("Content-Type", "application/json")
+	d, err = FromRequestWith(r, RequestOption{QueryFirst: true})
+	is.Nil(err)
+	val, _ = d.Get("id")
+	is.Equal("34", val)
+	val, _ = d.Get("name")
+	is.Equal("inhere", val)
+
+	// the query is layered for the form body
+	r, _ = http.NewRequest("POST", "/users?id=34&page=2", strings.NewReader("id=56"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	d, err = FromRequestWith(r, RequestOption{})
+	is.Nil(err)
+	val, _ = d.Get("id")
+	is.Equal("56", val)
+	val, _ = d.Get("page")
+	is.Equal("2", val)
+
+	// no body
+	r, _ = http.NewRequest("DELETE", "/users/12", nil)
+	d, err = FromRequestWith(r, RequestOption{
+		PathParams: map[string]string{"id": "12"},
+	})
+	is.Nil(err)
+	val, ok := d.Get("id")
+	is.True(ok)
+	is.Equal("12", val)
+}
+
+func TestFromHeaders(t *testing.T) {
+	is := assert.New(t)
+
+	h := http.Header{}
+	h.Set("x-request-id", "req-1")
+	v := FromHeaders(h).Create()
+	v.StringRule("X-Request-Id", "required|minLen:3")
+	is.True(v.Validate())
+	is.Equal("req-1", v.SafeVal("X-Request-Id"))
+}
+
 func TestFieldCompare(t *testing.T) {
 	is := assert.New(t)
 	v := Map(mpSample)
@@ -511,6 +844,73 @@ func TestFieldCompare(t *testing.T) {
 	is.Contains(emp, "newSt")
 }
 
+func TestValidation_DifferentFrom(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"oldPwd": "123456", "newPwd": "123456"})
+	v.StringRule("newPwd", "required|differentFrom:oldPwd")
+	is.False(v.Validate())
+	is.Equal("newPwd value must be different from the field oldPwd", v.Errors.One())
+
+	v = Map(M{"oldPwd": 123456, "newPwd": "123456"})
+	v.StringRule("newPwd", "required|different_from:oldPwd")
+	is.False(v.Validate())
+
+	v = Map(M{"oldPwd": "123456", "newPwd": "abcdef"})
+	v.StringRule("newPwd", "required|differentFrom:oldPwd")
+	is.True(v.Validate())
+
+	// the dst field not exists
+	v = Map(M{"newPwd": "abcdef"})
+	v.StringRule("newPwd", "required|differentFrom:oldPwd")
+	is.True(v.Validate())
+}
+
+func TestValidation_AllDifferent(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"c1": "a", "c2": "b", "c3": "c"})
+	v.StringRule("c1", "allDifferent:c1,c2,c3")
+	is.True(v.Validate())
+
+	v = Map(M{"c1": "a", "c2": "b", "c3": "b"})
+	v.StringRule("c1", "allDifferent:c2,c3")
+	is.False(v.Validate())
+	is.Equal("c1 value must be different from the fields [c2 c3]", v.Errors.One())
+
+	v = Map(M{"c1": "1", "c2": 1})
+	v.StringRule("c1", "all_different:c2,c3")
+	is.False(v.Validate())
+
+	// ignore the empty values
+	v = Map(M{"c1": "a", "c2": "", "c3": ""})
+	v.StringRule("c1", "allDifferent:c2,c3")
+	is.True(v.Validate())
+}
+
+func TestValidation_SumTo(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"a": 33.33, "b": "33.33", "c": 33.34})
+	v.StringRule("a", "sumTo:100,b,c")
+	is.True(v.Validate())
+
+	// the zero value will not skip
+	v = Map(M{"a": 0, "b": 40, "c": 50})
+	v.StringRule("a", "sumTo:100,b,c")
+	is.False(v.Validate())
+	is.Equal("a and the fields [b c] must add up to 100", v.Errors.One())
+
+	// the not exists field as 0, duplicate field is counted once
+	v = Map(M{"a": 60, "b": 40})
+	v.StringRule("a", "sum_to:100,a,b,c")
+	is.True(v.Validate())
+
+	v = Map(M{"a": 60, "b": "abc"})
+	v.StringRule("a", "sumTo:100,b")
+	is.False(v.Validate())
+}
+
 func TestValidationScene(t *testing.T) {
 	is := assert.New(t)
 	mp := M{
@@ -550,6 +950,243 @@ func TestValidationScene(t *testing.T) {
 	is.Equal("name min length is 7", v.Errors.One())
 }
 
+func TestValidation_SceneExtends(t *testing.T) {
+	is := assert.New(t)
+	mp := M{"name": "in", "age": 100, "id": "", "password": ""}
+
+	v := Map(mp)
+	v.StopOnError = false
+	v.StringRules(MS{
+		"name":     "minLen:7",
+		"age":      "min:101",
+		"id":       "required",
+		"password": "required",
+	})
+	v.WithScenes(SValues{"base": {"name", "age"}})
+	v.SceneExtends("create", "base", "password")
+	v.SceneExtends("update", "base", "id")
+
+	is.False(v.Validate("update"))
+	is.Equal([]string{"name", "age", "id"}, v.SceneFields())
+	is.Len(v.Errors, 3)
+	is.Contains(v.Errors, "id")
+	is.NotContains(v.Errors, "password")
+
+	v.ResetResult()
+	is.False(v.Validate("create"))
+	is.Len(v.Errors, 3)
+	is.Contains(v.Errors, "password")
+
+	// multi level and circular
+	v.ResetResult()
+	v.SceneExtends("admin", "update", "role")
+	v.SceneExtends("base", "admin")
+	v.AtScene("admin")
+	is.Equal([]string{"name", "age", "id", "role"}, v.SceneFields())
+}
+
+func TestValidation_SceneFieldsFunc(t *testing.T) {
+	is := assert.New(t)
+	sent := func(field string, d DataFace) bool {
+		_, ok := d.Get(field)
+		return ok
+	}
+
+	v := Map(M{"name": "in", "tags": []string{"a", ""}})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"name":   "minLen:7",
+		"age":    "required",
+		"tags.*": "required",
+	})
+	v.SceneFieldsFunc("update", sent)
+
+	// only the sent fields are validated
+	is.False(v.Validate("update"))
+	is.Len(v.Errors, 2)
+	is.Contains(v.Errors, "name")
+	is.Contains(v.Errors, "tags.1")
+	is.NotContains(v.Errors, "age")
+
+	// without scene, all the fields are validated
+	v.ResetResult()
+	is.False(v.AtScene("").Validate())
+	is.Contains(v.Errors, "age")
+
+	// with the scene config and exclusions
+	v = Map(M{"name": "in", "tags": []string{"a"}})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"name":   "minLen:7",
+		"age":    "required",
+		"tags.*": "required",
+	})
+	v.WithScenes(SValues{"update": {"age", "!name"}})
+	v.SceneFieldsFunc("update", sent)
+	is.False(v.Validate("update"))
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "age")
+
+	// no field is sent
+	v = Map(M{})
+	v.StringRules(MS{"name": "required", "age": "required"})
+	v.SceneFieldsFunc("update", sent)
+	is.True(v.Validate("update"))
+}
+
+func TestValidationScene_wildcard(t *testing.T) {
+	is := assert.New(t)
+	mp := M{"name": "in", "age": 100, "password": ""}
+
+	newV := func() *Validation {
+		v := Map(mp)
+		v.StopOnError = false
+		v.StringRules(MS{
+			"name":     "minLen:7",
+			"age":      "min:101",
+			"password": "required",
+		})
+		return v.WithScenes(SValues{
+			"all":      {"*"},
+			"update":   {"*", "!password"},
+			"exclude":  {"!password", "!age"},
+			"none":     {"*", "!name", "!age", "!password"},
+			"extended": {"name", "password"},
+		})
+	}
+
+	v := newV()
+	is.False(v.Validate("all"))
+	is.Len(v.Errors, 3)
+
+	v = newV()
+	is.False(v.Validate("update"))
+	is.Len(v.Errors, 2)
+	is.NotContains(v.Errors, "password")
+
+	v = newV()
+	is.False(v.Validate("exclude"))
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "name")
+
+	v = newV()
+	is.True(v.Validate("none"))
+
+	// exclude on extends
+	v = newV()
+	v.SceneExtends("noPass", "extended", "!password")
+	is.False(v.Validate("noPass"))
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "name")
+}
+
+func TestValidation_SceneFunc(t *testing.T) {
+	is := assert.New(t)
+
+	newV := func(data M) *Validation {
+		v := Map(data)
+		v.StringRules(MS{
+			"name":    "required",
+			"company": "required",
+			"idCard":  "required",
+		})
+		v.WithScenes(SValues{
+			"company": {"name", "company"},
+			"person":  {"name", "idCard"},
+		})
+		return v.SceneFunc(func(d DataFace) string {
+			if typ, _ := d.Get("type"); typ == "company" {
+				return "company"
+			}
+			return "person"
+		})
+	}
+
+	v := newV(M{"type": "company", "name": "inhere", "company": "gookit"})
+	is.True(v.Validate())
+	is.Equal("company", v.Scene())
+
+	v = newV(M{"type": "person", "name": "inhere", "company": "gookit"})
+	is.False(v.Validate())
+	is.Equal("person", v.Scene())
+	is.Contains(v.Errors, "idCard")
+
+	// the given scene take precedence
+	v = newV(M{"type": "person", "name": "inhere", "company": "gookit"})
+	is.True(v.Validate("company"))
+	is.Equal("company", v.Scene())
+
+	// empty result keep the current scene
+	v = newV(M{"name": "inhere"})
+	v.SceneFunc(func(d DataFace) string { return "" }).AtScene("company")
+	is.False(v.Validate())
+	is.Contains(v.Errors, "company")
+}
+
+type periodForm struct {
+	Start int `validate:"required"`
+	End   int `validate:"required"`
+}
+
+func (f *periodForm) Validate(v *Validation) {
+	if f.Start >= f.End {
+		v.AddError("End", "gtStart", "End must be greater than Start")
+	}
+}
+
+type orderForm struct {
+	Total int   `validate:"required"`
+	Parts []int `validate:"required"`
+}
+
+func (f orderForm) ValidateStruct() error {
+	sum := 0
+	for _, n := range f.Parts {
+		sum += n
+	}
+
+	if sum == f.Total {
+		return nil
+	}
+
+	if f.Total < 0 {
+		return fmt.Errorf("invalid total %d", f.Total)
+	}
+
+	es := Errors{}
+	es.Add("Total", "sumOfParts", "Total must be the sum of Parts")
+	return es
+}
+
+func TestStruct_levelValidate(t *testing.T) {
+	is := assert.New(t)
+
+	v := Struct(&periodForm{Start: 1, End: 3})
+	is.True(v.Validate())
+
+	v = Struct(&periodForm{Start: 3, End: 1})
+	is.False(v.Validate())
+	is.Equal("End must be greater than Start", v.Errors.FieldOne("End"))
+
+	// not called on the field rules failed
+	v = Struct(&periodForm{Start: 3})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Contains(v.Errors, "End")
+	is.NotContains(v.Errors.Field("End"), "gtStart")
+
+	v = Struct(orderForm{Total: 6, Parts: []int{1, 2, 3}})
+	is.True(v.Validate())
+
+	v = Struct(&orderForm{Total: 5, Parts: []int{1, 2, 3}})
+	is.False(v.Validate())
+	is.Equal("Total must be the sum of Parts", v.Errors.FieldOne("Total"))
+
+	v = Struct(&orderForm{Total: -1, Parts: []int{1, 2, 3}})
+	is.False(v.Validate())
+	is.Equal("invalid total -1", v.Errors.FieldOne("_validate"))
+}
+
 func TestAddValidator(t *testing.T) {
 	is := assert.New(t)
 
@@ -740,3 +1377,540 @@ func TestEnum(t *testing.T) {
 	v.Validate()
 	assert.True(t, v.Validate())
 }
+
+func TestEnum_typed(t *testing.T) {
+	is := assert.New(t)
+
+	v := New(M{
+		"status": 2,
+		"price":  1.5,
+		"agreed": true,
+		"lang":   "EN",
+	})
+	v.StringRules(MS{
+		"status": "in:1,2,3",
+		"price":  "in:1.5,2.5",
+		"agreed": "in:true",
+		"lang":   "inIgnoreCase:en,zh",
+	})
+	is.True(v.Validate())
+
+	// multi args OR an slice
+	v = New(M{"status": "2", "lang": "fr"})
+	v.AddRule("status", "in", 1, 2, 3)
+	v.AddRule("status", "enum", []int{1, 2})
+	v.AddRule("lang", "notIn", "en", "zh")
+	is.True(v.Validate())
+
+	v = New(M{"lang": "EN"})
+	v.StringRule("lang", "notInIgnoreCase:en,zh")
+	is.False(v.Validate())
+
+	v = New(M{"lang": "EN"})
+	v.AddRule("lang", "inIgnoreCase", "fr", "zh")
+	is.False(v.Validate())
+	is.Equal("lang value must be in the enum [fr zh](ignore case)", v.Errors.One())
+}
+
+func TestValidation_Check(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"contact": "13688889999", "code": "abc"})
+	v.AddValidator("contact", func(val string) bool {
+		if ok, _ := v.Check("email", val); ok {
+			return true
+		}
+
+		ok, _ := v.Check("cnMobile", val)
+		return ok
+	})
+	v.StringRule("contact", "required|contact")
+	is.True(v.Validate())
+
+	ok, msg := v.Check("minLen", "ab", 3)
+	is.False(ok)
+	is.Equal("value min length is 3", msg)
+
+	ok, msg = v.Check("in", 2, 1, 2, 3)
+	is.True(ok)
+	is.Equal("", msg)
+
+	ok, msg = v.Check("required", "")
+	is.False(ok)
+	is.Equal("value is required and not empty", msg)
+
+	ok, _ = v.Check("isFile", "")
+	is.False(ok)
+
+	is.Panics(func() {
+		v.Check("not-exists", "val")
+	})
+}
+
+func TestValidation_AggregateErrors(t *testing.T) {
+	is := assert.New(t)
+
+	items := make([]interface{}, 0, 20)
+	for i := 0; i < 20; i++ {
+		sku := "abcd"
+		if i%2 == 0 {
+			sku = "ab"
+		}
+		items = append(items, M{"sku": sku, "qty": i})
+	}
+
+	// not aggregate
+	v := Map(M{"items": items})
+	v.StringRule("items.*.sku", "required|minLen:3")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("items.0.sku min length is 3", v.Errors.FieldOne("items.0.sku"))
+
+	v = Map(M{"items": items})
+	v.StopOnError = false
+	v.StringRule("items.*.sku", "required|minLen:3")
+	is.False(v.Validate())
+	is.Len(v.Errors, 10)
+
+	// aggregate
+	v = Map(M{"items": items})
+	v.AggregateErrors = true
+	v.StringRule("items.*.sku", "required|minLen:3")
+	v.StringRule("items.*.qty", "required|int|min:1")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal(
+		"10 elements failed minLen:3 (indexes 0,2,4,6,8,10,12,14,16,18)",
+		v.Errors.FieldOne("items.*.sku"),
+	)
+
+	v = Map(M{"items": items})
+	v.AggregateErrors = true
+	v.StopOnError = false
+	v.StringRule("items.*.qty", "int|max:5")
+	is.False(v.Validate())
+	is.Equal(
+		"14 elements failed max:5 (indexes 6,7,8,9,10,11,12,13,14,15,...)",
+		v.Errors.FieldOne("items.*.qty"),
+	)
+
+	// nested wildcard
+	v = Map(M{"groups": []M{
+		{"tags": []string{"go", "php"}},
+		{"tags": []string{"java", "c"}},
+	}})
+	v.AggregateErrors = true
+	v.StringRule("groups.*.tags.*", "minLen:2")
+	is.False(v.Validate())
+	is.Equal("1 elements failed minLen:2 (indexes 1.1)", v.Errors.FieldOne("groups.*.tags.*"))
+
+	// empty OR not exists
+	v = Map(M{"items": []M{}})
+	v.StringRule("items.*.sku", "required")
+	v.StringRule("users.*.name", "required")
+	is.True(v.Validate())
+}
+
+type sliceItemForm struct {
+	Name string `validate:"required|minLen:3" filter:"trim"`
+	Age  int    `validate:"required|min:1"`
+}
+
+func (f sliceItemForm) Translates() map[string]string {
+	return MS{"Name": "User Name"}
+}
+
+func (f sliceItemForm) Messages() map[string]string {
+	return MS{"Age.required": "{field} is required!"}
+}
+
+func TestStruct_slice(t *testing.T) {
+	is := assert.New(t)
+
+	items := []sliceItemForm{
+		{Name: " inhere ", Age: 20},
+		{Name: "tom", Age: 23},
+	}
+	v := Struct(items)
+	is.True(v.Validate())
+	// update source value
+	is.Equal("inhere", items[0].Name)
+	is.Equal("tom", v.SafeVal("1.Name"))
+
+	v = New(&[]*sliceItemForm{
+		{Name: "inhere", Age: 20},
+		{Name: "in"},
+	})
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("User Name min length is 3", v.Errors.FieldOne("1.Name"))
+	is.Equal("1.Age is required!", v.Errors.FieldOne("1.Age"))
+
+	// array
+	v = Struct(&[1]sliceItemForm{{Name: "inhere"}})
+	is.False(v.Validate())
+	is.Contains(v.Errors, "0.Age")
+
+	// invalid
+	v = Struct([]*sliceItemForm{nil})
+	is.False(v.Validate())
+	is.Contains(v.Errors.String(), "invalid input data")
+
+	d, err := FromStructSlice([]string{"a"})
+	is.Equal(ErrInvalidData, err)
+	is.Equal(0, d.Len())
+	d, err = FromStructSlice(items)
+	is.NoError(err)
+	is.Equal(2, d.Len())
+
+	val, ok := d.Get("1")
+	is.True(ok)
+	is.IsType(&sliceItemForm{}, val)
+	_, ok = d.Get("3.Name")
+	is.False(ok)
+	_, err = d.Set("0", "val")
+	is.Equal(ErrNoField, err)
+}
+
+func TestSlice(t *testing.T) {
+	is := assert.New(t)
+
+	items := []map[string]interface{}{
+		{"name": "inhere", "age": 20},
+		{"name": "in", "age": 0},
+	}
+	v := Slice(items, MS{"name": "required|minLen:3", "age": "required|int|min:1"})
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("1.name min length is 3", v.Errors.FieldOne("1.name"))
+	is.Contains(v.Errors, "1.age")
+
+	// from JSON array
+	var list []interface{}
+	is.NoError(Unmarshal([]byte(`[{"name":"inhere"},{"name":"tom"}]`), &list))
+	v = Slice(list, MS{"name": "required|minLen:3"})
+	is.True(v.Validate())
+	is.Equal("tom", v.SafeVal("1.name"))
+
+	// New with the slice of map
+	v = New([]M{{"name": "inhere"}, {"age": 23}})
+	v.StringRule("0.name", "required")
+	v.StringRule("1.name", "required")
+	is.False(v.Validate())
+	is.Equal("1.name is required and not empty", v.Errors.One())
+
+	// the struct slice
+	v = Slice([]sliceItemForm{{Name: "inhere", Age: 20}, {Name: "tom", Age: 12}}, MS{"Age": "max:18"})
+	is.False(v.Validate())
+	is.Equal("0.Age max value is 18", v.Errors.One())
+
+	// invalid
+	v = Slice([]string{"a"}, MS{"name": "required"})
+	is.False(v.Validate())
+	is.Contains(v.Errors.String(), "invalid input data")
+	is.False(isMapSlice([]interface{}{M{}, "a"}))
+	is.True(isMapSlice([]M{}))
+	is.False(isMapSlice([]interface{}{}))
+
+	d, err := FromSlice(items)
+	is.NoError(err)
+	is.Equal(2, d.Len())
+	val, ok := d.Get("0")
+	is.True(ok)
+	is.Equal(items[0], val)
+}
+
+func TestValidation_WithOriginal(t *testing.T) {
+	is := assert.New(t)
+
+	type user struct {
+		Name  string
+		Email string
+		Age   int
+	}
+	orig := &user{Name: "inhere", Email: "old@abc.com", Age: 20}
+
+	v := Map(M{"email": "new@abc.com", "age": "20"})
+	v.WithOriginal(M{"email": "old@abc.com", "age": 20})
+	is.True(v.IsChanged("email"))
+	is.False(v.IsChanged("age"))
+	is.False(v.IsChanged("name"))
+	val, ok := v.Original("email")
+	is.True(ok)
+	is.Equal("old@abc.com", val)
+
+	// email changed, but no password
+	v.StringRule("email", "required|email|changedRequires:password")
+	is.False(v.Validate())
+	is.Equal("email can only be changed when [password] is present", v.Errors.One())
+
+	v = Map(M{"Email": "new@abc.com", "password": "123456"})
+	v.WithOriginal(orig)
+	v.StringRule("Email", "changed_requires:password")
+	is.True(v.Validate())
+
+	// not changed
+	v = Map(M{"Email": "old@abc.com"})
+	v.WithOriginal(orig)
+	v.StringRule("Email", "changedRequires:password")
+	is.True(v.Validate())
+
+	// changed to empty
+	v = Map(M{"Email": ""})
+	v.WithOriginal(orig)
+	v.StringRule("Email", "changedRequires:password")
+	is.False(v.Validate())
+
+	// no original data
+	v = Map(M{"email": "new@abc.com"})
+	is.False(v.IsChanged("email"))
+	_, ok = v.Original("email")
+	is.False(ok)
+	v.StringRule("email", "changedRequires:password")
+	is.True(v.Validate())
+
+	// invalid original data
+	v = Map(M{"email": "new@abc.com"}).WithOriginal("invalid")
+	is.False(v.Validate())
+	is.Contains(v.Errors, validateError)
+}
+
+func TestValidation_Immutable(t *testing.T) {
+	is := assert.New(t)
+	orig := M{"username": "inhere", "age": 20}
+
+	v := Map(M{"username": "inhere", "age": "21"})
+	v.WithOriginal(orig)
+	v.StringRule("username", "immutable")
+	v.StringRule("age", "required|immutable")
+	is.False(v.Validate())
+	is.Equal("age value cannot be changed", v.Errors.One())
+
+	// not changed
+	v = Map(M{"username": "inhere", "age": 20})
+	v.WithOriginal(orig)
+	v.StringRules(MS{"username": "immutable", "age": "immutable"})
+	is.True(v.Validate())
+
+	// not submitted OR no original data
+	v = Map(M{"age": 21})
+	v.StringRules(MS{"username": "immutable", "age": "immutable"})
+	is.True(v.Validate())
+}
+
+func TestValidation_ReadOnlyInScene(t *testing.T) {
+	is := assert.New(t)
+	data := M{"name": "inhere", "createdAt": "2019-06-01"}
+
+	// reject
+	v := Map(data, "update")
+	v.StringRule("name", "required")
+	v.StringRule("createdAt", "readOnlyInScene:update")
+	is.False(v.Validate())
+	is.Equal("createdAt is read-only and cannot be submitted", v.Errors.One())
+
+	// other scene
+	v = Map(data, "create")
+	v.StringRule("createdAt", "read_only_in_scene:update,patch")
+	is.True(v.Validate())
+	is.Equal("2019-06-01", v.SafeVal("createdAt"))
+
+	// strip
+	v = Map(data, "update")
+	v.StripReadOnly = true
+	v.StringRule("name", "required")
+	v.StringRule("createdAt", "string|readOnlyInScene:update")
+	is.True(v.Validate())
+	is.Equal("inhere", v.SafeVal("name"))
+	_, ok := v.Safe("createdAt")
+	is.False(ok)
+
+	// not submitted
+	v = Map(M{"name": "inhere"}, "update")
+	v.StringRule("createdAt", "readOnlyInScene:update")
+	is.True(v.Validate())
+}
+
+func TestValidation_Confirmed(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "inhere", "password": "123456", "password_confirmation": "123456"})
+	v.StringRule("name", "required")
+	v.StringRule("password", "required|minLen:6|confirmed")
+	is.True(v.Validate())
+	is.Equal(M{"name": "inhere"}, v.SafeData())
+	val, _ := v.Get("password")
+	is.Equal("123456", val)
+
+	// not match
+	v = Map(M{"password": "123456", "password_confirmation": "1234567"})
+	v.StringRule("password", "required|confirmed")
+	is.False(v.Validate())
+	is.Equal("password value does not match the confirmation", v.Errors.One())
+
+	// missing confirmation
+	v = Map(M{"password": "123456"})
+	v.StringRule("password", "confirmed")
+	is.False(v.Validate())
+
+	// both not exist
+	v = Map(M{"name": "inhere"})
+	v.StringRule("password", "confirmed")
+	is.True(v.Validate())
+
+	// custom the confirmation field
+	v = Map(M{"Password": "123456", "PasswordConfirm": "123456", "PasswordRepeat": "123456"})
+	v.ConfirmSuffix = "Confirm"
+	v.StringRule("Password", "confirmed")
+	is.True(v.Validate())
+	v = Map(M{"Password": "123456", "PasswordRepeat": "123456"})
+	v.StringRule("Password", "confirmed:PasswordRepeat")
+	is.True(v.Validate())
+	is.Empty(v.SafeData())
+
+	// the struct field use the camel case name
+	type form struct {
+		Password             string `validate:"required|confirmed"`
+		PasswordConfirmation string
+	}
+	v = Struct(&form{Password: "123456", PasswordConfirmation: "123456"})
+	is.True(v.Validate())
+	v = Struct(&form{Password: "123456", PasswordConfirmation: "1234567"})
+	is.False(v.Validate())
+	is.Equal("Password value does not match the confirmation", v.Errors.One())
+	is.Equal("ConfirmCode", camelSuffix("_confirm-code"))
+}
+
+func TestValidation_CollectAllFieldErrors(t *testing.T) {
+	is := assert.New(t)
+	data := M{"name": "a", "email": "invalid", "age": "abc"}
+	addRules := func(v *Validation) {
+		v.StringRule("name", "minLen:3|maxLen:0|email")
+		v.StringRule("email", "email")
+		v.StringRule("age", "numeric")
+	}
+
+	// default, stop on the first error
+	v := Map(data)
+	addRules(v)
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Len(v.Errors.Field("name"), 1)
+
+	v = Map(data).CollectAllFieldErrors(true)
+	addRules(v)
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Len(v.Errors.Field("name"), 3)
+	is.Equal("name min length is 3", v.Errors.Field("name")["minLen"])
+
+	// with StopOnError=false, report all fields
+	v = Map(data).CollectAllFieldErrors(true)
+	v.StopOnError = false
+	addRules(v)
+	is.False(v.Validate())
+	is.Len(v.Errors, 3)
+	is.Len(v.Errors.Field("name"), 3)
+
+	// wildcard fields
+	v = Map(M{"tags": []string{"ab", "c", "def"}}).CollectAllFieldErrors(true)
+	v.StringRule("tags.*", "minLen:3|maxLen:1")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Len(v.Errors.Field("tags.0"), 2)
+}
+
+func TestValidation_SetPartial(t *testing.T) {
+	is := assert.New(t)
+
+	rules := MS{
+		"name":  "required|minLen:3",
+		"email": "required|email",
+		"age":   "required|int|min:1",
+	}
+
+	// full create
+	v := Map(M{"name": "inhere"})
+	v.StringRules(rules)
+	is.False(v.Validate())
+
+	// partial update
+	v = Map(M{"name": "inhere"}).SetPartial(true)
+	v.StringRules(rules)
+	is.True(v.Validate())
+	is.Equal(M{"name": "inhere"}, M(v.SafeData()))
+
+	// the present fields still be validated
+	v = Map(M{"name": "in", "email": nil}).SetPartial(true)
+	v.StopOnError = false
+	v.StringRules(rules)
+	v.StringRule("age", "default:10")
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("name min length is 3", v.Errors.FieldOne("name"))
+	is.Equal("email is required and not empty", v.Errors.FieldOne("email"))
+	is.NotContains(v.Errors, "age")
+
+	v = JSON(`{"email": "some@abc.com"}`).SetPartial(true)
+	v.StringRules(rules)
+	is.True(v.Validate())
+}
+
+func TestValidatorNameHook(t *testing.T) {
+	is := assert.New(t)
+
+	historical := map[string]string{"max_length": "maxLen", "is_email": "email"}
+	Config(func(opt *GlobalOption) {
+		opt.ValidatorNameHook = func(name string) string {
+			if newName, ok := historical[name]; ok {
+				return newName
+			}
+			return name
+		}
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.ValidatorNameHook = nil
+	})
+
+	is.Equal("maxLength", ValidatorName("max_length"))
+	is.Equal("isEmail", ValidatorName("is_email"))
+	is.Equal("required", ValidatorName("required"))
+
+	v := Map(M{"name": "inhere", "email": "invalid"})
+	v.StringRules(MS{
+		"name":  "required|max_length:3|no_such_rule",
+		"email": "is_email|unknownRule:1|-",
+	})
+	is.Equal([]string{"no_such_rule", "unknownRule"}, v.UnresolvedValidators())
+
+	v = Map(M{"name": "inhere", "email": "invalid"})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"name":  "required|max_length:3",
+		"email": "is_email",
+	})
+	is.False(v.Validate())
+	is.Equal("name max length is 3", v.Errors.FieldOne("name"))
+	is.Contains(v.Errors, "email")
+}
+
+func TestValidation_UnresolvedValidators(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{})
+	for name := range validatorValues {
+		v.AddRule("field", name)
+	}
+	for alias := range validatorAliases {
+		v.AddRule("field", alias)
+	}
+	for name := range v.validatorValues {
+		v.AddRule("field", name)
+	}
+	v.AddRule("field", "keys", "alpha")
+	v.AddRule("field", "safe")
+	v.AddRule("field", "custom").SetCheckFunc(func(val interface{}) bool { return true })
+	is.Empty(v.UnresolvedValidators())
+}
@@ -2,18 +2,20 @@ package validate
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"math"
 	"net"
 	"net/url"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/gookit/goutil/fsutil"
-	"github.com/gookit/goutil/mathutil"
 	"github.com/gookit/goutil/strutil"
 )
 
@@ -50,42 +52,47 @@ var (
 	// rxUser           = regexp.MustCompile("^[a-zA-Z0-9!#$%&'*+/=?^_`{|}~.-]+$")
 	// rxHostname       = regexp.MustCompile("^[^\\s]+\\.[^\\s]+$")
 	// rxUserDot        = regexp.MustCompile("(^[.]{1})|([.]{1}$)|([.]{2,})")
-	rxEmail     = regexp.MustCompile(Email)
-	rxISBN10    = regexp.MustCompile("^(?:[0-9]{9}X|[0-9]{10})$")
-	rxISBN13    = regexp.MustCompile("^(?:[0-9]{13})$")
-	rxUUID3     = regexp.MustCompile(UUID3)
-	rxUUID4     = regexp.MustCompile(UUID4)
-	rxUUID5     = regexp.MustCompile(UUID5)
-	rxUUID      = regexp.MustCompile(UUID)
-	rxAlpha     = regexp.MustCompile("^[a-zA-Z]+$")
-	rxAlphaNum  = regexp.MustCompile("^[a-zA-Z0-9]+$")
-	rxAlphaDash = regexp.MustCompile(`^(?:[\w-]+)$`)
-	rxNumber    = regexp.MustCompile("^[0-9]+$")
-	rxInt       = regexp.MustCompile(Int)
-	rxFloat     = regexp.MustCompile(Float)
-	rxCnMobile  = regexp.MustCompile(`^1\d{10}$`)
-	rxHexColor  = regexp.MustCompile("^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$")
-	rxRGBColor  = regexp.MustCompile(RGBColor)
-	rxASCII     = regexp.MustCompile("^[\x00-\x7F]+$")
+	rxEmail     = mustCompilePattern(Email)
+	rxISBN10    = mustCompilePattern("^(?:[0-9]{9}X|[0-9]{10})$")
+	rxISBN13    = mustCompilePattern("^(?:[0-9]{13})$")
+	rxUUID3     = mustCompilePattern(UUID3)
+	rxUUID4     = mustCompilePattern(UUID4)
+	rxUUID5     = mustCompilePattern(UUID5)
+	rxUUID      = mustCompilePattern(UUID)
+	rxAlpha     = mustCompilePattern("^[a-zA-Z]+$")
+	rxAlphaNum  = mustCompilePattern("^[a-zA-Z0-9]+$")
+	rxAlphaDash = mustCompilePattern(`^(?:[\w-]+)$`)
+	rxNumber    = mustCompilePattern("^[0-9]+$")
+	rxInt       = mustCompilePattern(Int)
+	rxFloat     = mustCompilePattern(Float)
+	rxCnMobile  = mustCompilePattern(`^1\d{10}$`)
+	rxHexColor  = mustCompilePattern("^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$")
+	rxRGBColor  = mustCompilePattern(RGBColor)
+	rxASCII     = mustCompilePattern("^[\x00-\x7F]+$")
 	// --
-	rxHexadecimal    = regexp.MustCompile("^[0-9a-fA-F]+$")
-	rxPrintableASCII = regexp.MustCompile("^[\x20-\x7E]+$")
-	rxMultiByte      = regexp.MustCompile("[^\x00-\x7F]")
+	rxHexadecimal    = mustCompilePattern("^[0-9a-fA-F]+$")
+	rxPrintableASCII = mustCompilePattern("^[\x20-\x7E]+$")
+	rxMultiByte      = mustCompilePattern("[^\x00-\x7F]")
 	// rxFullWidth      = regexp.MustCompile(FullWidth)
 	// rxHalfWidth      = regexp.MustCompile(HalfWidth)
-	rxBase64    = regexp.MustCompile(Base64)
-	rxDataURI   = regexp.MustCompile(`^data:.+/(.+);base64,(?:.+)`)
-	rxLatitude  = regexp.MustCompile(Latitude)
-	rxLongitude = regexp.MustCompile(Longitude)
-	rxDNSName   = regexp.MustCompile(DNSName)
-	rxFullURL   = regexp.MustCompile(FullURL)
-	rxURLSchema = regexp.MustCompile(URLSchema)
+	rxBase64    = mustCompilePattern(Base64)
+	rxDataURI   = mustCompilePattern(`^data:.+/(.+);base64,(?:.+)`)
+	rxLatitude  = mustCompilePattern(Latitude)
+	rxLongitude = mustCompilePattern(Longitude)
+	rxDNSName   = mustCompilePattern(DNSName)
+	rxFullURL   = mustCompilePattern(FullURL)
+	rxURLSchema = mustCompilePattern(URLSchema)
 	// rxSSN            = regexp.MustCompile(`^\d{3}[- ]?\d{2}[- ]?\d{4}$`)
-	rxWinPath  = regexp.MustCompile(WinPath)
-	rxUnixPath = regexp.MustCompile(UnixPath)
+	rxWinPath  = mustCompilePattern(WinPath)
+	rxUnixPath = mustCompilePattern(UnixPath)
 	// --
-	rxHasLowerCase = regexp.MustCompile(".*[[:lower:]]")
-	rxHasUpperCase = regexp.MustCompile(".*[[:upper:]]")
+	rxHasLowerCase = mustCompilePattern(".*[[:lower:]]")
+	rxHasUpperCase = mustCompilePattern(".*[[:upper:]]")
+	// -- case format of the identifier
+	rxCamelCase  = mustCompilePattern(`^[a-z][a-z0-9]*(?:[A-Z][a-z0-9]*)*$`)
+	rxPascalCase = mustCompilePattern(`^(?:[A-Z][a-z0-9]*)+$`)
+	rxSnakeCase  = mustCompilePattern(`^[a-z][a-z0-9]*(?:_[a-z0-9]+)*$`)
+	rxKebabCase  = mustCompilePattern(`^[a-z][a-z0-9]*(?:-[a-z0-9]+)*$`)
 )
 
 /*************************************************************
@@ -102,6 +109,8 @@ type funcMeta struct {
 	isInternal bool
 	// last arg is like "... interface{}"
 	isVariadic bool
+	// call with the context of the Validation, instead of the fv. eg: the remote validator
+	ctxFunc func(ctx context.Context, val interface{}, args []interface{}) bool
 }
 
 func (fm *funcMeta) checkArgNum(argNum int, name string) {
@@ -120,6 +129,14 @@ func (fm *funcMeta) checkArgNum(argNum int, name string) {
 	}
 }
 
+// check the arg num is match the validator func, not panic.
+func (fm *funcMeta) matchArgNum(argNum int) bool {
+	if fm.isVariadic {
+		return argNum+1 >= fm.numIn
+	}
+	return argNum == fm.numIn
+}
+
 func newFuncMeta(name string, isInternal bool, fv reflect.Value) *funcMeta {
 	fm := &funcMeta{fv: fv, name: name, isInternal: isInternal}
 	ft := fv.Type()
@@ -132,7 +149,12 @@ func newFuncMeta(name string, isInternal bool, fv reflect.Value) *funcMeta {
 }
 
 // ValidatorName get real validator name.
+// the name will be mapped by the GlobalOption.ValidatorNameHook first, if it is set.
 func ValidatorName(name string) string {
+	if globalOpt.ValidatorNameHook != nil {
+		name = globalOpt.ValidatorNameHook(name)
+	}
+
 	if rName, ok := validatorAliases[name]; ok {
 		return rName
 	}
@@ -156,6 +178,33 @@ func AddValidator(name string, checkFunc interface{}) {
 	validatorMetas[name] = newFuncMeta(name, false, fv)
 }
 
+// SafeValueFunc convert the validated value before save it to the SafeData, the args is the validator arguments.
+type SafeValueFunc func(val interface{}, args []interface{}) interface{}
+
+// the safe value funcs of the validators. see AddSafeValueFunc()
+var (
+	safeValueMux   sync.RWMutex
+	safeValueFuncs = map[string]SafeValueFunc{"moneyString": moneySafeValue}
+)
+
+// AddSafeValueFunc add the func to convert the value is validated by the validator, the result is saved to the SafeData.
+// Usage:
+// 	validate.AddValidator("cents", IsCents)
+// 	validate.AddSafeValueFunc("cents", func(val interface{}, args []interface{}) interface{} {
+// 		return parseCents(val.(string))
+// 	})
+func AddSafeValueFunc(name string, fn SafeValueFunc) {
+	safeValueMux.Lock()
+	safeValueFuncs[name] = fn
+	safeValueMux.Unlock()
+}
+
+func safeValueFunc(name string) SafeValueFunc {
+	safeValueMux.RLock()
+	defer safeValueMux.RUnlock()
+	return safeValueFuncs[name]
+}
+
 // Validators get all validator names
 func Validators() map[string]int {
 	return validators
@@ -170,7 +219,7 @@ func Validators() map[string]int {
 // Required field val check
 func (v *Validation) Required(field string, val interface{}) bool {
 	// check file
-	fd, ok := v.data.(*FormData)
+	fd, ok := v.formData()
 	if ok && fd.HasFile(field) {
 		return true
 	}
@@ -286,6 +335,148 @@ func (v *Validation) RequiredWithoutAll(field string, val interface{}, kvs ...st
 	return NotEqual(val, nil) && NotEqual(val, "")
 }
 
+// RequiredOneOf at least one of the fields must be present and not empty.
+// Usage:
+// 	v.AddRule("email,phone", "requiredOneOf")
+func (v *Validation) RequiredOneOf(fields ...string) bool {
+	for _, field := range fields {
+		val, _ := v.Get(field)
+		if v.Required(field, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// OnlyOneOf at most one of the fields can be present and not empty. eg: the coupon code and the gift card
+// Usage:
+// 	v.AddRule("couponCode,giftCardId", "onlyOneOf")
+func (v *Validation) OnlyOneOf(fields ...string) bool {
+	var num int
+	for _, field := range fields {
+		val, _ := v.Get(field)
+		if v.Required(field, val) {
+			num++
+		}
+	}
+	return num <= 1
+}
+
+// ExcludesWith the field cannot be present and not empty when any of the other specified fields is present and not empty.
+// Usage:
+// 	v.StringRule("couponCode", "excludesWith:giftCardId")
+func (v *Validation) ExcludesWith(field string, val interface{}, fields ...string) bool {
+	if !v.Required(field, val) {
+		return true
+	}
+
+	for _, name := range fields {
+		if name == field {
+			continue
+		}
+
+		other, _ := v.Get(name)
+		if v.Required(name, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// the validators apply to the group of the fields. see Rule.applyGroup()
+const groupValidators = "|requiredOneOf|onlyOneOf|"
+
+func isGroupValidator(name string) bool {
+	return strings.Contains(groupValidators, "|"+name+"|")
+}
+
+// the validators need the field name and will not skip on empty value.
+const fieldValidators = "|changedRequires|immutable|readOnlyInScene|confirmed|allDifferent|sumTo|excludesWith|"
+
+func isFieldValidator(name string) bool {
+	return strings.Contains(fieldValidators, "|"+name+"|")
+}
+
+// Confirmed the field value must be equal to the confirmation field value.
+// the confirmation field default is field + Validation.ConfirmSuffix. eg: "password_confirmation"
+// if it is not exists, will try the camel case name. eg: "PasswordConfirmation" for the struct field
+//
+// Notice: the field and the confirmation field will not be saved to the SafeData, please get the value by v.Get()
+func (v *Validation) Confirmed(field string, val interface{}, confirmField ...string) bool {
+	name := field + v.ConfirmSuffix
+	if len(confirmField) > 0 && confirmField[0] != "" {
+		name = confirmField[0]
+	} else if !v.hasInputField(name) {
+		if camelName := field + camelSuffix(v.ConfirmSuffix); v.hasInputField(camelName) {
+			name = camelName
+		}
+	}
+
+	// dont leak the sensitive values to the safe data.
+	v.stripFields = append(v.stripFields, field, name)
+
+	cVal, has := v.Get(name)
+	// the field not exist. ok if the confirmation field also not exist
+	if _, exist := v.Get(field); !exist {
+		return !has
+	}
+	return has && IsEqual(val, cVal)
+}
+
+// convert the suffix to camel case. eg: "_confirmation" => "Confirmation"
+func camelSuffix(suffix string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(suffix, func(r rune) bool { return r == '_' || r == '-' }) {
+		sb.WriteString(strutil.UpperFirst(part))
+	}
+	return sb.String()
+}
+
+// Immutable the field value cannot be different from the original value.
+// the original data is set by Validation.WithOriginal()
+func (v *Validation) Immutable(field string, _ interface{}) bool {
+	return !v.IsChanged(field)
+}
+
+// ReadOnlyInScene the field cannot be submitted in the given scenes.
+// if Validation.StripReadOnly is true, will strip the field from the safe data instead of reject it.
+func (v *Validation) ReadOnlyInScene(field string, _ interface{}, scenes ...string) bool {
+	// format error
+	if len(scenes) == 0 {
+		return false
+	}
+
+	if _, has := v.Get(field); !has || !Enum(v.scene, scenes) {
+		return true
+	}
+
+	if v.StripReadOnly {
+		v.stripFields = append(v.stripFields, field)
+		return true
+	}
+	return false
+}
+
+// ChangedRequires the other fields must be present and not empty only if the field value is changed.
+// the changed is compare with the original data. see Validation.WithOriginal()
+func (v *Validation) ChangedRequires(field string, _ interface{}, fields ...string) bool {
+	// format error
+	if len(fields) == 0 {
+		return false
+	}
+
+	if !v.IsChanged(field) {
+		return true
+	}
+
+	for _, name := range fields {
+		if val, has := v.Get(name); !has || IsEmpty(val) {
+			return false
+		}
+	}
+	return true
+}
+
 // EqField value should EQ the dst field value
 func (v *Validation) EqField(val interface{}, dstField string) bool {
 	// get dst field value.
@@ -310,6 +501,78 @@ func (v *Validation) NeField(val interface{}, dstField string) bool {
 	return !IsEqual(val, dstVal)
 }
 
+// DifferentFrom value should be different from the dst field value. eg: new password != old password
+// the difference from the NeField() is: will pass on the dst field is not exists, and compare the values loosely.
+func (v *Validation) DifferentFrom(val interface{}, dstField string) bool {
+	dstVal, has := v.Get(dstField)
+	if !has || IsEmpty(dstVal) {
+		return true
+	}
+	return !isSameValue(val, dstVal)
+}
+
+// AllDifferent the values of the field and the given fields must be pairwise distinct.
+// the not exists or empty values will be ignored.
+// Usage:
+// 	v.StringRule("choice1", "allDifferent:choice2,choice3")
+func (v *Validation) AllDifferent(field string, _ interface{}, fields ...string) bool {
+	var vals []interface{}
+	for _, name := range append([]string{field}, fields...) {
+		if name == field && len(vals) > 0 {
+			continue
+		}
+
+		val, has := v.Get(name)
+		if !has || IsEmpty(val) {
+			continue
+		}
+
+		for _, other := range vals {
+			if isSameValue(val, other) {
+				return false
+			}
+		}
+		vals = append(vals, val)
+	}
+	return true
+}
+
+// SumTo the sum of the field and the given fields values must equal to the total.
+// the not exists or empty field value will be treated as 0. useful for allocation forms.
+// Usage:
+// 	v.StringRule("ratioA", "sumTo:100,ratioB,ratioC")
+func (v *Validation) SumTo(field string, _ interface{}, args ...string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	total, err := valueToFloat64(args[0])
+	if err != nil {
+		return false
+	}
+
+	var sum float64
+	seen := make(map[string]bool, len(args))
+	for _, name := range append([]string{field}, args[1:]...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		val, has := v.Get(name)
+		if !has || IsEmpty(val) {
+			continue
+		}
+
+		f64, err := valueToFloat64(val)
+		if err != nil {
+			return false
+		}
+		sum += f64
+	}
+	return floatEqual(sum, total)
+}
+
 // GtField value should GT the dst field value
 func (v *Validation) GtField(val interface{}, dstField string) bool {
 	// get dst field value.
@@ -359,7 +622,7 @@ func (v *Validation) LteField(val interface{}, dstField string) bool {
  *  - file validators
  *************************************************************/
 
-const fileValidators = "|isFile|isImage|inMimeTypes|"
+const fileValidators = "|isFile|isImage|inMimeTypes|archiveMaxEntries|archiveMaxSize|archiveExts|"
 
 var (
 	imageMimeTypes = map[string]string{
@@ -435,6 +698,44 @@ func (v *Validation) InMimeTypes(fd *FormData, field, mimeType string, moreTypes
  * global: basic validators
  *************************************************************/
 
+// the custom empty value checkers of the types. see RegisterEmptyChecker()
+var (
+	emptyCheckerMux sync.RWMutex
+	emptyCheckers   = make(map[reflect.Type]func(val interface{}) bool)
+)
+
+// RegisterEmptyChecker register the func to check the value of the type is empty.
+// the typ is the sample value or the reflect.Type, it affects the IsEmpty(), so the "required" and SkipOnEmpty.
+// Usage:
+// 	validate.RegisterEmptyChecker(uuid.UUID{}, func(val interface{}) bool {
+// 		return val.(uuid.UUID) == uuid.Nil
+// 	})
+func RegisterEmptyChecker(typ interface{}, fn func(val interface{}) bool) {
+	rt, ok := typ.(reflect.Type)
+	if !ok {
+		rt = reflect.TypeOf(typ)
+	}
+
+	if rt == nil || fn == nil {
+		panicf("the type and the checker func of the empty checker cannot be nil")
+	}
+	emptyCheckerMux.Lock()
+	emptyCheckers[rt] = fn
+	emptyCheckerMux.Unlock()
+}
+
+// get the registered empty checker of the type
+func emptyChecker(rt reflect.Type) (func(val interface{}) bool, bool) {
+	emptyCheckerMux.RLock()
+	defer emptyCheckerMux.RUnlock()
+
+	if len(emptyCheckers) == 0 {
+		return nil, false
+	}
+	fn, ok := emptyCheckers[rt]
+	return fn, ok
+}
+
 // IsEmpty of the value
 func IsEmpty(val interface{}) bool {
 	if val == nil {
@@ -444,6 +745,17 @@ func IsEmpty(val interface{}) bool {
 	if s, ok := val.(string); ok {
 		return s == ""
 	}
+
+	if fn, ok := emptyChecker(reflect.TypeOf(val)); ok {
+		return fn(val)
+	}
+
+	// the non-nil pointer of the registered type
+	if rv := reflect.ValueOf(val); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		if fn, ok := emptyChecker(rv.Type().Elem()); ok {
+			return fn(rv.Elem().Interface())
+		}
+	}
 	return ValueIsEmpty(reflect.ValueOf(val))
 }
 
@@ -491,7 +803,12 @@ func IsUint(val interface{}) bool {
 	case uint, uint8, uint16, uint32, uint64:
 		return true
 	case string:
-		_, err := strconv.ParseUint(typVal, 10, 32)
+		str, ok := cleanNumberString(typVal)
+		if !ok {
+			return false
+		}
+
+		_, err := strconv.ParseUint(str, 10, 32)
 		return err == nil
 	}
 	return false
@@ -520,7 +837,10 @@ func IsFloat(val interface{}) bool {
 	case float32, float64:
 		return true
 	case string:
-		return rv != "" && rxFloat.MatchString(rv)
+		str, ok := cleanNumberString(rv)
+		return ok && str != "" && rxFloat.MatchString(str)
+	case json.Number:
+		return rxFloat.MatchString(string(rv))
 	}
 	return false
 }
@@ -599,7 +919,14 @@ func IsInt(val interface{}, minAndMax ...int64) (ok bool) {
 		return false
 	}
 
-	intVal, err := valueToInt64(val, true)
+	var intVal int64
+	var err error
+	if s, isStr := val.(string); isStr && globalOpt.NumberIntString {
+		intVal, err = parseIntString(s)
+	} else {
+		intVal, err = valueToInt64(val, true)
+	}
+
 	if err != nil {
 		return false
 	}
@@ -668,7 +995,8 @@ func HasWhitespace(s string) bool {
 
 // IsIntString check. eg "10"
 func IsIntString(s string) bool {
-	return s != "" && rxInt.MatchString(s)
+	str, ok := cleanNumberString(s)
+	return ok && str != "" && rxInt.MatchString(str)
 }
 
 // IsASCII string.
@@ -743,6 +1071,50 @@ func IsISBN13(s string) bool {
 	return s != "" && rxISBN13.MatchString(s)
 }
 
+// IsEAN8 check the EAN-8 barcode with the check digit.
+func IsEAN8(s string) bool {
+	return len(s) == 8 && isGS1Number(s)
+}
+
+// IsEAN13 check the EAN-13 barcode with the check digit.
+func IsEAN13(s string) bool {
+	return len(s) == 13 && isGS1Number(s)
+}
+
+// IsUPC check the UPC-A barcode with the check digit.
+func IsUPC(s string) bool {
+	return len(s) == 12 && isGS1Number(s)
+}
+
+// IsGTIN14 check the GTIN-14 code with the check digit.
+func IsGTIN14(s string) bool {
+	return len(s) == 14 && isGS1Number(s)
+}
+
+// check the digits string with the GS1 check digit at the end.
+func isGS1Number(s string) bool {
+	sum := 0
+	last := len(s) - 1
+	for i := last; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+
+		if i == last {
+			continue
+		}
+
+		n := int(s[i] - '0')
+		// the weight is 3 for the odd position from the right(exclude the check digit)
+		if (last-i)%2 == 1 {
+			n *= 3
+		}
+		sum += n
+	}
+
+	return (10-sum%10)%10 == int(s[last]-'0')
+}
+
 // IsHexadecimal string.
 func IsHexadecimal(s string) bool {
 	return s != "" && rxHexadecimal.MatchString(s)
@@ -778,6 +1150,26 @@ func IsAlphaDash(s string) bool {
 	return s != "" && rxAlphaDash.MatchString(s)
 }
 
+// IsCamelCase string. eg: "userName", "metricV2"
+func IsCamelCase(s string) bool {
+	return rxCamelCase.MatchString(s)
+}
+
+// IsPascalCase string. eg: "UserName", "HTTPServer"
+func IsPascalCase(s string) bool {
+	return rxPascalCase.MatchString(s)
+}
+
+// IsSnakeCase string. eg: "user_name", "http_requests_total"
+func IsSnakeCase(s string) bool {
+	return rxSnakeCase.MatchString(s)
+}
+
+// IsKebabCase string. eg: "user-name", "x-request-id"
+func IsKebabCase(s string) bool {
+	return rxKebabCase.MatchString(s)
+}
+
 // IsNumber string. should >= 0
 func IsNumber(v interface{}) bool {
 	if s, err := strutil.ToString(v); err == nil {
@@ -804,9 +1196,18 @@ func IsEmail(s string) bool {
 	return s != "" && rxEmail.MatchString(s)
 }
 
-// IsUUID string
-func IsUUID(s string) bool {
-	return s != "" && rxUUID.MatchString(s)
+// IsUUID string. the version is optional, check the version and the RFC 4122 variant. eg: 4, 7
+// Usage:
+// 	v.StringRule("id", "uuid:4")
+func IsUUID(s string, version ...int) bool {
+	if s == "" || !rxUUID.MatchString(s) {
+		return false
+	}
+
+	if len(version) == 0 || version[0] == 0 {
+		return true
+	}
+	return int(s[14]-'0') == version[0] && strings.IndexByte("89ab", s[19]) >= 0
 }
 
 // IsUUID3 string
@@ -939,10 +1340,13 @@ func StringContains(s, sub string) bool {
 	return strings.Contains(s, sub)
 }
 
-// Regexp match value string
+// Regexp match value string. the compiled pattern is cached, see GlobalOption.RegexpCacheSize
 func Regexp(str string, pattern string) bool {
-	ok, _ := regexp.MatchString(pattern, str)
-	return ok
+	rx, err := compileRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return rx.MatchString(str)
 }
 
 /*************************************************************
@@ -1029,7 +1433,7 @@ func NotEqual(val, wantVal interface{}) bool {
 // IntEqual check
 func IntEqual(val interface{}, wantVal int64) bool {
 	// intVal, isInt := IntVal(val)
-	intVal, err := mathutil.Int64(val)
+	intVal, err := toInt64(val)
 	if err != nil {
 		return false
 	}
@@ -1039,7 +1443,7 @@ func IntEqual(val interface{}, wantVal int64) bool {
 
 // Gt check value greater dst value. only check for: int(X), uint(X), float(X)
 func Gt(val interface{}, dstVal int64) bool {
-	intVal, err := mathutil.Int64(val)
+	intVal, err := toInt64(val)
 	if err != nil {
 		return false
 	}
@@ -1050,7 +1454,7 @@ func Gt(val interface{}, dstVal int64) bool {
 // Min check value greater or equal dst value, alias `Gte`.
 // only check for: int(X), uint(X), float(X).
 func Min(val interface{}, min int64) bool {
-	intVal, err := mathutil.Int64(val)
+	intVal, err := toInt64(val)
 	if err != nil {
 		return false
 	}
@@ -1060,7 +1464,7 @@ func Min(val interface{}, min int64) bool {
 
 // Lt less than dst value. only check for: int(X), uint(X), float(X).
 func Lt(val interface{}, dstVal int64) bool {
-	intVal, err := mathutil.Int64(val)
+	intVal, err := toInt64(val)
 	if err != nil {
 		return false
 	}
@@ -1070,7 +1474,7 @@ func Lt(val interface{}, dstVal int64) bool {
 
 // Max less than or equal dst value, alias `Lte`. check for: int(X), uint(X), float(X).
 func Max(val interface{}, max int64) bool {
-	intVal, err := mathutil.Int64(val)
+	intVal, err := toInt64(val)
 	if err != nil {
 		return false
 	}
@@ -1079,8 +1483,9 @@ func Max(val interface{}, max int64) bool {
 }
 
 // Between int value in the given range.
+// the math.MinInt64 min or the math.MaxInt64 max is an open bound. eg: "range:10,"
 func Between(val interface{}, min, max int64) bool {
-	intVal, err := mathutil.Int64(val)
+	intVal, err := toInt64(val)
 	if err != nil {
 		return false
 	}
@@ -1088,60 +1493,130 @@ func Between(val interface{}, min, max int64) bool {
 	return intVal >= min && intVal <= max
 }
 
-/*************************************************************
- * global: array, slice, map validators
- *************************************************************/
-
-// Enum value(int(X),string) should be in the given enum(strings, ints, uints).
-func Enum(val, enum interface{}) bool {
-	if val == nil || enum == nil {
+// BetweenX int value in the given range, the bounds are excluded.
+// the math.MinInt64 min or the math.MaxInt64 max is an open bound. eg: "rangeX:,99"
+func BetweenX(val interface{}, min, max int64) bool {
+	intVal, err := toInt64(val)
+	if err != nil {
 		return false
 	}
 
-	// if is string value
-	if strVal, ok := val.(string); ok {
-		if ss, ok := enum.([]string); ok {
-			for _, strItem := range ss {
-				if strVal == strItem { // exists
-					return true
-				}
-			}
-		}
+	return (min == math.MinInt64 || intVal > min) && (max == math.MaxInt64 || intVal < max)
+}
+
+// use the message of the open bound on the range rule is failed. eg: "range:10," => "min", "rangeX:,99" => "lt"
+func (v *Validation) openRangeReason(name string, min, max int64) {
+	var key string
+	var bound int64
+	switch {
+	case min == math.MinInt64 && max != math.MaxInt64:
+		key, bound = "max", max
+	case max == math.MaxInt64 && min != math.MinInt64:
+		key, bound = "min", min
+	default:
+		return
+	}
 
+	if name == "betweenX" {
+		key = map[string]string{"max": "lt", "min": "gt"}[key]
+	}
+	v.failReason = &failReason{validator: name, key: key, args: []interface{}{bound}}
+}
+
+// MultipleOf check the number value is an exact multiple of the step. eg: 1.5 is multiple of 0.5
+// the float precision error is tolerated. same as the "step" of HTML5 and the "multipleOf" of JSON Schema.
+func MultipleOf(val interface{}, step float64) bool {
+	if step <= 0 {
 		return false
 	}
 
-	// as int value
-	intVal, err := mathutil.Int64(val)
+	f64, err := valueToFloat64(val)
 	if err != nil {
-		intVal,err = valueToInt64ByReflect(val)
-		if err != nil {
-			return false
-		}
+		return false
 	}
 
-	if int64s, ok := toInt64Slice(enum); ok {
-		for _, i64 := range int64s {
-			if intVal == i64 { // exists
-				return true
-			}
-		}
+	quo := f64 / step
+	return floatEqual(quo, math.Round(quo))
+}
+
+// Percent check value is a percentage in the range 0 - 100, allow decimals.
+// the string value can be with the suffix "%". eg: 12.5, "12.5", "12.5%"
+func Percent(val interface{}) bool {
+	if s, ok := val.(string); ok {
+		val = strings.TrimSuffix(strings.TrimSpace(s), "%")
 	}
-	return false
+
+	f64, err := valueToFloat64(val)
+	return err == nil && f64 >= 0 && f64 <= 100
+}
+
+// Probability check value is a probability in the range 0 - 1, allow decimals.
+func Probability(val interface{}) bool {
+	f64, err := valueToFloat64(val)
+	return err == nil && f64 >= 0 && f64 <= 1
+}
+
+/*************************************************************
+ * global: array, slice, map validators
+ *************************************************************/
+
+// Enum value(int(X),uint(X),float(X),string,bool) should be in the given enum(slice, array).
+// the value will compare with type awareness:
+// 	- number compare by numeric equality. eg: 1 == "1" == 1.0
+// 	- bool compare by bool value. eg: true == "true" == "on"
+// 	- string compare by string equality.
+func Enum(val, enum interface{}) bool {
+	return inEnum(val, enum, false)
 }
 
-// NotIn value should be not in the given enum(strings, ints, uints).
+// NotIn value should be not in the given enum(slice, array).
 func NotIn(val, enum interface{}) bool {
 	return false == Enum(val, enum)
 }
 
+// EnumIgnoreCase like Enum(), but the string compare is case-insensitive.
+func EnumIgnoreCase(val, enum interface{}) bool {
+	return inEnum(val, enum, true)
+}
+
+// NotInIgnoreCase like NotIn(), but the string compare is case-insensitive.
+func NotInIgnoreCase(val, enum interface{}) bool {
+	return false == EnumIgnoreCase(val, enum)
+}
+
+func isEnumValidator(name string) bool {
+	switch ValidatorName(name) {
+	case "enum", "notIn", "enumIgnoreCase", "notInIgnoreCase":
+		return true
+	}
+	return false
+}
+
+func inEnum(val, enum interface{}, ignoreCase bool) bool {
+	if val == nil || enum == nil {
+		return false
+	}
+
+	ev := reflect.ValueOf(enum)
+	if ev.Kind() != reflect.Slice && ev.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < ev.Len(); i++ {
+		if enumItemEqual(val, ev.Index(i).Interface(), ignoreCase) { // exists
+			return true
+		}
+	}
+	return false
+}
+
 /*************************************************************
  * global: length validators
  *************************************************************/
 
 // Length equal check for string, array, slice, map
 func Length(val interface{}, wantLen int) bool {
-	ln := CalcLength(val)
+	ln := validateLength(val)
 	if ln == -1 {
 		return false
 	}
@@ -1151,7 +1626,7 @@ func Length(val interface{}, wantLen int) bool {
 
 // MinLength check for string, array, slice, map
 func MinLength(val interface{}, minLen int) bool {
-	ln := CalcLength(val)
+	ln := validateLength(val)
 	if ln == -1 {
 		return false
 	}
@@ -1161,7 +1636,7 @@ func MinLength(val interface{}, minLen int) bool {
 
 // MaxLength check for string, array, slice, map
 func MaxLength(val interface{}, maxLen int) bool {
-	ln := CalcLength(val)
+	ln := validateLength(val)
 	if ln == -1 {
 		return false
 	}
@@ -1206,6 +1681,55 @@ func StringLength(val interface{}, minLen int, maxLen ...int) bool {
 	return RuneLength(val, minLen, maxLen...)
 }
 
+// FitsVarchar check the UTF-8 byte length of the string fits the DB column size.
+// the difference from the StringLength() is: the multi byte chars are calc by the bytes. eg: "中文" is 6
+func FitsVarchar(val interface{}, maxBytes int) bool {
+	switch tv := val.(type) {
+	case string:
+		return len(tv) <= maxBytes
+	case []byte:
+		return len(tv) <= maxBytes
+	}
+	return false
+}
+
+// WordCount count the words of the string, it is unicode-aware.
+// the letters and digits joined by the "'" or "-" is one word, each CJK ideograph and kana is one word.
+// eg: "don't stop" => 2, "你好 world" => 3
+func WordCount(s string) (n int) {
+	inWord := false
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			n++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if !inWord {
+				n++
+				inWord = true
+			}
+		case inWord && (r == '\'' || r == '’' || r == '-') && i+1 < len(runes) && unicode.IsLetter(runes[i+1]):
+			// the joiner in the word. eg: "don't", "e-mail"
+		default:
+			inWord = false
+		}
+	}
+	return
+}
+
+// MinWords check the string words number is greater than or equal to the min.
+func MinWords(val interface{}, min int) bool {
+	s, ok := val.(string)
+	return ok && WordCount(s) >= min
+}
+
+// MaxWords check the string words number is less than or equal to the max.
+func MaxWords(val interface{}, max int) bool {
+	s, ok := val.(string)
+	return ok && WordCount(s) <= max
+}
+
 /*************************************************************
  * global: date/time validators
  *************************************************************/
@@ -1288,3 +1812,66 @@ func AfterDate(srcDate, dstDate string) bool {
 
 	return st.After(dt)
 }
+
+// WithinBusinessHours check the time value is within the business hours.
+// the location default is UTC. the end of hours is exclusive, allow overnight hours. eg: "22:00-06:00"
+// Usage:
+// 	WithinBusinessHours("2019-06-03 10:00", "Mon-Fri", "09:00-17:00", "Europe/Berlin")
+func WithinBusinessHours(val interface{}, days, hours string, location ...string) bool {
+	weekdays, ok := parseWeekdays(days)
+	if !ok {
+		return false
+	}
+
+	start, end, ok := parseHourRange(hours)
+	if !ok {
+		return false
+	}
+
+	loc := time.UTC
+	if len(location) > 0 && location[0] != "" {
+		var err error
+		if loc, err = time.LoadLocation(location[0]); err != nil {
+			return false
+		}
+	}
+
+	t, ok := valueToTime(val, loc)
+	if !ok {
+		return false
+	}
+
+	t = t.In(loc)
+	minutes := t.Hour()*60 + t.Minute()
+	// overnight. eg: "22:00-06:00"
+	if end <= start {
+		if minutes >= start {
+			return weekdays[t.Weekday()]
+		}
+		// after midnight, belongs to the previous day
+		return minutes < end && weekdays[(t.Weekday()+6)%7]
+	}
+
+	return weekdays[t.Weekday()] && minutes >= start && minutes < end
+}
+
+// NotInDateRanges check the time value is not in the date ranges of the named calendar.
+// the calendar is registered by AddCalendar(), returns false on the calendar is not exists.
+func NotInDateRanges(val interface{}, calendar string) bool {
+	ranges, ok := Calendar(calendar)
+	if !ok {
+		return false
+	}
+
+	t, ok := valueToTime(val, time.UTC)
+	if !ok {
+		return false
+	}
+
+	for _, r := range ranges {
+		if r.Contains(t) {
+			return false
+		}
+	}
+	return true
+}
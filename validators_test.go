@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -33,6 +34,47 @@ func TestIsEmpty(t *testing.T) {
 	is.True(ValueIsEmpty(rv))
 }
 
+type nullString struct {
+	String string
+	Valid  bool
+}
+
+type testUUID [4]byte
+
+func TestRegisterEmptyChecker(t *testing.T) {
+	is := assert.New(t)
+
+	RegisterEmptyChecker(nullString{}, func(val interface{}) bool {
+		return !val.(nullString).Valid
+	})
+	RegisterEmptyChecker(reflect.TypeOf(testUUID{}), func(val interface{}) bool {
+		return val.(testUUID) == testUUID{}
+	})
+	defer func() {
+		delete(emptyCheckers, reflect.TypeOf(nullString{}))
+		delete(emptyCheckers, reflect.TypeOf(testUUID{}))
+	}()
+
+	is.True(IsEmpty(nullString{String: "abc"}))
+	is.False(IsEmpty(nullString{Valid: true}))
+	is.True(IsEmpty(&nullString{}))
+	is.True(IsEmpty(testUUID{}))
+	is.False(IsEmpty(testUUID{1}))
+	is.Panics(func() {
+		RegisterEmptyChecker(nil, func(val interface{}) bool { return true })
+	})
+
+	v := Map(M{"name": nullString{String: "abc"}, "id": testUUID{}, "ref": testUUID{}})
+	v.StopOnError = false
+	v.StringRules(MS{"name": "required", "id": "required", "ref": "len:10"})
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Equal("name is required and not empty", v.Errors.FieldOne("name"))
+	is.Equal("id is required and not empty", v.Errors.FieldOne("id"))
+	// skip on empty
+	is.NotContains(v.Errors, "ref")
+}
+
 func TestContains(t *testing.T) {
 	is := assert.New(t)
 
@@ -162,6 +204,72 @@ func TestTypeCheck(t *testing.T) {
 	is.False(IsStrings(map[string]int{}))
 }
 
+func TestNumberStringOptions(t *testing.T) {
+	is := assert.New(t)
+
+	// default: the strict format as before
+	is.False(IsIntString("007"))
+	is.True(IsIntString("+12"))
+	is.True(IsIntString("0"))
+	is.True(IsIntString("-0"))
+	is.True(IsUint("007"))
+	is.False(IsUint("+12"))
+	is.False(IsUint("4294967296"))
+	is.True(IsFloat("+0.5"))
+	is.True(IsFloat("007"))
+	is.False(IsInt("12"))
+	is.False(IsUint("1_000"))
+	is.False(IsIntString("1_000"))
+	is.False(IsFloat("1_000.5"))
+	is.False(IsIntString("+"))
+	is.False(IsIntString("+-1"))
+
+	Config(func(opt *GlobalOption) {
+		opt.NumberLeadingZeros = true
+		opt.NumberPlusSign = true
+		opt.NumberUnderscores = true
+		opt.NumberIntString = true
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.NumberLeadingZeros = false
+		opt.NumberPlusSign = false
+		opt.NumberUnderscores = false
+		opt.NumberIntString = false
+	})
+
+	is.True(IsIntString("007"))
+	is.True(IsIntString("-007"))
+	is.True(IsUint("+12"))
+	is.True(IsFloat("00.5"))
+	is.True(IsFloat("+.5"))
+	is.False(IsUint("4294967296"))
+	is.False(IsIntString("+"))
+	is.False(IsIntString("+-1"))
+	is.False(IsUint("+-1"))
+
+	is.True(IsIntString("1_000_000"))
+	is.True(IsUint("1_000"))
+	is.True(IsFloat("-1_000.5"))
+	is.False(IsIntString("_100"))
+	is.False(IsIntString("100_"))
+	is.False(IsIntString("1__000"))
+	is.False(IsFloat("1_.5"))
+
+	// the int validator check the int string
+	is.True(IsInt("12"))
+	is.True(IsInt("+1_000", 1, 2000))
+	is.True(IsInt("-007", -10))
+	is.False(IsInt("+1_000", 1, 999))
+	is.False(IsInt("1.5"))
+	is.False(IsInt("abc"))
+	is.True(IsInt(12))
+
+	v := Map(M{"num": "1_000", "age": "+007", "price": "+9.9"})
+	v.StopOnError = false
+	v.StringRules(MS{"num": "uint", "age": "int:1,100", "price": "float"})
+	is.True(v.Validate())
+}
+
 // ------------------ value compare ------------------
 
 func TestValueCompare(t *testing.T) {
@@ -226,6 +334,62 @@ func TestValueCompare(t *testing.T) {
 	is.True(Between("3", 2, 5))
 	is.False(Between(6, 2, 5))
 	is.False(Between("invalid", 2, 5))
+
+	// BetweenX
+	is.True(BetweenX(3, 2, 5))
+	is.False(BetweenX(2, 2, 5))
+	is.False(BetweenX(5, 2, 5))
+	is.False(BetweenX("invalid", 2, 5))
+}
+
+func TestValidation_openRange(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"age": 9, "score": 100, "level": 5, "rank": 1})
+	v.StopOnError = false
+	v.StringRules(MS{
+		"age":   "range:10,",
+		"score": "between:,99",
+		"level": "rangeX:1,5",
+		"rank":  "rangeX:1,",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 4)
+	is.Contains(v.Errors.Field("age"), "range")
+	is.Equal("age min value is 10", v.Errors.FieldOne("age"))
+	is.Contains(v.Errors.Field("score"), "between")
+	is.Equal("score max value is 99", v.Errors.FieldOne("score"))
+	is.Equal("level value must be greater than 1 and less than 5", v.Errors.FieldOne("level"))
+	is.Contains(v.Errors.Field("rank"), "rangeX")
+	is.Equal("rank value must be greater than 1", v.Errors.FieldOne("rank"))
+
+	v = Map(M{"level": 5})
+	v.StringRule("level", "rangeX:,5")
+	is.False(v.Validate())
+	is.Equal("level value must be less than 5", v.Errors.One())
+
+	v = Map(M{"age": 10, "score": 99, "level": 4, "rank": 100})
+	v.StringRules(MS{"age": "range:10,", "score": "range: ,99", "level": "rangeX:1,5", "rank": "rangeX:1,"})
+	is.True(v.Validate(), v.Errors.String())
+
+	// the custom messages of the range
+	v = Map(M{"age": 9, "score": 100})
+	v.StopOnError = false
+	v.StringRules(MS{"age": "range:10,", "score": "range:,99"})
+	v.AddMessages(map[string]string{"age.range": "{field} is too small", "range": "{field} is out of range"})
+	is.False(v.Validate())
+	is.Equal("age is too small", v.Errors.FieldOne("age"))
+	is.Equal("score is out of range", v.Errors.FieldOne("score"))
+
+	// direct call
+	is.True(Between(100, 10, math.MaxInt64))
+	is.False(Between(9, 10, math.MaxInt64))
+	is.True(BetweenX(math.MinInt64+1, math.MinInt64, 5))
+	is.False(BetweenX(5, math.MinInt64, 5))
+
+	is.Panics(func() {
+		Map(M{"age": 10}).StringRule("age", "range:,")
+	})
 }
 
 func TestMin(t *testing.T) {
@@ -380,6 +544,22 @@ func TestStringCheck(t *testing.T) {
 	is.True(IsISBN13("9780596528317"))
 	is.False(IsISBN13(""))
 
+	// barcodes
+	is.True(IsEAN8("73513537"))
+	is.False(IsEAN8("73513538"))
+	is.True(IsEAN13("4006381333931"))
+	is.False(IsEAN13("4006381333932"))
+	is.False(IsEAN13("400638133393a"))
+	is.True(IsUPC("036000291452"))
+	is.False(IsUPC("036000291453"))
+	is.True(IsGTIN14("10012345678902"))
+	is.False(IsGTIN14("10012345678903"))
+	is.False(IsGTIN14("4006381333931"))
+	v := Map(M{"ean": "4006381333932", "upc": "036000291452"})
+	v.StringRules(MS{"ean": "ean13", "upc": "upc"})
+	is.False(v.Validate())
+	is.Equal("ean value must be a valid EAN-13 barcode", v.Errors.One())
+
 	// IsHexColor
 	is.True(IsHexColor("ccc"))
 	is.True(IsHexColor("#ccc"))
@@ -412,6 +592,14 @@ func TestStringCheck(t *testing.T) {
 	is.True(IsUUID5("f6785639-778b-5db8-b1b3-60962fb4f38d"))
 	is.False(IsUUID5(""))
 
+	// UUID with version
+	is.True(IsUUID("fd2fff4c-cc39-11e8-a8d5-f2801f1b9fd1", 1))
+	is.True(IsUUID("8098f6fb-1557-4633-b82b-40e1b26137bf", 4))
+	is.True(IsUUID("017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7))
+	is.True(IsUUID("8098f6fb-1557-4633-b82b-40e1b26137bf", 0))
+	is.False(IsUUID("8098f6fb-1557-4633-b82b-40e1b26137bf", 7))
+	is.False(IsUUID("8098f6fb-1557-4633-c82b-40e1b26137bf", 4)) // not the RFC 4122 variant
+
 	// IsLatitude
 	is.True(IsLatitude("29.8431681298"))
 	is.False(IsLatitude(""))
@@ -548,6 +736,156 @@ func TestLength(t *testing.T) {
 	// MaxLength
 	is.True(MaxLength("abc", 5))
 	is.False(MaxLength(nil, 5))
+
+	// count the runes
+	Config(func(opt *GlobalOption) {
+		opt.CountRunes = true
+	})
+	defer Config(func(opt *GlobalOption) {
+		opt.CountRunes = false
+	})
+
+	is.True(Length("a中文", 3))
+	is.True(MinLength("中文", 2))
+	is.True(MaxLength("张小明", 3))
+	is.True(Length([]string{"a", "b"}, 2))
+
+	v := Map(M{"name": "张小明"})
+	v.StringRule("name", "required|minLen:2|maxLen:4")
+	is.True(v.Validate(), v.Errors.One())
+}
+
+func TestFitsVarchar(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(FitsVarchar("abc", 3))
+	is.True(FitsVarchar([]byte("abc"), 3))
+	is.True(FitsVarchar("", 0))
+	is.False(FitsVarchar("中文", 5))
+	is.True(FitsVarchar("中文", 6))
+	is.False(FitsVarchar("😀", 3))
+	is.False(FitsVarchar(123, 10))
+
+	// passed the rune length check, but the bytes too long
+	v := Map(M{"title": "中文标题"})
+	v.StringRule("title", "strLen:1,4|fitsVarchar:10")
+	is.False(v.Validate())
+	is.Equal("title value is too long, the max size is 10 bytes", v.Errors.One())
+
+	v = Map(M{"title": "中文标题"})
+	v.StringRule("title", "max_bytes:12")
+	is.True(v.Validate())
+}
+
+func TestWordCount(t *testing.T) {
+	is := assert.New(t)
+
+	is.Equal(0, WordCount(""))
+	is.Equal(0, WordCount(" ,. "))
+	is.Equal(2, WordCount("hello, world!"))
+	is.Equal(2, WordCount("don't stop"))
+	is.Equal(3, WordCount("an e-mail - address"))
+	is.Equal(3, WordCount("Größe café naïve"))
+	is.Equal(3, WordCount("你好 world"))
+	is.Equal(4, WordCount("こん 123 abc"))
+	is.Equal(2, WordCount("안녕하세요 세계"))
+
+	is.True(MinWords("one two three", 3))
+	is.False(MinWords("one two", 3))
+	is.False(MinWords(123, 1))
+	is.True(MaxWords("one two", 2))
+	is.False(MaxWords("one two three", 2))
+
+	v := Map(M{"desc": "too short"})
+	v.StringRule("desc", "required|minWords:3|max_words:5")
+	is.False(v.Validate())
+	is.Equal("desc must contain at least 3 words", v.Errors.One())
+}
+
+func TestPercentAndProbability(t *testing.T) {
+	is := assert.New(t)
+
+	for _, val := range []interface{}{0, 100, 12.5, uint8(50), "33.33", " 99 ", "12.5%"} {
+		is.True(Percent(val), "%v", val)
+	}
+	for _, val := range []interface{}{-1, 100.01, "101", "abc", "%", nil, []int{1}} {
+		is.False(Percent(val), "%v", val)
+	}
+
+	is.True(Probability(0))
+	is.True(Probability(1))
+	is.True(Probability("0.75"))
+	is.True(Probability(float32(0.5)))
+	is.False(Probability(1.1))
+	is.False(Probability(-0.1))
+	is.False(Probability("50%"))
+
+	v := Map(M{"discount": "120", "rate": 0.3})
+	v.StringRules(MS{"discount": "percent", "rate": "probability"})
+	is.False(v.Validate())
+	is.Equal("discount value must be a percentage between 0 and 100", v.Errors.One())
+}
+
+func TestMultipleOf(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(MultipleOf(10, 5))
+	is.True(MultipleOf(0, 5))
+	is.True(MultipleOf(-15, 5))
+	is.True(MultipleOf(1.5, 0.5))
+	is.True(MultipleOf("2.5", 0.5))
+	is.True(MultipleOf(0.3, 0.1))
+	is.True(MultipleOf(19.99, 0.01))
+	is.True(MultipleOf(1e12, 0.01))
+	is.False(MultipleOf(7, 5))
+	is.False(MultipleOf(1.25, 0.5))
+	is.False(MultipleOf(0.31, 0.1))
+	is.False(MultipleOf(10, 0))
+	is.False(MultipleOf("abc", 1))
+
+	v := Map(M{"qty": 12, "price": "9.99", "size": 7})
+	v.StopOnError = false
+	v.StringRules(MS{"qty": "step:5", "price": "multipleOf:0.01", "size": "multiple_of:0.5"})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("qty value must be a multiple of 5", v.Errors.One())
+}
+
+func TestCaseFormat(t *testing.T) {
+	is := assert.New(t)
+
+	for _, s := range []string{"user", "userName", "metricV2", "userID"} {
+		is.True(IsCamelCase(s), s)
+	}
+	for _, s := range []string{"", "User", "user_name", "user-name", "2user", "user name"} {
+		is.False(IsCamelCase(s), s)
+	}
+
+	for _, s := range []string{"User", "UserName", "HTTPServer", "V2"} {
+		is.True(IsPascalCase(s), s)
+	}
+	for _, s := range []string{"", "user", "userName", "User_Name", "2User"} {
+		is.False(IsPascalCase(s), s)
+	}
+
+	for _, s := range []string{"user", "user_name", "http_requests_total", "v2_api"} {
+		is.True(IsSnakeCase(s), s)
+	}
+	for _, s := range []string{"", "User_name", "user__name", "_user", "user_", "user-name"} {
+		is.False(IsSnakeCase(s), s)
+	}
+
+	for _, s := range []string{"user", "user-name", "x-request-id", "v2-api"} {
+		is.True(IsKebabCase(s), s)
+	}
+	for _, s := range []string{"", "User-name", "user--name", "-user", "user-", "user_name"} {
+		is.False(IsKebabCase(s), s)
+	}
+
+	v := Map(M{"metric": "HttpRequests", "key": "custom_field"})
+	v.StringRules(MS{"metric": "snake_case", "key": "snakeCase"})
+	is.False(v.Validate())
+	is.Equal("metric value must be in snake_case", v.Errors.One())
 }
 
 func TestEnumAndNotIn(t *testing.T) {
@@ -592,6 +930,26 @@ func TestEnumAndNotIn(t *testing.T) {
 		is.True(NotIn(val, list))
 		is.False(Enum(val, list))
 	}
+
+	// typed compare
+	is.True(Enum("2", []int{1, 2}))
+	is.True(Enum(2.0, []string{"1", "2"}))
+	is.True(Enum(1.5, []string{"1.5", "2"}))
+	is.True(Enum(uint64(3), []interface{}{"a", 3}))
+	is.True(Enum(true, []string{"on", "off"}))
+	is.True(Enum("yes", []bool{true}))
+	is.True(Enum(false, [2]bool{false, true}))
+	is.False(Enum(1.5, []int{1, 2}))
+	is.False(Enum("1.0", []string{"1"}))
+	is.False(Enum(true, []string{"abc"}))
+	is.False(Enum("A", []string{"a", "b"}))
+
+	// ignore case
+	is.True(EnumIgnoreCase("A", []string{"a", "b"}))
+	is.True(EnumIgnoreCase(2, []string{"1", "2"}))
+	is.False(EnumIgnoreCase("C", []string{"a", "b"}))
+	is.True(NotInIgnoreCase("C", []string{"a", "b"}))
+	is.False(NotInIgnoreCase("B", []string{"a", "b"}))
 }
 
 func TestDateCheck(t *testing.T) {
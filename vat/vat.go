@@ -0,0 +1,182 @@
+// Package vat provide the EU VAT identification number validator, with an optional remote verification hook.
+//
+// it is an optional package, call Register() to add the validator "vatNumber" to the validate.
+//
+// 	vat.Register()
+//
+// 	v := validate.Map(data)
+// 	v.StringRule("vatId", "required|vatNumber:EU")
+//
+// the remote verification(eg: the VIES service) can be injected by SetVerifier():
+//
+// 	vat.SetVerifier(func(ctx context.Context, country, number string) (bool, error) {
+// 		// call the VIES service ...
+// 	}, 3*time.Second)
+package vat
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gookit/validate"
+)
+
+// ValidatorName the validator name registered by Register()
+const ValidatorName = "vatNumber"
+
+// EU the region name for match all EU member states
+const EU = "EU"
+
+// DefaultTimeout the default timeout of the remote verification
+const DefaultTimeout = 5 * time.Second
+
+// the VAT number formats of the EU member states, without the country prefix.
+// the key is the VAT prefix, Greece use "EL" and the Northern Ireland use "XI".
+var formats = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^\d{2,10}$`),
+	"SE": regexp.MustCompile(`^\d{12}$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+}
+
+// VerifyFunc the remote verification func. the country is the VAT prefix, the number is without the prefix.
+type VerifyFunc func(ctx context.Context, country, number string) (bool, error)
+
+var (
+	verifyMux sync.RWMutex
+	verifier  VerifyFunc
+	timeout   = DefaultTimeout
+	// pass the validation on the verifier return error(eg: the service is unavailable)
+	passOnError bool
+)
+
+// Register add the validator "vatNumber" and the error message to the validate.
+func Register() {
+	validate.AddValidator(ValidatorName, func(val interface{}, region string) bool {
+		s, ok := val.(string)
+		return ok && Check(region, s)
+	})
+	validate.AddGlobalMessages(map[string]string{
+		ValidatorName: "{field} value must be a valid VAT number of %s",
+	})
+}
+
+// SetVerifier set the remote verification hook and the timeout. set nil to disable it.
+// the timeout <= 0 will use the DefaultTimeout.
+func SetVerifier(fn VerifyFunc, d time.Duration) {
+	if d <= 0 {
+		d = DefaultTimeout
+	}
+
+	verifyMux.Lock()
+	verifier, timeout = fn, d
+	verifyMux.Unlock()
+}
+
+// PassOnError set whether pass the validation on the verifier return error or timeout. default is false.
+func PassOnError(pass bool) {
+	verifyMux.Lock()
+	passOnError = pass
+	verifyMux.Unlock()
+}
+
+// Countries get the supported VAT prefixes
+func Countries() []string {
+	codes := make([]string, 0, len(formats))
+	for code := range formats {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// Normalize the VAT number, remove the spaces, dots and dashes. eg: "de 123.456.789" => "DE123456789"
+func Normalize(vatNo string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(vatNo))
+}
+
+// IsValidFormat check the VAT number format with the country prefix. eg: "DE123456789"
+func IsValidFormat(vatNo string) bool {
+	s := Normalize(vatNo)
+	if len(s) < 3 {
+		return false
+	}
+
+	re, ok := formats[s[:2]]
+	return ok && re.MatchString(s[2:])
+}
+
+// Check the VAT number is valid for the region. the region allow "EU" or an VAT prefix(eg: "DE").
+// if has set the verifier, will verify it by the remote service after the format check.
+func Check(region, vatNo string) bool {
+	s := Normalize(vatNo)
+	if !IsValidFormat(s) {
+		return false
+	}
+
+	region = strings.ToUpper(region)
+	if region != EU && region != s[:2] {
+		return false
+	}
+	return verify(s[:2], s[2:])
+}
+
+func verify(country, number string) bool {
+	verifyMux.RLock()
+	fn, d, pass := verifier, timeout, passOnError
+	verifyMux.RUnlock()
+
+	if fn == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	type result struct {
+		ok  bool
+		err error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		ok, err := fn(ctx, country, number)
+		ch <- result{ok, err}
+	}()
+
+	select {
+	case ret := <-ch:
+		if ret.err != nil {
+			return pass
+		}
+		return ret.ok
+	case <-ctx.Done():
+		return pass
+	}
+}
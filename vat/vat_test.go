@@ -0,0 +1,86 @@
+package vat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidFormat(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsValidFormat("DE123456789"))
+	is.True(IsValidFormat("de 123.456.789"))
+	is.True(IsValidFormat("ATU12345678"))
+	is.True(IsValidFormat("NL123456789B01"))
+	is.True(IsValidFormat("FR12345678901"))
+	is.True(IsValidFormat("IE1234567WA"))
+	is.True(IsValidFormat("EL123456789"))
+	is.False(IsValidFormat("GR123456789"))
+	is.False(IsValidFormat("DE12345678"))
+	is.False(IsValidFormat("AT12345678"))
+	is.False(IsValidFormat("US123456789"))
+	is.False(IsValidFormat("DE"))
+	is.Len(Countries(), 28)
+}
+
+func TestCheck(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(Check("EU", "DE123456789"))
+	is.True(Check("de", "DE123456789"))
+	is.False(Check("FR", "DE123456789"))
+	is.False(Check("EU", "DE1234"))
+}
+
+func TestSetVerifier(t *testing.T) {
+	is := assert.New(t)
+	defer SetVerifier(nil, 0)
+	defer PassOnError(false)
+
+	var gotCountry, gotNumber string
+	SetVerifier(func(ctx context.Context, country, number string) (bool, error) {
+		gotCountry, gotNumber = country, number
+		return number == "123456789", nil
+	}, time.Second)
+	is.True(Check("EU", "de 123 456 789"))
+	is.Equal("DE", gotCountry)
+	is.Equal("123456789", gotNumber)
+	is.False(Check("EU", "DE987654321"))
+
+	// error
+	SetVerifier(func(ctx context.Context, country, number string) (bool, error) {
+		return false, errors.New("service unavailable")
+	}, 0)
+	is.False(Check("EU", "DE123456789"))
+	PassOnError(true)
+	is.True(Check("EU", "DE123456789"))
+	PassOnError(false)
+
+	// timeout
+	SetVerifier(func(ctx context.Context, country, number string) (bool, error) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return true, nil
+	}, 10*time.Millisecond)
+	is.False(Check("EU", "DE123456789"))
+}
+
+func TestRegister(t *testing.T) {
+	is := assert.New(t)
+
+	Register()
+	v := validate.Map(validate.M{"vat1": "DE123456789", "vat2": "DE12345"})
+	v.StopOnError = false
+	v.StringRules(validate.MS{
+		"vat1": "required|vatNumber:EU",
+		"vat2": "required|vatNumber:EU",
+	})
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Equal("vat2 value must be a valid VAT number of EU", v.Errors.One())
+}
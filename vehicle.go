@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"strings"
+	"sync"
+)
+
+// the VIN transliteration values of the letters. the I, O, Q are not allowed.
+var vinLetterValues = map[byte]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+// the weights of each VIN position
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// IsVIN check the vehicle identification number(ISO 3779), with the check digit at position 9.
+func IsVIN(s string) bool {
+	if len(s) != 17 {
+		return false
+	}
+
+	s = strings.ToUpper(s)
+	sum := 0
+	for i := 0; i < 17; i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			sum += int(c-'0') * vinWeights[i]
+		} else if n, ok := vinLetterValues[c]; ok {
+			sum += n * vinWeights[i]
+		} else {
+			return false
+		}
+	}
+
+	check := byte('0' + sum%11)
+	if sum%11 == 10 {
+		check = 'X'
+	}
+	return s[8] == check
+}
+
+// registry of the license plate patterns. use for the validator "plate"
+var (
+	plateMux      sync.RWMutex
+	platePatterns = map[string]*builtinPattern{
+		"CN": mustCompilePattern(`^[京津沪渝冀豫云辽黑湘皖鲁新苏浙赣鄂桂甘晋蒙陕吉闽贵粤青藏川宁琼][A-HJ-NP-Z][A-HJ-NP-Z0-9]{4,5}[A-HJ-NP-Z0-9挂学警港澳]$`),
+		"DE": mustCompilePattern(`^[A-ZÄÖÜ]{1,3}[- ][A-Z]{1,2}[- ]?[1-9]\d{0,3}[EH]?$`),
+		"FR": mustCompilePattern(`^[A-Z]{2}-?\d{3}-?[A-Z]{2}$`),
+		"GB": mustCompilePattern(`^[A-Z]{2}\d{2} ?[A-Z]{3}$`),
+		"IT": mustCompilePattern(`^[A-Z]{2} ?\d{3} ?[A-Z]{2}$`),
+	}
+)
+
+// AddPlatePattern add or override the license plate pattern of the region.
+// Usage:
+// 	validate.AddPlatePattern("NL", `^[A-Z0-9]{2}-[A-Z0-9]{2,3}-[A-Z0-9]{1,2}$`)
+func AddPlatePattern(region, pattern string) {
+	re := mustCompilePattern(pattern)
+
+	plateMux.Lock()
+	platePatterns[strings.ToUpper(region)] = re
+	plateMux.Unlock()
+}
+
+// PlatePattern get the license plate pattern of the region, it is matched by the regex engine on it is set.
+func PlatePattern(region string) (RegexpMatcher, bool) {
+	plateMux.RLock()
+	defer plateMux.RUnlock()
+
+	re, ok := platePatterns[strings.ToUpper(region)]
+	return re, ok
+}
+
+// IsPlate check the license plate number of the region. returns false on the region has no pattern.
+// Usage:
+// 	IsPlate("M-AB 1234", "DE")
+func IsPlate(s, region string) bool {
+	re, ok := PlatePattern(region)
+	return ok && s != "" && re.MatchString(strings.ToUpper(s))
+}
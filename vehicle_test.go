@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsVIN(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsVIN("1M8GDM9AXKP042788"))
+	is.True(IsVIN("1m8gdm9axkp042788"))
+	is.True(IsVIN("11111111111111111"))
+	is.False(IsVIN("1M8GDM9A1KP042788"))
+	is.False(IsVIN("1M8GDM9AXKP04278"))
+	is.False(IsVIN("IM8GDM9AXKP042788"))
+	is.False(IsVIN(""))
+
+	v := Map(M{"vin": "1M8GDM9A1KP042788"})
+	v.StringRule("vin", "required|vin")
+	is.False(v.Validate())
+	is.Equal("vin value must be a valid vehicle identification number", v.Errors.One())
+}
+
+func TestIsPlate(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(IsPlate("M-AB 1234", "DE"))
+	is.True(IsPlate("b-x 12e", "de"))
+	is.True(IsPlate("AB-123-CD", "FR"))
+	is.True(IsPlate("AB12 CDE", "GB"))
+	is.True(IsPlate("京A12345", "CN"))
+	is.False(IsPlate("M-AB 0123", "DE"))
+	is.False(IsPlate("AB12345", "GB"))
+	is.False(IsPlate("", "DE"))
+	is.False(IsPlate("AB-12-CD", "XX"))
+
+	defer delete(platePatterns, "NL")
+	AddPlatePattern("nl", `^[A-Z0-9]{2}-[A-Z0-9]{2,3}-[A-Z0-9]{1,2}$`)
+	_, ok := PlatePattern("NL")
+	is.True(ok)
+	is.True(IsPlate("12-ABC-3", "NL"))
+
+	v := Map(M{"plate": "AB12345"})
+	v.StringRule("plate", "required|plate:GB")
+	is.False(v.Validate())
+	is.Equal("plate value must be a valid license plate number of GB", v.Errors.One())
+
+	// unknown region: fail the rule, TryStringRule report it
+	v = Map(M{"plate": "AB-12-CD"})
+	v.StringRule("plate", "plate:XX")
+	is.False(v.Validate())
+	is.Equal("plate value must be a valid license plate number of XX", v.Errors.One())
+
+	err := Map(M{}).TryStringRule("plate", "required|plate:XX")
+	is.Error(err)
+	is.Contains(err.Error(), "the license plate pattern of the region 'XX' is not exists")
+}
@@ -0,0 +1,11 @@
+module github.com/gookit/validate/yamldata
+
+go 1.11
+
+require (
+	github.com/gookit/validate v0.0.0
+	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/gookit/validate => ../
@@ -0,0 +1,23 @@
+// Package yamldata provide the YAML data source, it is a sub-module
+// to avoid add the dependency "gopkg.in/yaml.v3" to the validate.
+//
+// 	d, err := yamldata.FromString(s)
+// 	v := d.Create()
+// 	v.StringRule("db.host", "required|ip")
+package yamldata
+
+import (
+	"github.com/gookit/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// FromString build data instance from YAML string.
+func FromString(s string) (*validate.MapData, error) {
+	return FromBytes([]byte(s))
+}
+
+// FromBytes build data instance from YAML bytes.
+// the nested mappings can be access by the dot path. eg: "db.host"
+func FromBytes(bs []byte) (*validate.MapData, error) {
+	return validate.FromBytesWith(bs, yaml.Unmarshal)
+}
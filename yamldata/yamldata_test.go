@@ -0,0 +1,45 @@
+package yamldata
+
+import (
+	"testing"
+
+	"github.com/gookit/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromString(t *testing.T) {
+	is := assert.New(t)
+
+	_, err := FromString("- invalid")
+	is.Error(err)
+
+	d, err := FromString(`
+name: inhere
+age: 30
+db:
+  host: 127.0.0.1
+  port: 3306
+servers:
+  - name: web1
+  - name: web2
+`)
+	is.Nil(err)
+	v := d.Create()
+	v.StopOnError = false
+	v.StringRules(validate.MS{
+		"name":           "required|string:6,12",
+		"age":            "required|int|range:1,100",
+		"db.host":        "required|ip",
+		"db.port":        "required|int|max:65535",
+		"servers.*.name": "required|minLen:3",
+	})
+	is.True(v.Validate(), v.Errors.String())
+	is.Equal("127.0.0.1", v.SafeVal("db.host"))
+
+	d, err = FromBytes([]byte("db:\n  port: 70000\n"))
+	is.Nil(err)
+	v = d.Validation()
+	v.StringRule("db.port", "required|int|max:65535")
+	is.False(v.Validate())
+	is.Contains(v.Errors.String(), "db.port")
+}